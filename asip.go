@@ -1,424 +1,612 @@
 // Package asip is a Alexa Website Info page parser.
+//
+// HTTP concerns live in package fetch and HTML extraction in package parse,
+// this package wires them together.
+//
+// This is the second major version of the module: every lookup takes a
+// context, configuration is done with options and percents are numeric.
+// The first version is frozen at the v1 tags.
 package asip
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"net"
 	"net/http"
-	"strconv"
+	"net/url"
 	"strings"
+	"time"
 
-	"github.com/PuerkitoBio/goquery"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/enrich"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/fetch"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/metrics"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
 )
 
+// SchemaVersion is the version of serialized sites.
+const SchemaVersion = parse.SchemaVersion
+
 const (
-	seGlobalRank   = "span.globleRank span div strong"
-	seLocalRank    = "span.countryRank span div strong"
-	seCountry      = "span.countryRank span h4 a"
-	seVisitors     = "table#demographics_div_country_table tbody"
-	seKeywords     = "table#keywords_top_keywords_table tbody"
-	seUpstreams    = "table#keywords_upstream_site_table tbody"
-	seLinks        = "table#linksin_table tbody"
-	seLinkingTotal = "section#linksin-panel-content div span div span.font-4.box1-r"
-	seRelated      = "table#audience_overlap_table tbody"
-	seCategories   = "table#category_link_table tbody"
-	seSubdomains   = "table#subdomain_table tbody"
-	seTitle        = "div.row-fluid.siteinfo-site-summary span div p"
-	seDescription  = "section#contact-panel-content div.row-fluid span.span8 p.color-s3"
-	seNoData       = "section#no-enough-data"
-	asiLocation    = "https://www.alexa.com/siteinfo/%s?ver=classic"
+	asiBase     = "https://www.alexa.com"
+	asiLocation = asiBase + "/siteinfo/%s?ver=classic"
+	provider    = "alexa.com"
 )
 
 // ErrNoEnoughData is returned when a domain is not in top 1M.
-var ErrNoEnoughData = errors.New("asip: no enough data")
+var ErrNoEnoughData = parse.ErrNoEnoughData
+
+// ErrLayoutChanged is returned when too many sections are missing from a
+// page.
+var ErrLayoutChanged = parse.ErrLayoutChanged
+
+// ErrUnexpectedContentType is matched by a ContentTypeError.
+var ErrUnexpectedContentType = errors.New("asip: unexpected content type")
+
+const snippetSize = 256
+
+type (
+	// Site is Website Traffic Statistics from alexa.com.
+	Site = parse.Site
+	// Link is a site and page that links to the website.
+	Link = parse.Link
+	// Visitor represents a variety of visitors from a single country.
+	Visitor = parse.Visitor
+	// Keyword is a one of the top keywords from search engines.
+	Keyword = parse.Keyword
+
+	// Keywords are top keywords with normalization helpers.
+	Keywords = parse.Keywords
+	// Upstream sites people visited immediately before this site.
+	Upstream = parse.Upstream
+	// Subdomain represent subdomains where visitors go from the site.
+	Subdomain = parse.Subdomain
+	// Percent is a share as printed on the page along with its numeric value.
+	Percent = parse.Percent
+	// CategoryPath is a DMOZ-style breadcrumb from the top category down.
+	CategoryPath = parse.CategoryPath
+	// Trends are changes of the figures versus the previous 3 months.
+	Trends = parse.Trends
+	// Trend is the current value of a figure along with its change.
+	Trend = parse.Trend
+	// TrafficSources are shares of visits by where they come from.
+	TrafficSources = parse.TrafficSources
+	// LinkingCount is a number of sites linking in as of a date.
+	LinkingCount = parse.LinkingCount
+
+	// Continent is a two-letter continent code like parse.Europe.
+	Continent = parse.Continent
+
+	// Warning is an implausible value found by Site.Validate.
+	Warning = parse.Warning
+
+	// FieldError is returned when a section of the page is missing.
+	FieldError = parse.FieldError
+	// DNSRecords are resolved records of a single host.
+	DNSRecords = parse.DNSRecords
+	// Enrichment holds data gathered from third-party services.
+	Enrichment = parse.Enrichment
+	// Meta describes where and when a Site was fetched.
+	Meta = parse.Meta
+)
 
-// Conf is a asip configuration.
-type Conf struct {
-	client *http.Client
+// StatusError is returned when alexa.com responds with a non-OK status.
+type StatusError struct {
+	Code   int
+	Domain string
 }
 
-// NewWithClient bootstraps configuration with a customized client.
-func NewWithClient(c *http.Client) *Conf {
-	return &Conf{c}
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status code: %d, no data for %s?", e.Code, e.Domain)
 }
 
-// Site is Website Traffic Statistics from alexa.com.
-type Site struct {
-	Title        string
-	Description  string
-	MainCountry  string
-	GlobalRank   uint
-	LocalRank    uint
-	LinkingTotal uint
-	Visitors     []Visitor
-	Keywords     []Keyword
-	Upstreams    []Upstream
-	Related      []string
-	Subdomains   []Subdomain
-	Categories   []string
-	LinksFrom    []Link
+// ContentTypeError is returned when the response is not an HTML page, e.g.
+// a JSON error or something a broken proxy served instead.
+type ContentTypeError struct {
+	ContentType string
+	Snippet     string // beginning of the body
 }
 
-// Link is a site and page that links to the website.
-type Link struct {
-	Site string
-	Page string
+func (e *ContentTypeError) Error() string {
+	return fmt.Sprintf("%v %q: %s", ErrUnexpectedContentType, e.ContentType, e.Snippet)
 }
 
-// Visitor represents a variety of visitors from a single country.
-type Visitor struct {
-	Country   string
-	Percent   string
-	LocalRank uint
+// Unwrap makes errors.Is match ErrUnexpectedContentType.
+func (e *ContentTypeError) Unwrap() error {
+	return ErrUnexpectedContentType
 }
 
-// Keyword is a one of the top keywords from search engines.
-type Keyword struct {
-	Word    string
-	Percent string
+// ErrTruncatedResponse is matched by a TruncatedError.
+var ErrTruncatedResponse = errors.New("asip: truncated response")
+
+// TruncatedError is returned when the connection dropped mid-body, so the
+// page failed to parse for lack of its end rather than a layout change.
+type TruncatedError struct {
+	Read     int64 // bytes of the body read
+	Expected int64 // Content-Length, -1 if unknown
 }
 
-// Upstream sites people visited immediately before this site.
-type Upstream struct {
-	Site    string
-	Percent string
+func (e *TruncatedError) Error() string {
+	if e.Expected < 0 {
+		return fmt.Sprintf("%v after %d bytes, retry", ErrTruncatedResponse, e.Read)
+	}
+	return fmt.Sprintf("%v after %d of %d bytes, retry", ErrTruncatedResponse, e.Read, e.Expected)
 }
 
-// Subdomain represent subdomains where visitors go from the site.
-type Subdomain struct {
-	Domain  string
-	Percent string
+// Unwrap makes errors.Is match ErrTruncatedResponse.
+func (e *TruncatedError) Unwrap() error {
+	return ErrTruncatedResponse
 }
 
-type findable interface {
-	Find(string) *goquery.Selection
-	Text() string
+// Temporary hints the lookup is worth retrying.
+func (e *TruncatedError) Temporary() bool {
+	return true
 }
 
-func parse(body io.Reader) (*Site, error) {
-	d, err := goquery.NewDocumentFromReader(body)
-	if err != nil {
-		return nil, err
+// truncated tells if parsing failed with err because the body was cut: it
+// ended early, fell short of Content-Length or lacks the closing html tag.
+func truncated(err error, resp *http.Response, body *countingReader) *TruncatedError {
+	if err == nil || errors.Is(err, parse.ErrNoEnoughData) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil
 	}
 
-	if noEnoughData(d) {
-		return nil, ErrNoEnoughData
+	t := &TruncatedError{Read: body.n, Expected: resp.ContentLength}
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return t
+	case resp.ContentLength >= 0 && body.n < resp.ContentLength:
+		return t
+	case !body.ended():
+		return t
 	}
+	return nil
+}
 
-	var s Site
-	gr, err := globalRank(d)
-	if err != nil {
-		return nil, err
+// checkResponse makes sure resp is a successful HTML page.
+func checkResponse(resp *http.Response, domain string) error {
+	if resp.StatusCode != http.StatusOK {
+		return &StatusError{resp.StatusCode, domain}
 	}
-	s.GlobalRank = uint(gr)
 
-	lr, err := localRank(d)
-	if err != nil {
-		return &s, err
+	ct := resp.Header.Get("Content-Type")
+	if isHTML(ct) {
+		return nil
 	}
-	s.LocalRank = uint(lr)
 
-	country, err := country(d)
-	if err != nil {
-		return &s, err
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, snippetSize))
+	return &ContentTypeError{
+		ContentType: ct,
+		Snippet:     strings.ToValidUTF8(string(b), ""),
 	}
-	s.MainCountry = country
+}
 
-	lt, err := linkingTotal(d)
-	if err != nil {
-		return &s, err
+// isHTML reports if ct is an HTML content type, the empty one is assumed to
+// be.
+func isHTML(ct string) bool {
+	if ct == "" {
+		return true
 	}
-	s.LinkingTotal = uint(lt)
+	mt, _, err := mime.ParseMediaType(ct)
+	return err == nil && (mt == "text/html" || mt == "application/xhtml+xml")
+}
 
-	tt, err := title(d)
-	if err != nil {
-		return &s, err
-	}
-	s.Title = tt
+// Enricher augments a parsed Site with data from other sources.
+type Enricher interface {
+	Enrich(context.Context, *Site) error
+}
 
-	dsc, err := description(d)
-	if err != nil {
-		return &s, err
+// Conf is a asip configuration.
+type Conf struct {
+	fetcher   Fetcher
+	mirrors   []string
+	fetchOpts []fetch.Option
+	enrichers []Enricher
+	statsd    *metrics.StatsD
+	scheduler *scheduler
+	cache     *swr
+	callbacks callbacks
+	page      page
+}
+
+// Option customizes a Conf.
+type Option func(*Conf)
+
+// WithHTTPClient sets a customized HTTP client.
+func WithHTTPClient(c *http.Client) Option {
+	return func(conf *Conf) {
+		conf.fetchOpts = append(conf.fetchOpts, fetch.WithClient(c))
 	}
-	s.Description = dsc
+}
 
-	vst, err := visitors(d)
-	if err != nil {
-		return &s, err
+// WithHTTPCache keeps raw responses in dir following HTTP caching rules, so
+// they are reused across process restarts. The least recently used ones are
+// evicted once dir grows over maxBytes, zero means no limit.
+func WithHTTPCache(dir string, maxBytes int64) Option {
+	return func(conf *Conf) {
+		conf.fetchOpts = append(conf.fetchOpts, fetch.WithDiskCache(dir, maxBytes))
 	}
-	s.Visitors = vst
+}
 
-	kws, err := keywords(d)
-	if err != nil {
-		return &s, err
+// WithResolver resolves names of the HTTP client with r.
+func WithResolver(r *net.Resolver) Option {
+	return func(conf *Conf) {
+		conf.fetchOpts = append(conf.fetchOpts, fetch.WithResolver(r))
 	}
-	s.Keywords = kws
+}
 
-	ups, err := upstreams(d)
-	if err != nil {
-		return &s, err
+// WithTransport tunes the connection pool of the HTTP client.
+func WithTransport(t fetch.Transport) Option {
+	return func(conf *Conf) {
+		conf.fetchOpts = append(conf.fetchOpts, fetch.WithTransport(t))
 	}
-	s.Upstreams = ups
+}
 
-	ls, err := linksFrom(d)
-	if err != nil {
-		return &s, err
+// WithLimiter caps concurrent requests to alexa.com with l, share it with
+// enrichers through l.Client to enforce a global limit.
+func WithLimiter(l *fetch.Limiter) Option {
+	return func(conf *Conf) {
+		conf.fetchOpts = append(conf.fetchOpts, fetch.WithLimiter(l))
 	}
-	s.LinksFrom = ls
+}
 
-	rs, err := related(d)
-	if err != nil {
-		return &s, err
+// WithRetryBudget caps retries of all requests to ratio of their number
+// plus min, e.g. 0.1 and 10, to avoid retry storms in large batches.
+func WithRetryBudget(ratio float64, min int) Option {
+	return func(conf *Conf) {
+		conf.fetchOpts = append(conf.fetchOpts, fetch.WithRetryBudget(ratio, min))
 	}
-	s.Related = rs
+}
 
-	cts, err := categories(d)
-	if err != nil {
-		return &s, err
+// WithPoliteness pauses a random time between min and max between
+// consecutive requests to the same host.
+func WithPoliteness(min, max time.Duration) Option {
+	return func(conf *Conf) {
+		conf.fetchOpts = append(conf.fetchOpts, fetch.WithPoliteness(min, max))
 	}
-	s.Categories = cts
+}
 
-	ss, err := subdomains(d)
-	if err != nil {
-		return &s, err
+// WithCapturedHeaders records the named response headers in Site.Meta, handy
+// to tell when alexa.com starts serving different content. Set-Cookie is
+// recorded as present rather than by its value.
+func WithCapturedHeaders(names ...string) Option {
+	return func(conf *Conf) {
+		conf.page.capture = append(conf.page.capture, names...)
 	}
-	s.Subdomains = ss
+}
 
-	return &s, nil
+// WithVisitorRows keeps up to n visitors rows when the subscription view
+// exposes more than the default five, n < 0 keeps all of them.
+func WithVisitorRows(n int) Option {
+	return func(conf *Conf) {
+		conf.page.parse = append(conf.page.parse, parse.WithVisitorRows(n))
+	}
 }
 
-type getFunc func(string) (*http.Response, error)
+// WithNormalizedKeywords composes keywords to Unicode NFC, lowercases and
+// collapses whitespace, merging the ones equal after that.
+func WithNormalizedKeywords() Option {
+	return func(conf *Conf) {
+		conf.page.parse = append(conf.page.parse, parse.WithNormalizedKeywords())
+	}
+}
 
-func siteInfo(domain string, f getFunc) (*Site, error) {
-	resp, err := f(domain)
-	if err != nil {
-		return nil, err
+// WithLayoutThreshold reports ErrLayoutChanged once more than n sections
+// are missing from a page, n < 0 disables the check.
+func WithLayoutThreshold(n int) Option {
+	return func(conf *Conf) {
+		conf.page.parse = append(conf.page.parse, parse.WithLayoutThreshold(n))
 	}
-	defer resp.Body.Close()
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status code: %d, no data for %s?", resp.StatusCode, domain)
+// WithCountryCodes overrides ISO codes of countries by their names as shown
+// on the page.
+func WithCountryCodes(overrides map[string]string) Option {
+	return func(conf *Conf) {
+		conf.page.parse = append(conf.page.parse, parse.WithCountryCodes(overrides))
 	}
+}
 
-	return parse(resp.Body)
+// WithLayout parses pages as of the layout version v instead of detecting
+// it.
+func WithLayout(v string) Option {
+	return func(conf *Conf) {
+		conf.page.parse = append(conf.page.parse, parse.WithLayout(v))
+	}
 }
 
-// SiteInfo parses webpage of Alexa Website Info.
-func SiteInfo(domain string) (*Site, error) {
-	return siteInfo(fmt.Sprintf(asiLocation, domain), http.Get)
+// ParseFields resolves a comma separated list of fields of Site named like
+// GlobalRank or global_rank.
+func ParseFields(list string) ([]string, error) {
+	return parse.ParseFields(list)
 }
 
-// SiteInfo parses webpage of Alexa Website Info with customised parameters.
-func (c *Conf) SiteInfo(domain string) (*Site, error) {
-	return siteInfo(fmt.Sprintf(asiLocation, domain), c.client.Get)
+// SelectFields returns the fields of s keyed as they are encoded to JSON.
+func SelectFields(s *Site, fields []string) map[string]any {
+	return parse.SelectFields(s, fields)
 }
 
-func getUint(d findable, selector string, kind string) (uint64, error) {
-	var s string
-	if selector != "" {
-		s = strings.TrimSpace(d.Find(selector).Text())
-	} else {
-		s = strings.TrimSpace(d.Text())
+// WithFields parses only sections filling the fields, as resolved by
+// ParseFields, to save time when just a few of them are wanted.
+func WithFields(fields ...string) Option {
+	return func(conf *Conf) {
+		conf.page.parse = append(conf.page.parse, parse.WithFields(fields...))
 	}
+}
 
-	if s == "" {
-		return 0, fmt.Errorf("no %s found", kind)
+// WithFetcher sets a customized fetcher, e.g. a *fetch.Client, options of
+// the HTTP client are ignored then.
+func WithFetcher(f Fetcher) Option {
+	return func(conf *Conf) {
+		conf.fetcher = f
 	}
+}
 
-	s = strings.ReplaceAll(s, ",", "") // remove commas from string like 1,111,111
-
-	value, err := strconv.ParseUint(s, 10, 64)
-	if err != nil {
-		return 0, err
+// WithMirrors sets base URLs tried in order in place of https://www.alexa.com
+// when it errors, e.g. a regional mirror, an internal caching proxy or
+// https://web.archive.org/web/2022/https://www.alexa.com.
+func WithMirrors(bases ...string) Option {
+	return func(conf *Conf) {
+		conf.mirrors = append(conf.mirrors, bases...)
 	}
-
-	return value, nil
 }
 
-func getString(d findable, selector string, kind string) (string, error) {
-	var s string
-	if selector != "" {
-		s = strings.TrimSpace(d.Find(selector).Text())
-	} else {
-		s = strings.TrimSpace(d.Text())
-	}
-	if s == "" {
-		return "", fmt.Errorf("no %s found", kind)
+// WithEnrichers runs enrichers in order on every parsed Site.
+func WithEnrichers(e ...Enricher) Option {
+	return func(conf *Conf) {
+		conf.enrichers = append(conf.enrichers, e...)
 	}
-	return s, nil
 }
 
-func globalRank(d *goquery.Document) (uint64, error) {
-	return getUint(d, seGlobalRank, "global rank")
+// WithStatsD sends global_rank and linking_total gauges tagged with the
+// domain to s after every successful fetch.
+func WithStatsD(s *metrics.StatsD) Option {
+	return func(conf *Conf) {
+		conf.statsd = s
+	}
 }
 
-func localRank(d *goquery.Document) (uint64, error) {
-	return getUint(d, seLocalRank, "local rank")
+// WithSecurityTrails enriches sites with SecurityTrails data using the API
+// token.
+func WithSecurityTrails(token string) Option {
+	return WithEnrichers(enrich.NewSecurityTrails(token, nil))
 }
 
-func country(d *goquery.Document) (string, error) {
-	return getString(d, seCountry, "country")
+// New bootstraps configuration.
+func New(opts ...Option) *Conf {
+	c := &Conf{}
+	for _, o := range opts {
+		o(c)
+	}
+	if c.fetcher == nil {
+		c.fetcher = fetch.New(c.fetchOpts...)
+	}
+	c.fetcher = failover(c.fetcher, c.mirrors)
+	return c
 }
 
-func linkingTotal(d *goquery.Document) (uint64, error) {
-	return getUint(d, seLinkingTotal, "linking total")
-}
+var defaultConf = New()
 
-func title(d *goquery.Document) (string, error) {
-	return getString(d, seTitle, "site title")
+// Fetcher retrieves pages, e.g. over HTTP, from recorded responses or
+// through a scraping API.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) (*http.Response, error)
 }
 
-func description(d *goquery.Document) (string, error) {
-	return getString(d, seDescription, "site description")
+// FetcherFunc adapts a function to a Fetcher.
+type FetcherFunc func(ctx context.Context, url string) (*http.Response, error)
+
+// Fetch calls f.
+func (f FetcherFunc) Fetch(ctx context.Context, url string) (*http.Response, error) {
+	return f(ctx, url)
 }
 
-func noEnoughData(d *goquery.Document) bool {
-	return d.Find(seNoData).Length() > 0
+// page configures how a fetched page becomes a Site.
+type page struct {
+	capture  []string
+	parse    []parse.Option
+	report   ErrorReporter
+	timeouts Timeouts
+	hooks    []ParseHook
 }
 
-func visitors(d *goquery.Document) ([]Visitor, error) {
-	tbody := d.Find(seVisitors)
-	if tbody.Length() == 0 {
-		return nil, errors.New("no visitors found")
+func siteInfo(ctx context.Context, domain string, f Fetcher, p page) (s *Site, err error) {
+	start := time.Now()
+	fetchesTotal.Inc()
+	defer func() {
+		if err != nil {
+			fetchErrors.Inc()
+		}
+	}()
+
+	st := newStages(ctx)
+	defer st.stop()
+	ctx = st.ctx
+
+	st.start("connect", p.timeouts.Connect)
+	resp, err := f.Fetch(ctx, fmt.Sprintf(asiLocation, domain))
+	if err != nil {
+		return nil, st.err(err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, domain); err != nil {
+		return nil, err
 	}
 
-	var (
-		v                []Visitor
-		country, percent string
-		countryRank      uint64
-	)
-	tbody.Find("tr").Each(func(i int, tr *goquery.Selection) {
-		country = strings.TrimSpace(tr.Find("td a").Text())
-		percent = strings.TrimSpace(tr.Find("td span").First().Text())
-		countryRank, _ = getUint(
-			tr.Find("td span").Last(),
-			"",
-			fmt.Sprintf("%d country rank", i),
-		)
+	body := &countingReader{r: resp.Body}
+	var r io.Reader = body
+	if p.timeouts.Read > 0 || p.timeouts.Parse > 0 {
+		// read up front to tell slow reading from slow parsing
+		st.start("read", p.timeouts.Read)
+		b, err := io.ReadAll(body)
+		if err != nil {
+			if te := st.err(err); te != err {
+				return nil, te
+			}
+			if t := truncated(err, resp, body); t != nil {
+				return nil, t
+			}
+			return nil, err
+		}
+		r = bytes.NewReader(b)
+	}
 
-		v = append(v, Visitor{
-			Country:   country,
-			Percent:   percent,
-			LocalRank: uint(countryRank),
-		})
-	})
+	st.start("parse", p.timeouts.Parse)
+	if p.timeouts.Parse > 0 {
+		s, err = parseWithin(ctx, r, p.parse)
+	} else {
+		s, err = parse.ParseContext(ctx, r, p.parse...)
+	}
+	err = st.err(err)
+	if t := truncated(err, resp, body); t != nil {
+		return nil, t
+	}
+	if s != nil {
+		s.Domain = domain
+		layout := s.Meta.Layout
+		s.Meta = fetch.Meta(resp, provider, p.capture...)
+		s.Meta.Layout = layout
+		if s.Meta.SourceURL == "" {
+			s.Meta.SourceURL = fmt.Sprintf(asiLocation, domain)
+		}
+		if c := canonicalDomain(s.Meta.FinalURL); c != "" && c != Normalize(domain) {
+			s.Meta.CanonicalDomain = c
+		}
+		s.Meta.Duration = time.Since(start)
+		s.Meta.Bytes = body.n
+
+		fetchRetries.Add(float64(s.Meta.Retries))
+		fetchBytes.Add(float64(body.n))
+		fetchDurations.Observe(s.Meta.Duration.Seconds())
+	}
 
-	return v, nil
+	err = p.hooked(ctx, domain, s, err)
+	if err != nil {
+		u := fmt.Sprintf(asiLocation, domain)
+		if resp.Request != nil {
+			u = resp.Request.URL.String()
+		}
+		report(ctx, p.report, err, domain, u, resp.StatusCode)
+	}
+	return s, err
 }
 
-func keywords(d *goquery.Document) ([]Keyword, error) {
-	tbody := d.Find(seKeywords)
-	if tbody.Length() == 0 {
-		return nil, errors.New("no keywords found")
+// canonicalDomain extracts the domain from a siteinfo URL.
+func canonicalDomain(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	d, ok := strings.CutPrefix(u.Path, "/siteinfo/")
+	if !ok {
+		return ""
 	}
+	return Normalize(d)
+}
 
-	var (
-		ks              []Keyword
-		key, percentage string
-	)
-	tbody.Find("tr").Each(func(_ int, tr *goquery.Selection) {
-		key = strings.TrimSpace(tr.Find("td:first-child span:last-child").Text())
-		percentage = strings.TrimSpace(tr.Find("td:last-child span").Text())
-		ks = append(ks, Keyword{
-			Word:    key,
-			Percent: percentage,
-		})
-	})
+// SiteInfo parses webpage of Alexa Website Info.
+func SiteInfo(ctx context.Context, domain string) (*Site, error) {
+	return defaultConf.SiteInfo(ctx, domain)
+}
 
-	return ks, nil
+// SiteInfo parses webpage of Alexa Website Info with customised parameters.
+func (c *Conf) SiteInfo(ctx context.Context, domain string) (*Site, error) {
+	if c.cache != nil {
+		return c.cache.siteInfo(ctx, domain, c.lookup)
+	}
+	return c.lookup(ctx, domain)
 }
 
-func upstreams(d *goquery.Document) ([]Upstream, error) {
-	tbody := d.Find(seUpstreams)
-	if tbody.Length() == 0 {
-		return nil, errors.New("no upstream servers found")
+// lookup fetches, parses and enriches a site bypassing the cache.
+func (c *Conf) lookup(ctx context.Context, domain string) (*Site, error) {
+	release, err := c.schedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s, err := siteInfo(ctx, domain, c.fetcher, c.page)
+	release()
+	if err != nil {
+		return s, err
+	}
+	if c.statsd != nil {
+		gauge(c.statsd, s)
 	}
 
-	var (
-		us            []Upstream
-		site, percent string
-	)
-	tbody.Find("tr").Each(func(_ int, tr *goquery.Selection) {
-		site = strings.TrimSpace(tr.Find("td a").Text())
-		percent = strings.TrimSpace(tr.Find("td:last-child span").Text())
-		us = append(us, Upstream{
-			Site:    site,
-			Percent: percent,
-		})
-	})
+	return s, c.enrich(ctx, s)
+}
 
-	return us, nil
+func (c *Conf) enrich(ctx context.Context, s *Site) error {
+	for _, e := range c.enrichers {
+		if err := e.Enrich(ctx, s); err != nil {
+			return fmt.Errorf("enrich: %w", err)
+		}
+	}
+	return nil
 }
 
-func linksFrom(d *goquery.Document) ([]Link, error) {
-	tbody := d.Find(seLinks)
-	if tbody.Length() == 0 {
-		return nil, errors.New("no linking sites found")
+func rank(ctx context.Context, domain string, f Fetcher) (global, local uint, country string, err error) {
+	resp, err := f.Fetch(ctx, fmt.Sprintf(asiLocation, domain))
+	if err != nil {
+		return 0, 0, "", err
 	}
+	defer resp.Body.Close()
 
-	var (
-		ls         []Link
-		site, page string
-	)
-	tbody.Find("tr").Each(func(_ int, tr *goquery.Selection) {
-		site = strings.TrimSpace(tr.Find("span.word-wrap a").Text())
-		page, _ = tr.Find("a.word-wrap").Attr("href")
-		ls = append(ls, Link{
-			Site: site,
-			Page: page,
-		})
-	})
+	if err := checkResponse(resp, domain); err != nil {
+		return 0, 0, "", err
+	}
 
-	return ls, nil
+	return parse.ParseRank(resp.Body)
 }
 
-func related(d *goquery.Document) ([]string, error) {
-	tbody := d.Find(seRelated)
-	if tbody.Length() == 0 {
-		return nil, errors.New("no related sites found")
-	}
-
-	var rs []string
-	tbody.Find("tr").Each(func(_ int, tr *goquery.Selection) {
-		rs = append(rs, tr.Find("a").Text())
-	})
-
-	return rs, nil
-}
-
-func categories(d *goquery.Document) ([]string, error) {
-	tbody := d.Find(seCategories)
-	if tbody.Length() == 0 {
-		return nil, errors.New("no categories found")
-	}
-
-	var cts []string
-	tbody.Find("a").Each(func(_ int, a *goquery.Selection) {
-		cts = append(cts, a.Text())
-	})
+// Rank returns global rank, local rank and its country, the rest of the page
+// is neither parsed nor downloaded.
+func Rank(ctx context.Context, domain string) (global, local uint, country string, err error) {
+	return defaultConf.Rank(ctx, domain)
+}
 
-	return cts, nil
+// Rank is like the package level Rank but uses customised parameters.
+func (c *Conf) Rank(ctx context.Context, domain string) (global, local uint, country string, err error) {
+	release, err := c.schedule(ctx)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	defer release()
+	return rank(ctx, domain, c.fetcher)
 }
 
-func subdomains(d *goquery.Document) ([]Subdomain, error) {
-	tbody := d.Find(seSubdomains)
-	if tbody.Length() == 0 {
-		return nil, errors.New("no subdomains found")
+func isRanked(ctx context.Context, domain string, f Fetcher) (bool, error) {
+	resp, err := f.Fetch(ctx, fmt.Sprintf(asiLocation, domain))
+	if err != nil {
+		return false, err
 	}
+	defer resp.Body.Close()
 
-	var (
-		ss              []Subdomain
-		domain, percent string
-	)
-	tbody.Find("tr").Each(func(_ int, tr *goquery.Selection) {
-		domain = tr.Find("td:first-child span").Text()
-		percent = tr.Find("td:last-child span").Text()
-		ss = append(ss, Subdomain{
-			Domain:  domain,
-			Percent: percent,
-		})
-	})
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err := checkResponse(resp, domain); err != nil {
+		return false, err
+	}
 
-	return ss, nil
+	return parse.IsRanked(resp.Body)
+}
+
+// IsRanked tells whether alexa.com has ranking data for the domain, reading
+// as little of the page as possible.
+func IsRanked(ctx context.Context, domain string) (bool, error) {
+	return defaultConf.IsRanked(ctx, domain)
+}
+
+// IsRanked is like the package level IsRanked but uses customised parameters.
+func (c *Conf) IsRanked(ctx context.Context, domain string) (bool, error) {
+	release, err := c.schedule(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	return isRanked(ctx, domain, c.fetcher)
 }
@@ -1,185 +1,243 @@
 package asip
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
+	"net/http"
 	"os"
-	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 const (
-	successTestDocLoc = "testdata/body.html"
-	nodataTestDocLoc  = "testdata/nodata.html"
+	successTestDocLoc = "parse/testdata/body.html"
+	nodataTestDocLoc  = "parse/testdata/nodata.html"
 )
 
-var successTestSite = &Site{
-	Title:        "Сбербанк России",
-	Description:  "Сведения об истории создания, руководстве, филиалах и подразделениях. Перечень услуг. Тарифы.",
-	MainCountry:  "Russia",
-	GlobalRank:   506,
-	LocalRank:    17,
-	LinkingTotal: 8491,
-	Visitors: []Visitor{
-		Visitor{
-			Country:   "Russia",
-			Percent:   "83.8%",
-			LocalRank: 17,
-		},
-		Visitor{
-			Country:   "Netherlands",
-			Percent:   "2.0%",
-			LocalRank: 182,
-		},
-		Visitor{
-			Country:   "Germany",
-			Percent:   "1.7%",
-			LocalRank: 1366,
-		},
-		Visitor{
-			Country:   "United Kingdom",
-			Percent:   "1.4%",
-			LocalRank: 1234,
-		},
-		Visitor{
-			Country:   "United States",
-			Percent:   "1.3%",
-			LocalRank: 7997,
-		},
-	},
-	Keywords: []Keyword{
-		Keyword{
-			Word:    "сбербанк онлайн",
-			Percent: "49.69%",
-		},
-		Keyword{
-			Word:    "сбербанк",
-			Percent: "7.87%",
-		},
-		Keyword{
-			Word:    "сбербанк бизнес онлайн",
-			Percent: "7.74%",
-		},
-		Keyword{
-			Word:    "sberbank online",
-			Percent: "3.63%",
-		},
-		Keyword{
-			Word:    "sberbank",
-			Percent: "2.65%",
-		},
-	},
-	Upstreams: []Upstream{
-		Upstream{
-			Site:    "yandex.ru",
-			Percent: "21.4%",
-		},
-		Upstream{
-			Site:    "google.com",
-			Percent: "10.1%",
-		},
-		Upstream{
-			Site:    "vk.com",
-			Percent: "5.6%",
-		},
-		Upstream{
-			Site:    "mail.ru",
-			Percent: "4.3%",
-		},
-		Upstream{
-			Site:    "youtube.com",
-			Percent: "2.3%",
-		},
-	},
-	LinksFrom: []Link{
-		Link{
-			Site: "yandex.ru",
-			Page: "http://money.yandex.ru/doc.xml?id=242350",
-		},
-		Link{
-			Site: "mail.ru",
-			Page: "http://card.krugdoveriya.mail.ru/articles.html?id=19376",
-		},
-		Link{
-			Site: "fc2.com",
-			Page: "http://10rank.blog.fc2.com/blog-entry-264.html",
-		},
-		Link{
-			Site: "mit.edu",
-			Page: "http://misti.mit.edu/hosts-partners/featured-hosts",
-		},
-		Link{
-			Site: "wixsite.com",
-			Page: "http://belov-72.wixsite.com/ocenka72",
-		},
-	},
-	Related: []string{
-		"sbrf.ru",
-		"sravni.ru",
-		"gosuslugi.ru",
-		"banki.ru",
-		"avito.ru",
-	},
-	Categories: []string{
-		"World",
-		"Russian",
-		"Страны и регионы",
-		"Европа",
-		"Россия",
-		"Бизнес и экономика",
-		"Финансовые услуги",
-		"Банки",
-	},
-	Subdomains: []Subdomain{
-		Subdomain{
-			Domain:  "online.sberbank.ru",
-			Percent: "69.69%",
-		},
-		Subdomain{
-			Domain:  "sberbank.ru",
-			Percent: "28.30%",
-		},
-		Subdomain{
-			Domain:  "securepayments.sberbank.ru",
-			Percent: "6.72%",
-		},
-		Subdomain{
-			Domain:  "sbi.sberbank.ru",
-			Percent: "4.53%",
-		},
-		Subdomain{
-			Domain:  "info.sberbank.ru",
-			Percent: "0.58%",
-		},
-	},
-}
-
 func testDoc(filename string) (body io.ReadCloser, err error) {
 	return os.Open(filename)
 }
 
+var fileGet = FetcherFunc(func(ctx context.Context, url string) (*http.Response, error) {
+	loc := nodataTestDocLoc
+	if url == fmt.Sprintf(asiLocation, "sberbank.ru") {
+		loc = successTestDocLoc
+	}
+
+	body, err := testDoc(loc)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: body}, nil
+})
+
+func statusGet(code int) FetcherFunc {
+	return func(ctx context.Context, url string) (*http.Response, error) {
+		return &http.Response{StatusCode: code, Body: http.NoBody}, nil
+	}
+}
+
 func TestSiteInfo(t *testing.T) {
-	body, err := testDoc(successTestDocLoc)
+	si, err := siteInfo(context.Background(), "sberbank.ru", fileGet, page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if si.GlobalRank != 506 {
+		t.Fatalf("want global rank 506, got %d", si.GlobalRank)
+	}
+	if si.Domain != "sberbank.ru" {
+		t.Fatalf("want domain sberbank.ru, got %s", si.Domain)
+	}
+	if si.Meta.HTTPStatus != http.StatusOK || si.Meta.Provider != "alexa.com" || si.Meta.FetchedAt.IsZero() {
+		t.Fatalf("unexpected meta %v", si.Meta)
+	}
+	if si.Meta.SourceURL != fmt.Sprintf(asiLocation, "sberbank.ru") {
+		t.Fatalf("want source url of sberbank.ru, got %s", si.Meta.SourceURL)
+	}
+
+	fi, err := os.Stat(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if si.Meta.Bytes != fi.Size() {
+		t.Fatalf("want %d bytes, got %d", fi.Size(), si.Meta.Bytes)
+	}
+}
+
+func TestSiteInfoStatus(t *testing.T) {
+	_, err := siteInfo(context.Background(), "sberbank.ru", statusGet(http.StatusForbidden), page{})
+
+	var se *StatusError
+	if !errors.As(err, &se) {
+		t.Fatalf("want *StatusError, got %v", err)
+	}
+	if se.Code != http.StatusForbidden {
+		t.Fatalf("want status %d, got %d", http.StatusForbidden, se.Code)
+	}
+}
+
+func TestRank(t *testing.T) {
+	global, local, country, err := rank(context.Background(), "sberbank.ru", fileGet)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if global != 506 || local != 17 || country != "Russia" {
+		t.Fatalf("want 506, 17, Russia, got %d, %d, %s", global, local, country)
+	}
+}
 
-	si, err := parse(body)
+func TestIsRanked(t *testing.T) {
+	ranked, err := isRanked(context.Background(), "sberbank.ru", fileGet)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if !ranked {
+		t.Fatal("want sberbank.ru ranked")
+	}
 
-	if !reflect.DeepEqual(si, successTestSite) {
-		t.Fatalf("want %v, got %v", successTestSite, si)
+	ranked, err = isRanked(context.Background(), "example.org", statusGet(http.StatusNotFound))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ranked {
+		t.Fatal("want example.org not ranked")
 	}
 }
 
-func TestNoData(t *testing.T) {
-	body, err := testDoc(nodataTestDocLoc)
+func TestSiteInfoContentType(t *testing.T) {
+	get := FetcherFunc(func(ctx context.Context, url string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"error": "quota exceeded"}`)),
+		}, nil
+	})
+
+	_, err := siteInfo(context.Background(), "sberbank.ru", get, page{})
+	if !errors.Is(err, ErrUnexpectedContentType) {
+		t.Fatalf("want %v, got %v", ErrUnexpectedContentType, err)
+	}
+
+	var ce *ContentTypeError
+	if !errors.As(err, &ce) || ce.Snippet != `{"error": "quota exceeded"}` {
+		t.Fatalf("want the body snippet, got %v", err)
+	}
+}
+
+func TestSiteInfoCanonicalDomain(t *testing.T) {
+	get := FetcherFunc(func(ctx context.Context, rawURL string) (*http.Response, error) {
+		resp, err := fileGet(ctx, fmt.Sprintf(asiLocation, "sberbank.ru"))
+		if err != nil {
+			return nil, err
+		}
+		orig, _ := http.NewRequest(http.MethodGet, rawURL, nil)
+		final, _ := http.NewRequest(http.MethodGet, fmt.Sprintf(asiLocation, "sberbank.ru"), nil)
+		final.Response = &http.Response{StatusCode: http.StatusMovedPermanently, Request: orig}
+		resp.Request = final
+		return resp, nil
+	})
+
+	s, err := siteInfo(context.Background(), "www.sberbank.com", get, page{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = parse(body)
-	if err != ErrNoEnoughData {
-		t.Fatal("want error, but got no error")
+	if s.Meta.CanonicalDomain != "sberbank.ru" {
+		t.Fatalf("want canonical domain sberbank.ru, got %q", s.Meta.CanonicalDomain)
+	}
+	if s.Meta.SourceURL != fmt.Sprintf(asiLocation, "www.sberbank.com") {
+		t.Fatalf("unexpected source url %s", s.Meta.SourceURL)
+	}
+	if len(s.Meta.Redirects) != 1 {
+		t.Fatalf("want a redirect, got %v", s.Meta.Redirects)
+	}
+}
+
+type reports []ErrorContext
+
+func (r *reports) Report(ctx context.Context, err error, ec ErrorContext) {
+	*r = append(*r, ec)
+}
+
+func TestErrorReporter(t *testing.T) {
+	empty := FetcherFunc(func(ctx context.Context, url string) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("<html></html>"))}, nil
+	})
+
+	var r reports
+	if _, err := siteInfo(context.Background(), "example.org", empty, page{report: &r}); !errors.Is(err, ErrLayoutChanged) {
+		t.Fatalf("want %v, got %v", ErrLayoutChanged, err)
+	}
+	if _, err := siteInfo(context.Background(), "example.org", fileGet, page{report: &r}); !errors.Is(err, ErrNoEnoughData) {
+		t.Fatalf("want %v, got %v", ErrNoEnoughData, err)
+	}
+
+	if len(r) != 1 || r[0].Domain != "example.org" || r[0].URL != fmt.Sprintf(asiLocation, "example.org") || len(r[0].Missing) == 0 {
+		t.Fatalf("want a layout change reported, got %+v", r)
+	}
+}
+
+func TestSiteInfoTruncated(t *testing.T) {
+	doc, err := os.ReadFile(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cut := doc[:len(doc)/2]
+
+	for name, resp := range map[string]func() *http.Response{
+		"no closing tag": func() *http.Response {
+			return &http.Response{StatusCode: http.StatusOK, ContentLength: -1, Body: io.NopCloser(bytes.NewReader(cut))}
+		},
+		"short of content length": func() *http.Response {
+			return &http.Response{StatusCode: http.StatusOK, ContentLength: int64(len(doc)), Body: io.NopCloser(bytes.NewReader(cut))}
+		},
+	} {
+		get := FetcherFunc(func(ctx context.Context, url string) (*http.Response, error) {
+			return resp(), nil
+		})
+
+		_, err := siteInfo(context.Background(), "sberbank.ru", get, page{})
+		var te *TruncatedError
+		if !errors.Is(err, ErrTruncatedResponse) || !errors.As(err, &te) || te.Read != int64(len(cut)) {
+			t.Fatalf("%s: want %v after %d bytes, got %v", name, ErrTruncatedResponse, len(cut), err)
+		}
+	}
+}
+
+func TestSiteInfoTimeouts(t *testing.T) {
+	slow := FetcherFunc(func(ctx context.Context, url string) (*http.Response, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+		return fileGet(ctx, url)
+	})
+	_, err := siteInfo(context.Background(), "sberbank.ru", slow, page{timeouts: Timeouts{Connect: 10 * time.Millisecond}})
+	var te *TimeoutError
+	if !errors.As(err, &te) || te.Stage != "connect" || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want connect timeout, got %v", err)
+	}
+
+	dripping := FetcherFunc(func(ctx context.Context, url string) (*http.Response, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			pw.Write([]byte("<html>"))
+			<-ctx.Done()
+			pw.CloseWithError(ctx.Err())
+		}()
+		return &http.Response{StatusCode: http.StatusOK, ContentLength: -1, Body: pr}, nil
+	})
+	_, err = siteInfo(context.Background(), "sberbank.ru", dripping, page{timeouts: Timeouts{Read: 10 * time.Millisecond}})
+	if !errors.As(err, &te) || te.Stage != "read" {
+		t.Fatalf("want read timeout, got %v", err)
+	}
+
+	if _, err := siteInfo(context.Background(), "sberbank.ru", fileGet, page{timeouts: Timeouts{Connect: time.Second, Read: time.Second, Parse: time.Second}}); err != nil {
+		t.Fatal(err)
 	}
 }
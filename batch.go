@@ -0,0 +1,97 @@
+package asip
+
+import (
+	"context"
+	"strings"
+)
+
+// Normalize folds case and strips surrounding spaces, a trailing dot and a
+// leading www label, so equivalent inputs share a lookup.
+func Normalize(domain string) string {
+	d := strings.ToLower(strings.TrimSpace(domain))
+	d = strings.TrimSuffix(d, ".")
+	if strings.HasPrefix(d, "www.") && strings.Count(d, ".") > 1 {
+		d = d[len("www."):]
+	}
+	return d
+}
+
+// Dedupe normalizes domains and returns each canonical domain once in order
+// of appearance along with positions of the inputs it stands for.
+func Dedupe(domains []string) (canonical []string, positions map[string][]int) {
+	positions = make(map[string][]int)
+	for i, d := range domains {
+		n := Normalize(d)
+		if _, ok := positions[n]; !ok {
+			canonical = append(canonical, n)
+		}
+		positions[n] = append(positions[n], i)
+	}
+	return canonical, positions
+}
+
+// SiteInfoBatch looks up domains fetching each canonical domain once, the
+// results are in order of domains and duplicates share a result.
+func SiteInfoBatch(ctx context.Context, domains []string) []Result {
+	return defaultConf.SiteInfoBatch(ctx, domains)
+}
+
+// SiteInfoBatch is like the package level SiteInfoBatch but uses customised
+// parameters.
+func (c *Conf) SiteInfoBatch(ctx context.Context, domains []string) []Result {
+	return batch(ctx, domains, c.SiteInfo, c.callbacks)
+}
+
+// callbacks are told about every canonical domain of a batch once it is
+// looked up.
+type callbacks struct {
+	onResult func(domain string, s *Site)
+	onError  func(domain string, err error)
+}
+
+// WithOnResult calls f with every site of a batch as soon as it is looked
+// up, e.g. to persist results or report progress incrementally.
+func WithOnResult(f func(domain string, s *Site)) Option {
+	return func(conf *Conf) {
+		conf.callbacks.onResult = f
+	}
+}
+
+// WithOnError calls f with every failed lookup of a batch as soon as it
+// fails.
+func WithOnError(f func(domain string, err error)) Option {
+	return func(conf *Conf) {
+		conf.callbacks.onError = f
+	}
+}
+
+func (cb callbacks) call(domain string, res Result) {
+	switch {
+	case res.Err != nil && cb.onError != nil:
+		cb.onError(domain, res.Err)
+	case res.Err == nil && cb.onResult != nil:
+		cb.onResult(domain, res.Site)
+	}
+}
+
+func batch(ctx context.Context, domains []string, f lookupFunc, cb callbacks) []Result {
+	canonical, positions := Dedupe(domains)
+
+	rs := make([]Result, len(domains))
+	for d, res := range all(ctx, canonical, f) {
+		cb.call(d, res)
+		for _, i := range positions[d] {
+			rs[i] = res
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		for i := range rs {
+			if rs[i].Site == nil && rs[i].Err == nil {
+				rs[i].Err = err
+			}
+		}
+	}
+
+	return rs
+}
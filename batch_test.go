@@ -0,0 +1,60 @@
+package asip
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	for in, want := range map[string]string{
+		"Example.ORG":      "example.org",
+		" www.example.org": "example.org",
+		"example.org.":     "example.org",
+		"www.com":          "www.com",
+		"mail.example.org": "mail.example.org",
+	} {
+		if got := Normalize(in); got != want {
+			t.Fatalf("%q: want %s, got %s", in, want, got)
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	var l countingLookup
+	rs := batch(context.Background(), []string{"Example.org", "www.example.org", "example.com", "example.org."}, l.lookup, callbacks{})
+
+	if l.calls != 2 {
+		t.Fatalf("want 2 lookups, got %d", l.calls)
+	}
+
+	var got []uint
+	for _, r := range rs {
+		got = append(got, r.Site.GlobalRank)
+	}
+	if want := []uint{1, 1, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want ranks %v, got %v", want, got)
+	}
+}
+
+func TestBatchCallbacks(t *testing.T) {
+	var (
+		results []string
+		errs    []string
+	)
+	cb := callbacks{
+		onResult: func(domain string, s *Site) { results = append(results, domain) },
+		onError:  func(domain string, err error) { errs = append(errs, domain) },
+	}
+	failing := func(ctx context.Context, domain string) (*Site, error) {
+		if domain == "example.com" {
+			return nil, ErrNoEnoughData
+		}
+		return &Site{Domain: domain}, nil
+	}
+
+	batch(context.Background(), []string{"example.org", "www.example.org", "example.com"}, failing, cb)
+	if !reflect.DeepEqual(results, []string{"example.org"}) || !reflect.DeepEqual(errs, []string{"example.com"}) {
+		t.Fatalf("want a result and an error, got %v and %v", results, errs)
+	}
+}
@@ -0,0 +1,85 @@
+package asip
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/cache"
+)
+
+// WithCache serves sites from c while they are younger than ttl. For stale
+// more than ttl but less than ttl+stale the cached site is still returned
+// immediately while a fresh one is fetched in the background.
+func WithCache(c cache.Cache, ttl, stale time.Duration) Option {
+	return func(conf *Conf) {
+		conf.cache = &swr{
+			cache:      c,
+			ttl:        ttl,
+			stale:      stale,
+			refreshing: make(map[string]bool),
+		}
+	}
+}
+
+// swr is a stale-while-revalidate layer around a cache.
+type swr struct {
+	cache      cache.Cache
+	ttl, stale time.Duration
+
+	mu         sync.Mutex
+	refreshing map[string]bool
+}
+
+func (w *swr) siteInfo(ctx context.Context, domain string, f lookupFunc) (*Site, error) {
+	e, ok, err := w.cache.Get(domain)
+	if err != nil || !ok {
+		return w.fetch(ctx, domain, f)
+	}
+
+	age := time.Since(e.StoredAt)
+	switch {
+	case age < w.ttl:
+		return cached(e.Site), nil
+	case age < w.ttl+w.stale:
+		w.revalidate(domain, f)
+		return cached(e.Site), nil
+	}
+	return w.fetch(ctx, domain, f)
+}
+
+func (w *swr) fetch(ctx context.Context, domain string, f lookupFunc) (*Site, error) {
+	s, err := f(ctx, domain)
+	if err != nil {
+		return s, err
+	}
+
+	return s, w.cache.Set(domain, cache.Entry{Site: s, StoredAt: time.Now()})
+}
+
+// revalidate refreshes the domain in the background unless it is already
+// being refreshed.
+func (w *swr) revalidate(domain string, f lookupFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.refreshing[domain] {
+		return
+	}
+	w.refreshing[domain] = true
+
+	go func() {
+		w.fetch(context.Background(), domain, f)
+
+		w.mu.Lock()
+		delete(w.refreshing, domain)
+		w.mu.Unlock()
+	}()
+}
+
+// cached returns a copy of s marked as served from the cache.
+func cached(s *Site) *Site {
+	c := *s
+	c.Meta.FromCache = true
+	return &c
+}
@@ -0,0 +1,50 @@
+// Package cache stores looked up sites for reuse.
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+// Entry is a cached site along with the time it was stored.
+type Entry struct {
+	Site     *parse.Site
+	StoredAt time.Time
+}
+
+// Cache keeps entries by domain.
+type Cache interface {
+	Get(domain string) (Entry, bool, error)
+	Set(domain string, e Entry) error
+}
+
+// Memory is an in-process Cache safe for concurrent use.
+type Memory struct {
+	mu      sync.RWMutex
+	entries map[string]Entry
+}
+
+// NewMemory bootstraps an empty Memory cache.
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[string]Entry)}
+}
+
+// Get returns an entry of the domain.
+func (m *Memory) Get(domain string) (Entry, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	e, ok := m.entries[domain]
+	return e, ok, nil
+}
+
+// Set stores an entry of the domain.
+func (m *Memory) Set(domain string, e Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[domain] = e
+	return nil
+}
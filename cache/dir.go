@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/export"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+// Dir is a Cache keeping an entry per domain as a JSON file in a directory,
+// so it survives process restarts.
+type Dir struct {
+	path string
+}
+
+// NewDir bootstraps a Dir cache, creating the directory if needed.
+func NewDir(path string) (*Dir, error) {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+	return &Dir{path}, nil
+}
+
+// file is the content of an entry file.
+type file struct {
+	SchemaVersion int `json:"schema_version"`
+	Entry
+}
+
+func (d *Dir) file(domain string) string {
+	return filepath.Join(d.path, url.PathEscape(domain)+".json")
+}
+
+// Get returns an entry of the domain.
+func (d *Dir) Get(domain string) (Entry, bool, error) {
+	b, err := os.ReadFile(d.file(domain))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	if b, err = export.Migrate(b); err != nil {
+		return Entry{}, false, err
+	}
+
+	var f file
+	if err := json.Unmarshal(b, &f); err != nil {
+		return Entry{}, false, err
+	}
+	return f.Entry, true, nil
+}
+
+// Set stores an entry of the domain replacing the file atomically.
+func (d *Dir) Set(domain string, e Entry) error {
+	b, err := json.Marshal(file{parse.SchemaVersion, e})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(d.path, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), d.file(domain))
+}
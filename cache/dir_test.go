@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestDir(t *testing.T) {
+	d, err := NewDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok, err := d.Get("example.org"); ok || err != nil {
+		t.Fatalf("want miss, got %t, %v", ok, err)
+	}
+
+	want := Entry{
+		Site:     &parse.Site{Domain: "example.org", GlobalRank: 42},
+		StoredAt: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := d.Set("example.org", want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok, err := d.Get("example.org")
+	if err != nil || !ok {
+		t.Fatalf("want hit, got %t, %v", ok, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestDirMigrate(t *testing.T) {
+	d, err := NewDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := `{"Site":{"Domain":"example.org","Keywords":[{"Word":"example","Percent":{"Raw":"2.0%","Value":2}}]},"StoredAt":"2020-03-01T00:00:00Z"}`
+	if err := os.WriteFile(d.file("example.org"), []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e, ok, err := d.Get("example.org")
+	if err != nil || !ok {
+		t.Fatalf("want hit, got %t, %v", ok, err)
+	}
+	if e.Site.Keywords[0].Percent.Value != 2 {
+		t.Fatalf("unexpected keywords %v", e.Site.Keywords)
+	}
+}
@@ -0,0 +1,99 @@
+package asip
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/cache"
+)
+
+type countingLookup struct {
+	mu    sync.Mutex
+	calls int
+	done  chan struct{}
+}
+
+func (l *countingLookup) lookup(_ context.Context, domain string) (*Site, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls++
+	if l.done != nil {
+		l.done <- struct{}{}
+	}
+	return &Site{Domain: domain, GlobalRank: uint(l.calls)}, nil
+}
+
+func TestCacheFresh(t *testing.T) {
+	var l countingLookup
+	w := &swr{cache: cache.NewMemory(), ttl: time.Hour, refreshing: make(map[string]bool)}
+
+	for i := 0; i < 3; i++ {
+		s, err := w.siteInfo(context.Background(), "example.org", l.lookup)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.GlobalRank != 1 {
+			t.Fatalf("want cached site, got rank %d", s.GlobalRank)
+		}
+		if s.Meta.FromCache != (i > 0) {
+			t.Fatalf("want from cache %t, got %t", i > 0, s.Meta.FromCache)
+		}
+	}
+	if l.calls != 1 {
+		t.Fatalf("want 1 lookup, got %d", l.calls)
+	}
+}
+
+func TestCacheStaleWhileRevalidate(t *testing.T) {
+	l := countingLookup{done: make(chan struct{}, 1)}
+	c := cache.NewMemory()
+	c.Set("example.org", cache.Entry{
+		Site:     &Site{Domain: "example.org", GlobalRank: 100},
+		StoredAt: time.Now().Add(-2 * time.Hour),
+	})
+	w := &swr{cache: c, ttl: time.Hour, stale: 24 * time.Hour, refreshing: make(map[string]bool)}
+
+	s, err := w.siteInfo(context.Background(), "example.org", l.lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.GlobalRank != 100 {
+		t.Fatalf("want stale site served, got rank %d", s.GlobalRank)
+	}
+
+	select {
+	case <-l.done:
+	case <-time.After(time.Second):
+		t.Fatal("no background refresh")
+	}
+
+	// wait for the refreshed entry to land
+	for i := 0; i < 100; i++ {
+		if e, _, _ := c.Get("example.org"); e.Site.GlobalRank == 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("refreshed site is not cached")
+}
+
+func TestCacheExpired(t *testing.T) {
+	var l countingLookup
+	c := cache.NewMemory()
+	c.Set("example.org", cache.Entry{
+		Site:     &Site{Domain: "example.org", GlobalRank: 100},
+		StoredAt: time.Now().Add(-48 * time.Hour),
+	})
+	w := &swr{cache: c, ttl: time.Hour, stale: time.Hour, refreshing: make(map[string]bool)}
+
+	s, err := w.siteInfo(context.Background(), "example.org", l.lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.GlobalRank != 1 {
+		t.Fatalf("want fresh site, got rank %d", s.GlobalRank)
+	}
+}
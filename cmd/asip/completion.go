@@ -0,0 +1,243 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// watchlistEnv names the watchlist whose domains are completed.
+const watchlistEnv = "ASIP_WATCHLIST"
+
+var (
+	// describing silences flag sets while flagsOf runs commands.
+	describing bool
+	// lastFlagSet is the one the last command made.
+	lastFlagSet *flag.FlagSet
+)
+
+// newFlagSet makes a flag set of a command.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if describing {
+		fs.SetOutput(io.Discard)
+	}
+	lastFlagSet = fs
+	return fs
+}
+
+// flagsOf lists flags of c by running it with -h, every command parses its
+// flags before doing anything else.
+func flagsOf(c command) []*flag.Flag {
+	describing, lastFlagSet = true, nil
+	defer func() { describing = false }()
+
+	args := []string{"-h"}
+	if len(c.sub) > 0 {
+		args = append([]string{c.sub[0]}, args...)
+	}
+	c.run(args, nil, io.Discard)
+	if lastFlagSet == nil {
+		return nil
+	}
+
+	var fl []*flag.Flag
+	lastFlagSet.VisitAll(func(f *flag.Flag) {
+		fl = append(fl, f)
+	})
+	return fl
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// words are what is completed after the command: its subcommands and flags.
+func words(c command) []string {
+	w := append([]string{}, c.sub...)
+	for _, f := range flagsOf(c) {
+		w = append(w, "-"+f.Name)
+	}
+	return w
+}
+
+func completionCmd(args []string, stdout io.Writer) error {
+	if len(args) != 1 {
+		return errors.New("usage: asip completion bash|zsh|fish")
+	}
+
+	switch args[0] {
+	case "bash":
+		return bashCompletion(stdout)
+	case "zsh":
+		return zshCompletion(stdout)
+	case "fish":
+		return fishCompletion(stdout)
+	}
+	return fmt.Errorf("unknown shell %q", args[0])
+}
+
+func bashCompletion(w io.Writer) error {
+	var names []string
+	for _, c := range commands[1:] {
+		names = append(names, c.name)
+	}
+
+	fmt.Fprintf(w, `# bash completion of asip, load with: source <(asip completion bash)
+_asip_domains() {
+	[ -n "$%s" ] && asip watchlist domains "$%[1]s" 2>/dev/null
+}
+
+_asip() {
+	local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]} words
+	if [ "$prev" = -domain ]; then
+		COMPREPLY=($(compgen -W "$(_asip_domains)" -- "$cur"))
+		return
+	fi
+	case ${COMP_WORDS[1]} in
+`, watchlistEnv)
+	for _, c := range commands[1:] {
+		fmt.Fprintf(w, "\t%s) words=%q ;;\n", c.name, strings.Join(words(c), " "))
+	}
+	fmt.Fprintf(w, `	*)
+		words="%s $(_asip_domains)"
+		[ "$COMP_CWORD" -eq 1 ] && words="$words %s"
+		;;
+	esac
+	COMPREPLY=($(compgen -W "$words" -- "$cur"))
+	[ ${#COMPREPLY[@]} -eq 0 ] && COMPREPLY=($(compgen -f -- "$cur"))
+}
+complete -F _asip asip
+`, strings.Join(words(commands[0]), " "), strings.Join(names, " "))
+	return nil
+}
+
+func zshCompletion(w io.Writer) error {
+	var names []string
+	for _, c := range commands[1:] {
+		names = append(names, c.name)
+	}
+
+	fmt.Fprintf(w, `#compdef asip
+# zsh completion of asip, load with: source <(asip completion zsh)
+_asip_domains() {
+	[[ -n $%s ]] && asip watchlist domains "$%[1]s" 2>/dev/null
+}
+
+_asip() {
+	local -a candidates
+	if [[ $words[CURRENT-1] == -domain ]]; then
+		compadd -- ${(f)"$(_asip_domains)"}
+		return
+	fi
+	case $words[2] in
+`, watchlistEnv)
+	for _, c := range commands[1:] {
+		fmt.Fprintf(w, "\t%s) candidates=(%s) ;;\n", c.name, strings.Join(words(c), " "))
+	}
+	fmt.Fprintf(w, `	*)
+		candidates=(%s ${(f)"$(_asip_domains)"})
+		(( CURRENT == 2 )) && candidates+=(%s)
+		;;
+	esac
+	compadd -- $candidates
+	_files
+}
+compdef _asip asip
+`, strings.Join(words(commands[0]), " "), strings.Join(names, " "))
+	return nil
+}
+
+func fishCompletion(w io.Writer) error {
+	fmt.Fprintf(w, `# fish completion of asip, load with: asip completion fish | source
+function __asip_domains
+	test -n "$%s"; and asip watchlist domains $%[1]s 2>/dev/null
+end
+
+complete -c asip -n __fish_use_subcommand -a '(__asip_domains)'
+`, watchlistEnv)
+
+	for _, c := range commands {
+		cond := "__fish_use_subcommand"
+		if c.name != "" {
+			cond = "__fish_seen_subcommand_from " + c.name
+			fmt.Fprintf(w, "complete -c asip -n __fish_use_subcommand -a %s -d %s\n", c.name, fishQuote(c.help))
+		}
+		if len(c.sub) > 0 {
+			fmt.Fprintf(w, "complete -c asip -n %s -a %s\n", fishQuote(cond), fishQuote(strings.Join(c.sub, " ")))
+		}
+		for _, f := range flagsOf(c) {
+			arg := " -r"
+			switch {
+			case isBoolFlag(f):
+				arg = ""
+			case f.Name == "domain":
+				arg = " -x -a '(__asip_domains)'"
+			}
+			_, usage := flag.UnquoteUsage(f)
+			fmt.Fprintf(w, "complete -c asip -n %s -o %s%s -d %s\n", fishQuote(cond), f.Name, arg, fishQuote(usage))
+		}
+	}
+	return nil
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+func manCmd(args []string, stdout io.Writer) error {
+	if len(args) != 0 {
+		return errors.New("usage: asip man")
+	}
+
+	fmt.Fprint(stdout, `.TH ASIP 1
+.SH NAME
+asip \- look up Alexa Website Info of domains
+.SH SYNOPSIS
+`)
+	for _, c := range commands {
+		fmt.Fprintf(stdout, ".B %s\n.br\n", roff(c.usage))
+	}
+
+	fmt.Fprintln(stdout, ".SH COMMANDS")
+	for _, c := range commands {
+		fmt.Fprintf(stdout, ".TP\n.B %s\n%s\n", roff(c.usage), roff(c.help))
+	}
+
+	fmt.Fprintln(stdout, ".SH OPTIONS")
+	for _, c := range commands {
+		fl := flagsOf(c)
+		if len(fl) == 0 {
+			continue
+		}
+		fmt.Fprintf(stdout, ".SS %s\n", roff(strings.TrimSpace("asip "+c.name)))
+		for _, f := range fl {
+			name, usage := flag.UnquoteUsage(f)
+			if isBoolFlag(f) {
+				fmt.Fprintf(stdout, ".TP\n.B \\-%s\n", roff(f.Name))
+			} else {
+				fmt.Fprintf(stdout, ".TP\n.BI \\-%s \" %s\"\n", roff(f.Name), roff(strings.ToUpper(name)))
+			}
+			fmt.Fprintln(stdout, roff(usage))
+		}
+	}
+
+	fmt.Fprintf(stdout, `.SH ENVIRONMENT
+.TP
+.B %s
+watchlist whose domains are completed by shells
+`, watchlistEnv)
+	return nil
+}
+
+// roff escapes s to be a line of a man page.
+func roff(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"embed"
+	"io"
+	"io/fs"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/fetch"
+)
+
+// demoPages are a ranked and an unranked page saved from alexa.com.
+//
+//go:embed demo/*.html
+var demoPages embed.FS
+
+var demoDomains = []string{"sberbank.ru", "ilyaglotov.com"}
+
+// demoCmd looks up the embedded pages without network access, taking the
+// same flags as a lookup.
+func demoCmd(args []string, stdout io.Writer) error {
+	pages, err := fs.Sub(demoPages, "demo")
+	if err != nil {
+		return err
+	}
+	return lookupCmd(append(args, demoDomains...), nil, stdout, asip.WithFetcher(fetch.NewFileFetcher(pages)))
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+// step is a part of an --extract path: a field, an index into a list or a
+// lookup of the first list element whose field equals a value.
+type step struct {
+	field string
+	index int
+	key   string
+	value string
+}
+
+// extractor evaluates a path like keywords[0].word or
+// visitors[country=Russia].percent over a site as it is encoded to JSON.
+type extractor []step
+
+// parseExtract compiles a dotted path, names match fields ignoring case,
+// underscores and dashes.
+func parseExtract(expr string) (extractor, error) {
+	var e extractor
+	for _, part := range splitPath(expr) {
+		if part == "" {
+			return nil, fmt.Errorf("extract %q: empty field", expr)
+		}
+		name, rest, _ := strings.Cut(part, "[")
+		if name != "" {
+			e = append(e, step{field: normalizeField(name), index: -1})
+		}
+		for rest != "" {
+			inner, after, ok := strings.Cut(rest, "]")
+			if !ok || (after != "" && !strings.HasPrefix(after, "[")) {
+				return nil, fmt.Errorf("extract %q: unbalanced brackets", expr)
+			}
+			rest = strings.TrimPrefix(after, "[")
+
+			if k, v, ok := strings.Cut(inner, "="); ok {
+				e = append(e, step{index: -1, key: normalizeField(k), value: strings.TrimSpace(v)})
+				continue
+			}
+			i, err := strconv.Atoi(strings.TrimSpace(inner))
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("extract %q: bad index %q", expr, inner)
+			}
+			e = append(e, step{index: i})
+		}
+	}
+	return e, nil
+}
+
+// splitPath splits expr on dots outside brackets.
+func splitPath(expr string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range expr {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case r == '.' && depth == 0:
+			parts = append(parts, expr[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+// eval returns the value at the path, false if there is none.
+func (e extractor) eval(s *asip.Site) (interface{}, bool) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, false
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, false
+	}
+
+	for _, st := range e {
+		var ok bool
+		switch {
+		case st.field != "":
+			v, ok = field(v, st.field)
+		case st.key != "":
+			v, ok = where(v, st.key, st.value)
+		default:
+			l, _ := v.([]interface{})
+			if ok = st.index < len(l); ok {
+				v = l[st.index]
+			}
+		}
+		if !ok || v == nil {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// format renders strings as is and anything else as JSON.
+func (e extractor) format(s *asip.Site) string {
+	v, ok := e.eval(s)
+	if !ok {
+		return ""
+	}
+	if str, ok := v.(string); ok {
+		return str
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func field(v interface{}, name string) (interface{}, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	for k, fv := range m {
+		if normalizeField(k) == name {
+			return fv, true
+		}
+	}
+	return nil, false
+}
+
+func where(v interface{}, key, value string) (interface{}, bool) {
+	l, _ := v.([]interface{})
+	for _, el := range l {
+		fv, ok := field(el, key)
+		if !ok {
+			continue
+		}
+		str, ok := fv.(string)
+		if !ok {
+			b, _ := json.Marshal(fv)
+			str = string(b)
+		}
+		if strings.EqualFold(str, value) {
+			return el, true
+		}
+	}
+	return nil, false
+}
+
+func normalizeField(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/history"
+)
+
+const dateLayout = "2006-01-02"
+
+const historyUsage = "usage: asip history export|chart -store FILE -domain DOMAIN [-from DATE] [-to DATE] [-format FORMAT]"
+
+func historyCmd(args []string, stdout io.Writer) error {
+	if len(args) == 0 || (args[0] != "export" && args[0] != "chart") {
+		return errors.New(historyUsage)
+	}
+
+	formats := map[string]string{"export": "csv", "chart": "svg"}
+	fs := newFlagSet("asip history " + args[0])
+	store := fs.String("store", "", "dataset of lookup outputs, e.g. made with asip merge -all")
+	domain := fs.String("domain", "", "domain of the series")
+	from := fs.String("from", "", "first date of the series like 2023-01-01")
+	to := fs.String("to", "", "last date of the series like 2023-12-31")
+	format := fs.String("format", formats[args[0]], "csv or json to export, svg or png to chart")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *store == "" || *domain == "" {
+		return errors.New(historyUsage)
+	}
+
+	start, err := parseDate(*from)
+	if err != nil {
+		return fmt.Errorf("from: %w", err)
+	}
+	end, err := parseDate(*to)
+	if err != nil {
+		return fmt.Errorf("to: %w", err)
+	}
+	if !end.IsZero() {
+		end = end.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	s, err := history.Open(*store)
+	if err != nil {
+		return err
+	}
+	ps := s.Series(*domain, start, end)
+
+	switch *format {
+	case "csv":
+		return writeCSV(stdout, ps)
+	case "json":
+		if ps == nil {
+			ps = []history.Point{}
+		}
+		return json.NewEncoder(stdout).Encode(ps)
+	case "svg":
+		return history.SVG(stdout, ps)
+	case "png":
+		return history.PNG(stdout, ps)
+	}
+	return fmt.Errorf("unknown format %q", *format)
+}
+
+// parseDate parses a date like 2023-01-01, an empty one is zero.
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(dateLayout, s)
+}
+
+func writeCSV(w io.Writer, ps []history.Point) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "global_rank", "local_rank", "linking_total"})
+	for _, p := range ps {
+		cw.Write([]string{
+			p.Time.Format(time.RFC3339),
+			strconv.FormatUint(uint64(p.GlobalRank), 10),
+			strconv.FormatUint(uint64(p.LocalRank), 10),
+			strconv.FormatUint(uint64(p.LinkingTotal), 10),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+// inspectWidth is how much of the extracted text is printed.
+const inspectWidth = 60
+
+func inspectCmd(args []string, stdout io.Writer) error {
+	fs := newFlagSet("asip inspect")
+	layout := fs.String("layout", "", "inspect as of the layout version instead of detecting it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: asip inspect [-layout VERSION] FILE")
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var opts []parse.Option
+	if *layout != "" {
+		opts = append(opts, parse.WithLayout(*layout))
+	}
+	v, ms, err := parse.Inspect(f, opts...)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "layout %s\n\n", v)
+	tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tSELECTOR\tMATCHES\tTEXT")
+	for _, m := range ms {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", m.Field, m.Selector, m.Matches, clip(m.Text, inspectWidth))
+	}
+	return tw.Flush()
+}
+
+// clip cuts s to n runes marking the cut with an ellipsis.
+func clip(s string, n int) string {
+	rs := []rune(s)
+	if len(rs) <= n {
+		return s
+	}
+	return string(rs[:n-1]) + "…"
+}
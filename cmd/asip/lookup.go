@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/cache"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/fetch"
+)
+
+// record is a line of the lookup output.
+type record struct {
+	SchemaVersion int             `json:"schema_version"`
+	Domain        string          `json:"domain"`
+	Meta          json.RawMessage `json:"meta,omitempty"`
+	Site          *asip.Site      `json:"site,omitempty"`
+	Error         string          `json:"error,omitempty"`
+}
+
+// input is a line of the lookup input, either a bare domain or a JSON
+// object whose meta is echoed back on the output record.
+type input struct {
+	Domain string          `json:"domain"`
+	Meta   json.RawMessage `json:"meta,omitempty"`
+}
+
+func lookupCmd(args []string, stdin io.Reader, stdout io.Writer, opts ...asip.Option) error {
+	fs := newFlagSet("asip")
+	dir := fs.String("cache", "", "cache directory, e.g. populated by asip warm")
+	ttl := fs.Duration("cache-ttl", 24*time.Hour, "how long cached sites are served")
+	sortBy := fs.String("sort", "", "sort by global-rank, local-rank or linking-total")
+	var filters stringList
+	fs.Var(&filters, "filter", "keep sites matching an expression like country==Russia, repeatable")
+	maxRank := fs.Uint("max-global-rank", 0, "keep ranked sites with global rank of this or better")
+	minLinking := fs.Uint("min-linking-total", 0, "keep sites with at least this many sites linking in")
+	concurrency := fs.Int("concurrency", 1, "how many domains are looked up at once")
+	summary := fs.Bool("summary", false, "print summary statistics of the batch to stderr at the end")
+	quiet := fs.Bool("quiet", false, "print only values of -extract and exit with 2 if not ranked, 3 if blocked, 4 on network errors")
+	fieldList := fs.String("fields", "", "comma separated fields of sites to output, e.g. global_rank,country,linking_total")
+	extract := fs.String("extract", "", "print the value at a path like keywords[0].word or visitors[country=Russia].percent instead of records")
+	replay := fs.String("replay", "", "read pages saved as DOMAIN.html from a directory, .zip or .tar(.gz) instead of alexa.com")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var predicates []asip.Filter
+	if *maxRank != 0 {
+		predicates = append(predicates, asip.MaxGlobalRank(*maxRank))
+	}
+	if *minLinking != 0 {
+		predicates = append(predicates, asip.MinLinkingTotal(*minLinking))
+	}
+
+	out, err := newOutput(stdout, *sortBy, filters, predicates...)
+	if err != nil {
+		return err
+	}
+	if *fieldList != "" {
+		if out.fields, err = asip.ParseFields(*fieldList); err != nil {
+			return err
+		}
+		parsed := out.fields
+		if *quiet && !slices.Contains(parsed, "GlobalRank") {
+			// the exit status tells whether the site is ranked
+			parsed = append(slices.Clip(parsed), "GlobalRank")
+		}
+		opts = append(opts, asip.WithFields(parsed...))
+	}
+	if *extract != "" {
+		if out.extract, err = parseExtract(*extract); err != nil {
+			return err
+		}
+	}
+	out.quiet = *quiet
+
+	if *replay != "" {
+		f, err := fetch.OpenFileFetcher(*replay)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, asip.WithFetcher(f))
+	}
+	if *dir != "" {
+		c, err := cache.NewDir(*dir)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, asip.WithCache(c, *ttl, 0))
+	}
+
+	var inputs []input
+	for _, d := range fs.Args() {
+		inputs = append(inputs, input{Domain: d})
+	}
+	if len(inputs) == 0 {
+		if inputs, err = readInputs(stdin); err != nil {
+			return err
+		}
+	}
+
+	domains := make([]string, len(inputs))
+	for i, in := range inputs {
+		domains[i] = in.Domain
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	canonical, positions := asip.Dedupe(domains)
+
+	var (
+		sites []*asip.Site
+		code  int
+	)
+	for domain, res := range asip.New(opts...).Stream(ctx, canonical, *concurrency) {
+		sites = append(sites, res.Site)
+		code = max(code, exitCode(res.Site, res.Err))
+		for _, i := range positions[domain] {
+			rec := record{SchemaVersion: asip.SchemaVersion, Domain: domains[i], Meta: inputs[i].Meta, Site: res.Site}
+			if res.Err != nil {
+				rec.Error = res.Err.Error()
+			}
+			if err := out.write(rec); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := out.flush(); err != nil {
+		return err
+	}
+
+	if *summary {
+		if err := writeSummary(os.Stderr, asip.Summarize(sites)); err != nil {
+			return err
+		}
+	}
+	if *quiet && code != exitRanked {
+		return exitError(code)
+	}
+	return nil
+}
+
+// readInputs reads an input per line skipping blanks and # comments, lines
+// starting with { are JSON objects like {"domain": "...", "meta": {...}}.
+func readInputs(r io.Reader) ([]input, error) {
+	var inputs []input
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, "{") {
+			inputs = append(inputs, input{Domain: line})
+			continue
+		}
+
+		var in input
+		if err := json.Unmarshal([]byte(line), &in); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if in.Domain == "" {
+			return nil, fmt.Errorf("line %d: no domain", n)
+		}
+		inputs = append(inputs, in)
+	}
+	return inputs, sc.Err()
+}
+
+// readDomains is like readInputs but drops metadata.
+func readDomains(r io.Reader) ([]string, error) {
+	inputs, err := readInputs(r)
+	if err != nil {
+		return nil, err
+	}
+
+	domains := make([]string, len(inputs))
+	for i, in := range inputs {
+		domains[i] = in.Domain
+	}
+	return domains, nil
+}
@@ -0,0 +1,145 @@
+// Command asip looks up Alexa Website Info of domains.
+//
+// Usage:
+//
+//	asip [-cache DIR] [domain ...]        look up domains, read from stdin if none given
+//
+// With -summary, statistics of the whole batch are printed to stderr at the
+// end.
+//
+// With -fields LIST, sites are cut down to fields like
+// global_rank,country,linking_total and only sections filling them are
+// parsed.
+//
+// With -extract PATH, only the value at a path like keywords[0].word or
+// visitors[country=Russia].percent is printed per domain, empty if missing.
+//
+// With -quiet, nothing but values of -extract is printed and the exit status
+// tells how the lookups went, the highest of them for several domains: 0 if
+// ranked, 2 if not ranked, 3 if blocked and 4 on network errors.
+//
+// Pages saved as DOMAIN.html can be replayed offline with -replay PATH.
+//
+// Domains read from stdin are either one per line or NDJSON objects like
+// {"domain": "example.org", "meta": {"id": 1}} whose meta is echoed back.
+//
+// Commands:
+//
+//	asip watchlist check|domains FILE     validate a watchlist or list its domains
+//	asip monitor [-webhook URL] [-selectors FILE] [-addr ADDR] FILE
+//	                                      watch domains of a watchlist
+//	asip warm -input FILE -cache DIR      pre-populate a cache
+//	asip serve [-addr ADDR] [-cache DIR]  serve lookups over a REST API
+//	asip demo                             look up embedded pages offline
+//	asip merge [-all] FILE ...            merge outputs keeping the newest records
+//	asip history export -store FILE -domain DOMAIN [-from DATE]
+//	                                      export a rank series as CSV or JSON
+//	asip history chart -store FILE -domain DOMAIN [-format svg|png]
+//	                                      render a rank series as a sparkline
+//	asip inspect [-layout VERSION] FILE   show what selectors match on a saved page
+//	asip completion bash|zsh|fish         print a shell completion script
+//	asip man                              print the man page
+//
+// Completions offer domains of the watchlist at $ASIP_WATCHLIST.
+//
+// A monitor reloads its watchlist, along with its limits of concurrent
+// lookups and selector overrides, on SIGHUP keeping schedules of the domains
+// still watched. Selector overrides of -selectors FILE, like
+// {"2017": {"global rank": "div.rank strong"}} with fields listed by
+// asip inspect, are also applied as soon as the file changes. With
+// -addr ADDR, every alert is streamed to dashboards subscribed to
+// ws://ADDR/events as JSON shaped like webhook posts, or to
+// http://ADDR/events/sse as Server-Sent Events replaying the last 100
+// alerts, those after Last-Event-ID if set, on connect.
+//
+// Besides GET /sites/DOMAIN, POST /v1/siteinfo:batch takes a JSON array of
+// domains and streams NDJSON results back as they complete. With -jobs DIR,
+// POST /v1/jobs takes larger arrays to look up in the background, answering
+// with a job polled at /v1/jobs/ID whose results are downloaded from
+// /v1/jobs/ID/results once done. Jobs interrupted by a restart are resumed.
+//
+// The API is described at /openapi.json. With -api-keys FILE, endpoints but
+// it and /metrics require one of the keys, requests are counted by labels of
+// their keys. With -rate-limits FILE, requests of a label beyond its rate or
+// daily quota are answered with 429. With -history FILE, the series of
+// the dataset are served to Grafana as a JSON datasource at /grafana.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		var code exitError
+		if errors.As(err, &code) {
+			os.Exit(int(code))
+		}
+		fmt.Fprintln(os.Stderr, "asip:", err)
+		os.Exit(1)
+	}
+}
+
+// command is a command of asip, the lookup one has no name. Completions and
+// the man page are generated from them.
+type command struct {
+	name  string
+	sub   []string // subcommands, the first one is run to list flags
+	usage string
+	help  string
+	run   func(args []string, stdin io.Reader, stdout io.Writer) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"", nil, "asip [flags] [domain ...]", "look up domains, read from stdin if none given", func(args []string, stdin io.Reader, stdout io.Writer) error {
+			return lookupCmd(args, stdin, stdout)
+		}},
+		{"watchlist", []string{"check", "domains"}, "asip watchlist check|domains FILE", "validate a watchlist or list its domains", func(args []string, _ io.Reader, stdout io.Writer) error {
+			return watchlistCmd(args, stdout)
+		}},
+		{"monitor", nil, "asip monitor [-webhook URL] [-selectors FILE] [-addr ADDR] FILE", "watch domains of a watchlist", func(args []string, _ io.Reader, _ io.Writer) error {
+			return monitorCmd(args)
+		}},
+		{"warm", nil, "asip warm -input FILE -cache DIR", "pre-populate a cache", func(args []string, _ io.Reader, stdout io.Writer) error {
+			return warmCmd(args, stdout)
+		}},
+		{"serve", nil, "asip serve [-addr ADDR] [-cache DIR]", "serve lookups over a REST API", func(args []string, _ io.Reader, _ io.Writer) error {
+			return serveCmd(args)
+		}},
+		{"demo", nil, "asip demo", "look up embedded pages offline", func(args []string, _ io.Reader, stdout io.Writer) error {
+			return demoCmd(args, stdout)
+		}},
+		{"merge", nil, "asip merge [-all] FILE ...", "merge outputs keeping the newest records", func(args []string, _ io.Reader, stdout io.Writer) error {
+			return mergeCmd(args, stdout)
+		}},
+		{"history", []string{"export", "chart"}, "asip history export|chart -store FILE -domain DOMAIN", "export or chart a rank series", func(args []string, _ io.Reader, stdout io.Writer) error {
+			return historyCmd(args, stdout)
+		}},
+		{"inspect", nil, "asip inspect [-layout VERSION] FILE", "show what selectors match on a saved page", func(args []string, _ io.Reader, stdout io.Writer) error {
+			return inspectCmd(args, stdout)
+		}},
+		{"completion", []string{"bash", "zsh", "fish"}, "asip completion bash|zsh|fish", "print a shell completion script", func(args []string, _ io.Reader, stdout io.Writer) error {
+			return completionCmd(args, stdout)
+		}},
+		{"man", nil, "asip man", "print the man page", func(args []string, _ io.Reader, stdout io.Writer) error {
+			return manCmd(args, stdout)
+		}},
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) > 0 {
+		for _, c := range commands[1:] {
+			if c.name == args[0] {
+				return c.run(args[1:], stdin, stdout)
+			}
+		}
+	}
+	return commands[0].run(args, stdin, stdout)
+}
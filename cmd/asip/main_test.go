@@ -0,0 +1,359 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/cache"
+)
+
+func TestWatchlistCheck(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "watchlist.json")
+	err := os.WriteFile(name, []byte(`{"entries": [{"domain": "example.org"}]}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"watchlist", "check", name}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "1 entries OK") {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+
+	out.Reset()
+	if err := run([]string{"watchlist", "domains", name}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "example.org\n" {
+		t.Fatalf("want example.org, got %q", out.String())
+	}
+}
+
+func TestWatchlistCheckInvalid(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "watchlist.json")
+	err := os.WriteFile(name, []byte(`{"entries": [{"domain": ""}]}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := run([]string{"watchlist", "check", name}, nil, &bytes.Buffer{}); err == nil {
+		t.Fatal("want error, but got no error")
+	}
+}
+
+func TestReadDomains(t *testing.T) {
+	got, err := readDomains(strings.NewReader("example.org\n\n# comment\n  example.com \n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"example.org", "example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestWarmSkipsFresh(t *testing.T) {
+	tmp := t.TempDir()
+	input := filepath.Join(tmp, "domains.txt")
+	if err := os.WriteFile(input, []byte("example.org\nWWW.Example.org\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := cache.NewDir(filepath.Join(tmp, "cache"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("example.org", cache.Entry{Site: &asip.Site{Domain: "example.org"}, StoredAt: time.Now()})
+
+	var out bytes.Buffer
+	if err := run([]string{"warm", "-input", input, "-cache", filepath.Join(tmp, "cache")}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "warmed 0, already fresh 1, failed 0\n" {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+}
+
+func TestLookupDedupe(t *testing.T) {
+	dir := t.TempDir()
+	c, err := cache.NewDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("example.org", cache.Entry{Site: &asip.Site{Domain: "example.org", GlobalRank: 7}, StoredAt: time.Now()})
+
+	var out bytes.Buffer
+	if err := run([]string{"-cache", dir, "Example.org", "www.example.org"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want a record per input, got %q", out.String())
+	}
+	if !strings.Contains(lines[1], `"domain":"www.example.org"`) || !strings.Contains(lines[1], `"GlobalRank":7`) {
+		t.Fatalf("unexpected record %s", lines[1])
+	}
+}
+
+func TestLookupMetaPassthrough(t *testing.T) {
+	dir := t.TempDir()
+	c, err := cache.NewDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set("example.org", cache.Entry{Site: &asip.Site{Domain: "example.org"}, StoredAt: time.Now()})
+
+	in := strings.NewReader(`{"domain": "example.org", "meta": {"id": 1}}` + "\n" + `{"domain": "www.example.org", "meta": {"id": 2}}` + "\n")
+	var out bytes.Buffer
+	if err := run([]string{"-cache", dir}, in, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"meta":{"id":1}`) || !strings.Contains(lines[1], `"meta":{"id":2}`) {
+		t.Fatalf("meta is not echoed back: %q", out.String())
+	}
+}
+
+func TestReadInputsInvalid(t *testing.T) {
+	if _, err := readInputs(strings.NewReader("example.org\n{\"meta\": {}}\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("want line 2 error, got %v", err)
+	}
+}
+
+func TestLookupReplay(t *testing.T) {
+	dir := t.TempDir()
+	b, err := os.ReadFile("../../parse/testdata/body.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sberbank.ru.html"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"-replay", dir, "sberbank.ru", "example.org"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"GlobalRank":506`) || !strings.Contains(lines[1], `"error"`) {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+
+	out.Reset()
+	if err := run([]string{"-replay", dir, "-extract", "visitors[country=Russia].percent", "sberbank.ru", "example.org"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(out.String(), "\n"); len(lines) != 3 || lines[0] == "" || lines[1] != "" {
+		t.Fatalf("want a share of Russia and a blank line, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := run([]string{"-replay", dir, "-fields", "global_rank,country", "sberbank.ru"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"site":{"GlobalRank":506,"MainCountry":"Russia","MainCountryCode":"RU"}`) {
+		t.Fatalf("want global rank and country only, got %q", out.String())
+	}
+}
+
+func TestLookupQuiet(t *testing.T) {
+	dir := t.TempDir()
+	b, err := os.ReadFile("../../parse/testdata/body.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sberbank.ru.html"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"-replay", dir, "-quiet", "sberbank.ru"}, nil, &out); err != nil || out.Len() != 0 {
+		t.Fatalf("want a silent success, got %v %q", err, out.String())
+	}
+	if err := run([]string{"-replay", dir, "-quiet", "-extract", "global_rank", "sberbank.ru"}, nil, &out); err != nil || out.String() != "506\n" {
+		t.Fatalf("want the global rank only, got %v %q", err, out.String())
+	}
+	out.Reset()
+	if err := run([]string{"-replay", dir, "-quiet", "-fields", "main_country", "-extract", "main_country", "sberbank.ru"}, nil, &out); err != nil || out.String() != "Russia\n" {
+		t.Fatalf("want a ranked site with fields leaving out the rank, got %v %q", err, out.String())
+	}
+
+	var code exitError
+	if err := run([]string{"-replay", dir, "-quiet", "sberbank.ru", "example.org"}, nil, &out); !errors.As(err, &code) || code != exitNotRanked {
+		t.Fatalf("want exit status %d, got %v", exitNotRanked, err)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	for want, err := range map[int]error{
+		exitNotRanked: asip.ErrNoEnoughData,
+		exitBlocked:   &asip.StatusError{Code: http.StatusTooManyRequests},
+		exitNetwork:   &url.Error{Op: "Get", URL: "https://www.alexa.com", Err: errors.New("connection refused")},
+		1:             asip.ErrLayoutChanged,
+	} {
+		if got := exitCode(nil, err); got != want {
+			t.Fatalf("%v: want %d, got %d", err, want, got)
+		}
+	}
+	if got := exitCode(&asip.Site{GlobalRank: 1}, nil); got != exitRanked {
+		t.Fatalf("want %d, got %d", exitRanked, got)
+	}
+}
+
+func TestDemo(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"demo"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"GlobalRank":506`) || !strings.Contains(lines[1], "no enough data") {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tmp := t.TempDir()
+	run1 := filepath.Join(tmp, "run1.ndjson")
+	run2 := filepath.Join(tmp, "run2.ndjson")
+	err := os.WriteFile(run1, []byte(`{"domain": "example.org", "site": {"GlobalRank": 10, "Meta": {"FetchedAt": "2023-01-01T00:00:00Z"}}}
+{"schema_version": 2, "domain": "example.com", "error": "no enough data"}
+{"schema_version": 2, "domain": "example.net", "site": {"GlobalRank": 30, "Meta": {"FetchedAt": "2023-01-01T00:00:00Z"}}}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(run2, []byte(`{"schema_version": 2, "domain": "www.example.org", "site": {"GlobalRank": 20, "Meta": {"FetchedAt": "2023-02-01T00:00:00Z"}}}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ranks := func(args ...string) []string {
+		var out bytes.Buffer
+		if err := run(append([]string{"merge"}, args...), nil, &out); err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var rec record
+			if err := json.Unmarshal([]byte(line), &rec); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, fmt.Sprintf("%s %d", rec.Domain, rec.Site.GlobalRank))
+		}
+		return got
+	}
+
+	want := []string{"example.net 30", "www.example.org 20"}
+	if got := ranks(run1, run2); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	want = []string{"example.net 30", "example.org 10", "www.example.org 20"}
+	if got := ranks("-all", run1, run2); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestHistoryExport(t *testing.T) {
+	store := filepath.Join(t.TempDir(), "history.ndjson")
+	err := os.WriteFile(store, []byte(`{"schema_version": 2, "domain": "example.org", "site": {"GlobalRank": 10, "Meta": {"FetchedAt": "2022-12-01T00:00:00Z"}}}
+{"schema_version": 2, "domain": "example.org", "site": {"GlobalRank": 20, "LocalRank": 2, "LinkingTotal": 5, "Meta": {"FetchedAt": "2023-01-01T00:00:00Z"}}}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"history", "export", "-store", store, "--domain", "example.org", "--from", "2023-01-01"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "time,global_rank,local_rank,linking_total\n2023-01-01T00:00:00Z,20,2,5\n"
+	if out.String() != want {
+		t.Fatalf("want %q, got %q", want, out.String())
+	}
+}
+
+func TestHistoryChart(t *testing.T) {
+	store := filepath.Join(t.TempDir(), "history.ndjson")
+	err := os.WriteFile(store, []byte(`{"schema_version": 2, "domain": "example.org", "site": {"GlobalRank": 10, "Meta": {"FetchedAt": "2023-01-01T00:00:00Z"}}}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run([]string{"history", "chart", "-store", store, "-domain", "example.org"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "<svg") {
+		t.Fatalf("want an svg image, got %q", out.String())
+	}
+}
+
+func TestInspect(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"inspect", "../../parse/testdata/body.html"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "layout 2017\n") || !strings.Contains(out.String(), "global rank") {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+
+	if err := run([]string{"inspect"}, nil, &out); err == nil {
+		t.Fatal("want a usage error")
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	for shell, want := range map[string]string{
+		"bash": "complete -F _asip asip",
+		"zsh":  "compdef _asip asip",
+		"fish": "complete -c asip",
+	} {
+		var out bytes.Buffer
+		if err := run([]string{"completion", shell}, nil, &out); err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range []string{want, "webhook", "watchlist domains", "inspect"} {
+			if !strings.Contains(out.String(), w) {
+				t.Fatalf("%s: no %q in %s", shell, w, out.String())
+			}
+		}
+	}
+
+	if err := run([]string{"completion", "tcsh"}, nil, &bytes.Buffer{}); err == nil {
+		t.Fatal("want an unknown shell error")
+	}
+}
+
+func TestMan(t *testing.T) {
+	var out bytes.Buffer
+	if err := run([]string{"man"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range []string{".TH ASIP 1", ".B asip serve", `.BI \-webhook " STRING"`, "ASIP_WATCHLIST"} {
+		if !strings.Contains(out.String(), w) {
+			t.Fatalf("no %q in %s", w, out.String())
+		}
+	}
+}
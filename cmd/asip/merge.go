@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/export"
+)
+
+func mergeCmd(args []string, stdout io.Writer) error {
+	fs := newFlagSet("asip merge")
+	all := fs.Bool("all", false, "keep every distinct fetch of a domain rather than the newest one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("usage: asip merge [-all] FILE ...")
+	}
+
+	var recs []record
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		rs, err := readRecords(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		recs = append(recs, rs...)
+	}
+
+	enc := json.NewEncoder(stdout)
+	for _, rec := range merge(recs, *all) {
+		if err := enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readRecords decodes records of the lookup output upgrading ones of older
+// schema versions, failed lookups are dropped.
+func readRecords(r io.Reader) ([]record, error) {
+	var recs []record
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			return recs, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+
+		b, err := export.Migrate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+		var rec record
+		if err := json.Unmarshal(b, &rec); err != nil {
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+		if rec.Site != nil {
+			recs = append(recs, rec)
+		}
+	}
+}
+
+// merge keeps the newest record of every domain, or every record fetched
+// at a distinct time if all is set, ordered by domain and fetch time.
+func merge(recs []record, all bool) []record {
+	type key struct {
+		domain    string
+		fetchedAt time.Time
+	}
+
+	var (
+		merged []record
+		pos    = make(map[key]int)
+	)
+	for _, rec := range recs {
+		k := key{domain: asip.Normalize(rec.Domain)}
+		if all {
+			k.fetchedAt = rec.Site.Meta.FetchedAt
+		}
+
+		i, ok := pos[k]
+		switch {
+		case !ok:
+			pos[k] = len(merged)
+			merged = append(merged, rec)
+		case rec.Site.Meta.FetchedAt.After(merged[i].Site.Meta.FetchedAt):
+			merged[i] = rec
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := asip.Normalize(merged[i].Domain), asip.Normalize(merged[j].Domain)
+		if a != b {
+			return a < b
+		}
+		return merged[i].Site.Meta.FetchedAt.Before(merged[j].Site.Meta.FetchedAt)
+	})
+	return merged
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+// sortKeys are values of the --sort flag.
+var sortKeys = map[string]func(*asip.Site) uint{
+	"global-rank":   func(s *asip.Site) uint { return s.GlobalRank },
+	"local-rank":    func(s *asip.Site) uint { return s.LocalRank },
+	"linking-total": func(s *asip.Site) uint { return s.LinkingTotal },
+}
+
+// output writes filtered records as JSON lines, slimmed down to --fields or
+// to values of --extract, buffering them when sorted.
+type output struct {
+	w       io.Writer
+	enc     *json.Encoder
+	filters []asip.Filter
+	sortBy  string
+	fields  []string
+	extract extractor
+	quiet   bool
+	buf     []record
+}
+
+func newOutput(w io.Writer, sortBy string, filters []string, predicates ...asip.Filter) (*output, error) {
+	o := &output{w: w, enc: json.NewEncoder(w), sortBy: sortBy, filters: predicates}
+	if _, ok := sortKeys[sortBy]; sortBy != "" && !ok {
+		return nil, fmt.Errorf("unknown sort key %q", sortBy)
+	}
+
+	for _, expr := range filters {
+		f, err := parseFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		o.filters = append(o.filters, f)
+	}
+
+	return o, nil
+}
+
+func (o *output) write(rec record) error {
+	for _, f := range o.filters {
+		if rec.Site == nil || !f(rec.Site) {
+			return nil
+		}
+	}
+
+	if o.sortBy != "" {
+		o.buf = append(o.buf, rec)
+		return nil
+	}
+	return o.encode(rec)
+}
+
+func (o *output) encode(rec record) error {
+	if o.quiet && o.extract == nil {
+		return nil
+	}
+	if o.extract == nil {
+		if o.fields != nil && rec.Site != nil {
+			return o.enc.Encode(struct {
+				record
+				Site map[string]any `json:"site"`
+			}{rec, asip.SelectFields(rec.Site, o.fields)})
+		}
+		return o.enc.Encode(rec)
+	}
+
+	var v string
+	if rec.Site != nil {
+		v = o.extract.format(rec.Site)
+	}
+	_, err := fmt.Fprintln(o.w, v)
+	return err
+}
+
+// flush writes out sorted records, unranked and failed ones go last.
+func (o *output) flush() error {
+	key := sortKeys[o.sortBy]
+	rank := func(r record) uint {
+		if r.Site == nil || key(r.Site) == 0 {
+			return ^uint(0)
+		}
+		return key(r.Site)
+	}
+	sort.SliceStable(o.buf, func(i, j int) bool {
+		if o.sortBy == "linking-total" {
+			return rank(o.buf[i]) != ^uint(0) && (rank(o.buf[j]) == ^uint(0) || rank(o.buf[i]) > rank(o.buf[j]))
+		}
+		return rank(o.buf[i]) < rank(o.buf[j])
+	})
+
+	for _, rec := range o.buf {
+		if err := o.encode(rec); err != nil {
+			return err
+		}
+	}
+	o.buf = nil
+	return nil
+}
+
+// filterFields are fields usable in --filter expressions.
+var filterFields = map[string]func(*asip.Site) string{
+	"domain":        func(s *asip.Site) string { return s.Domain },
+	"title":         func(s *asip.Site) string { return s.Title },
+	"country":       func(s *asip.Site) string { return s.MainCountry },
+	"global-rank":   func(s *asip.Site) string { return strconv.FormatUint(uint64(s.GlobalRank), 10) },
+	"local-rank":    func(s *asip.Site) string { return strconv.FormatUint(uint64(s.LocalRank), 10) },
+	"linking-total": func(s *asip.Site) string { return strconv.FormatUint(uint64(s.LinkingTotal), 10) },
+}
+
+var filterOps = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// parseFilter compiles an expression like country==Russia or
+// global-rank<1000. Numeric fields compare as numbers, others as strings
+// ignoring case.
+func parseFilter(expr string) (asip.Filter, error) {
+	for _, op := range filterOps {
+		i := strings.Index(expr, op)
+		if i < 0 {
+			continue
+		}
+
+		name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(expr[:i])), "_", "-")
+		value := strings.TrimSpace(expr[i+len(op):])
+		field, ok := filterFields[name]
+		if !ok {
+			return nil, fmt.Errorf("filter %q: unknown field %s", expr, name)
+		}
+
+		want, numErr := strconv.ParseUint(value, 10, 64)
+		return func(s *asip.Site) bool {
+			got := field(s)
+			n, err := strconv.ParseUint(got, 10, 64)
+			if numErr != nil || err != nil {
+				return compare(strings.Compare(strings.ToLower(got), strings.ToLower(value)), op)
+			}
+			switch {
+			case n < want:
+				return compare(-1, op)
+			case n > want:
+				return compare(1, op)
+			}
+			return compare(0, op)
+		}, nil
+	}
+
+	return nil, fmt.Errorf("filter %q: no operator, want one of %s", expr, strings.Join(filterOps, " "))
+}
+
+func compare(c int, op string) bool {
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+var testRecords = []record{
+	{Domain: "a.example", Site: &asip.Site{Domain: "a.example", MainCountry: "Russia", GlobalRank: 300, LinkingTotal: 5}},
+	{Domain: "b.example", Error: "no enough data"},
+	{Domain: "c.example", Site: &asip.Site{Domain: "c.example", MainCountry: "Germany", GlobalRank: 100, LinkingTotal: 50}},
+	{Domain: "d.example", Site: &asip.Site{Domain: "d.example", MainCountry: "russia", GlobalRank: 200, LinkingTotal: 500}},
+}
+
+func written(t *testing.T, sortBy string, filters []string, predicates ...asip.Filter) []string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	o, err := newOutput(&buf, sortBy, filters, predicates...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range testRecords {
+		if err := o.write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := o.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	var domains []string
+	dec := json.NewDecoder(strings.NewReader(buf.String()))
+	for dec.More() {
+		var r record
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		domains = append(domains, r.Domain)
+	}
+	return domains
+}
+
+func TestOutputSort(t *testing.T) {
+	if got, want := written(t, "global-rank", nil), []string{"c.example", "d.example", "a.example", "b.example"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	if got, want := written(t, "linking-total", nil), []string{"d.example", "c.example", "a.example", "b.example"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestOutputFilter(t *testing.T) {
+	if got, want := written(t, "", []string{"country==Russia"}), []string{"a.example", "d.example"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	if got, want := written(t, "global-rank", []string{"global_rank<250", "linking-total>=50"}), []string{"c.example", "d.example"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestOutputPredicates(t *testing.T) {
+	if got, want := written(t, "", nil, asip.MaxGlobalRank(250), asip.MinLinkingTotal(100)), []string{"d.example"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestParseFilterInvalid(t *testing.T) {
+	for _, expr := range []string{"country", "colour==red"} {
+		if _, err := parseFilter(expr); err == nil {
+			t.Fatalf("%s: want error, but got no error", expr)
+		}
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	var sites []*asip.Site
+	for _, r := range testRecords {
+		sites = append(sites, r.Site)
+	}
+
+	var buf bytes.Buffer
+	if err := writeSummary(&buf, asip.Summarize(sites)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `sites               3
+ranked              3
+median global rank  200
+
+countries
+  Germany  1
+  Russia   1
+  russia   1
+`
+	if buf.String() != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, buf.String())
+	}
+}
+
+func TestExtract(t *testing.T) {
+	s := &asip.Site{
+		GlobalRank: 506,
+		Keywords:   []asip.Keyword{{Word: "sberbank"}, {Word: "sberbank online"}},
+		Visitors: []asip.Visitor{
+			{Country: "Russia", Percent: asip.Percent{Value: 87.5}},
+			{Country: "Ukraine", Percent: asip.Percent{Value: 2.1}},
+		},
+	}
+
+	for expr, want := range map[string]string{
+		"global_rank":                      "506",
+		"keywords[1].word":                 "sberbank online",
+		"visitors[country=russia].percent": "87.5",
+		"visitors[country=Italy].percent":  "",
+		"keywords[5].word":                 "",
+		"visitors[0]":                      `{"Country":"Russia","CountryCode":"","LocalRank":0,"Percent":87.5}`,
+	} {
+		e, err := parseExtract(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := e.format(s); got != want {
+			t.Fatalf("%s: want %q, got %q", expr, want, got)
+		}
+	}
+
+	for _, expr := range []string{"", "keywords..word", "keywords[0", "keywords[x]"} {
+		if _, err := parseExtract(expr); err == nil {
+			t.Fatalf("%s: want an error", expr)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+// Exit codes of -quiet, any other failure exits with 1.
+const (
+	exitRanked    = 0
+	exitNotRanked = 2
+	exitBlocked   = 3
+	exitNetwork   = 4
+)
+
+// exitError makes main exit with the code printing nothing.
+type exitError int
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+// exitCode tells how a lookup went.
+func exitCode(s *asip.Site, err error) int {
+	var (
+		se *asip.StatusError
+		ne net.Error
+	)
+	switch {
+	case err == nil && s != nil && s.GlobalRank > 0:
+		return exitRanked
+	case err == nil, errors.Is(err, asip.ErrNoEnoughData):
+		return exitNotRanked
+	case errors.As(err, &se):
+		switch se.Code {
+		case http.StatusNotFound:
+			return exitNotRanked
+		case http.StatusForbidden, http.StatusTooManyRequests:
+			return exitBlocked
+		}
+		return exitNetwork
+	case errors.Is(err, asip.ErrUnexpectedContentType):
+		return exitBlocked
+	case errors.As(err, &ne), errors.Is(err, context.DeadlineExceeded), errors.Is(err, asip.ErrTruncatedResponse):
+		return exitNetwork
+	}
+	return 1
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/cache"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/history"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/server"
+)
+
+func serveCmd(args []string) error {
+	fs := newFlagSet("asip serve")
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	dir := fs.String("cache", "", "cache directory, in memory if empty")
+	ttl := fs.Duration("cache-ttl", 24*time.Hour, "how long cached sites are served")
+	store := fs.String("history", "", "dataset to serve to Grafana under /grafana, e.g. made with asip merge -all")
+	keys := fs.String("api-keys", "", `JSON file of API keys required of clients mapped to their labels, like {"KEY": "team"}`)
+	batch := fs.Int("batch-concurrency", server.DefaultBatchConcurrency, "how many domains of a batch request are looked up at once")
+	jobs := fs.String("jobs", "", "directory of jobs of POST /v1/jobs, disabled if empty")
+	limits := fs.String("rate-limits", "", `JSON file of limits of API key labels, like {"team": {"per_minute": 60, "daily": 10000}}`)
+	selectors := fs.String("selectors", "", "JSON file of selector overrides, applied again whenever it changes")
+	fieldList := fs.String("fields", "", "comma separated fields of sites to answer with unless asked otherwise with ?fields=")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts := []server.Option{server.WithCacheTTL(*ttl), server.WithBatchConcurrency(*batch)}
+	if *keys != "" {
+		var m map[string]string
+		if err := readJSON(*keys, &m); err != nil {
+			return err
+		}
+		opts = append(opts, server.WithAPIKeys(m))
+	}
+	if *limits != "" {
+		var m map[string]server.RateLimit
+		if err := readJSON(*limits, &m); err != nil {
+			return err
+		}
+		opts = append(opts, server.WithRateLimits(m))
+	}
+	if *fieldList != "" {
+		fields, err := asip.ParseFields(*fieldList)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, server.WithFields(fields...))
+	}
+	if *jobs != "" {
+		opts = append(opts, server.WithJobs(*jobs))
+	}
+	if *store != "" {
+		h, err := history.Open(*store)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, server.WithHistory(h))
+	}
+
+	var c cache.Cache = cache.NewMemory()
+	if *dir != "" {
+		d, err := cache.NewDir(*dir)
+		if err != nil {
+			return err
+		}
+		c = d
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *selectors != "" {
+		if err := watchSelectors(ctx, *selectors); err != nil {
+			return err
+		}
+	}
+
+	h := server.New(asip.New(asip.WithCache(c, *ttl, 0)).SiteInfo, opts...)
+	if err := h.ResumeJobs(); err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:    *addr,
+		Handler: h,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func readJSON(name string, v any) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+// writeSummary renders s as a human readable report.
+func writeSummary(w io.Writer, s asip.Summary) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "sites\t%d\n", s.Sites)
+	fmt.Fprintf(tw, "ranked\t%d\n", s.Ranked)
+	fmt.Fprintf(tw, "median global rank\t%d\n", s.MedianRank)
+
+	for _, sec := range []struct {
+		name   string
+		counts []asip.Count
+	}{
+		{"countries", s.Countries},
+		{"categories", s.Categories},
+		{"shared upstreams", s.Upstreams},
+	} {
+		if len(sec.counts) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "\n%s\n", sec.name)
+		for _, c := range sec.counts {
+			fmt.Fprintf(tw, "  %s\t%d\n", c.Value, c.N)
+		}
+	}
+
+	return tw.Flush()
+}
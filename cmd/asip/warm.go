@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/cache"
+)
+
+func warmCmd(args []string, stdout io.Writer) error {
+	fs := newFlagSet("asip warm")
+	input := fs.String("input", "", "file with a domain per line")
+	dir := fs.String("cache", "", "cache directory to populate")
+	ttl := fs.Duration("ttl", 24*time.Hour, "skip domains cached more recently than this")
+	delay := fs.Duration("delay", 2*time.Second, "pause between requests")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *input == "" || *dir == "" {
+		return errors.New("usage: asip warm -input FILE -cache DIR")
+	}
+
+	f, err := os.Open(*input)
+	if err != nil {
+		return err
+	}
+	domains, err := readDomains(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	// lookups are cached by canonical domains
+	domains, _ = asip.Dedupe(domains)
+
+	c, err := cache.NewDir(*dir)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	conf := asip.New(asip.WithCache(c, *ttl, 0))
+	var warmed, fresh, failed int
+	for i, domain := range domains {
+		if e, ok, _ := c.Get(domain); ok && time.Since(e.StoredAt) < *ttl {
+			fresh++
+			continue
+		}
+
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(*delay):
+			}
+		}
+
+		if _, err := conf.SiteInfo(ctx, domain); err != nil {
+			fmt.Fprintf(os.Stderr, "asip: %s: %v\n", domain, err)
+			failed++
+			continue
+		}
+		warmed++
+	}
+
+	fmt.Fprintf(stdout, "warmed %d, already fresh %d, failed %d\n", warmed, fresh, failed)
+	return nil
+}
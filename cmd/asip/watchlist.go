@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/fetch"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/monitor"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func watchlistCmd(args []string, stdout io.Writer) error {
+	if len(args) != 2 || (args[0] != "check" && args[0] != "domains") {
+		return errors.New("usage: asip watchlist check|domains FILE")
+	}
+
+	w, err := monitor.LoadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	if args[0] == "domains" {
+		for _, e := range w.Entries {
+			fmt.Fprintln(stdout, e.Domain)
+		}
+		return nil
+	}
+	fmt.Fprintf(stdout, "%s: %d entries OK\n", args[1], len(w.Entries))
+	return nil
+}
+
+func monitorCmd(args []string) error {
+	fs := newFlagSet("asip monitor")
+	webhook := fs.String("webhook", "", "URL to post alerts of entries without notifiers to")
+	selectors := fs.String("selectors", "", "JSON file of selector overrides, applied again whenever it changes")
+	addr := fs.String("addr", "", "address to stream alerts from at /events over WebSocket and /events/sse as Server-Sent Events, disabled if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: asip monitor [-webhook URL] [-selectors FILE] [-addr ADDR] FILE")
+	}
+
+	w, err := monitor.LoadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *selectors != "" {
+		if err := watchSelectors(ctx, *selectors); err != nil {
+			return err
+		}
+	}
+
+	var n monitor.Notifier
+	if *webhook != "" {
+		n = &monitor.Webhook{URL: *webhook}
+	}
+
+	lim := fetch.NewLimiter(w.Limits)
+	m, err := monitor.New(asip.New(asip.WithLimiter(lim)).SiteInfo, w, n)
+	if err != nil {
+		return err
+	}
+	m.OnError = func(domain string, err error) {
+		fmt.Fprintf(os.Stderr, "asip: %s: %v\n", domain, err)
+	}
+	if *addr != "" {
+		m.Events = monitor.NewEvents(monitor.DefaultReplay)
+		if err := serveEvents(ctx, *addr, m.Events); err != nil {
+			return err
+		}
+	}
+
+	go reloadOnHangup(ctx, func() error {
+		w, err := monitor.LoadFile(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		if *selectors != "" {
+			if err := parse.LoadSelectorOverridesFile(*selectors); err != nil {
+				return err
+			}
+		}
+		if err := m.Reload(w); err != nil {
+			return err
+		}
+		lim.SetLimits(w.Limits)
+		return nil
+	})
+
+	if err := m.Run(ctx); err != context.Canceled {
+		return err
+	}
+	return nil
+}
+
+// serveEvents streams alerts of events at /events and /events/sse until
+// ctx is done.
+func serveEvents(ctx context.Context, addr string, events *monitor.Events) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /events", events.WebSocket())
+	mux.Handle("GET /events/sse", events.SSE())
+	srv := &http.Server{Handler: mux}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	go func() {
+		if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintln(os.Stderr, "asip: events:", err)
+		}
+	}()
+	return nil
+}
+
+// reloadOnHangup calls reload on every SIGHUP until ctx is done, a failed
+// reload keeps the running configuration.
+func reloadOnHangup(ctx context.Context, reload func() error) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if err := reload(); err != nil {
+				fmt.Fprintln(os.Stderr, "asip: reload:", err)
+				continue
+			}
+			fmt.Fprintln(os.Stderr, "asip: reloaded")
+		}
+	}
+}
+
+// watchSelectors applies selector overrides of the file as it changes.
+func watchSelectors(ctx context.Context, name string) error {
+	return parse.WatchSelectorOverrides(ctx, name, time.Second, func(err error) {
+		fmt.Fprintln(os.Stderr, "asip: selectors:", err)
+	})
+}
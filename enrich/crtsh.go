@@ -0,0 +1,101 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+const crtShLocation = "https://crt.sh/"
+
+// CrtSh merges subdomains found in certificate transparency logs by crt.sh
+// into the traffic-derived Subdomains.
+type CrtSh struct {
+	client *http.Client
+	base   string
+}
+
+// NewCrtSh bootstraps a crt.sh enricher, c may be nil to use the default
+// HTTP client.
+func NewCrtSh(c *http.Client) *CrtSh {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &CrtSh{c, crtShLocation}
+}
+
+type crtShEntry struct {
+	NameValue string `json:"name_value"`
+}
+
+// Enrich marks subdomains seen in certificates and appends the ones the page
+// does not list.
+func (c *CrtSh) Enrich(ctx context.Context, s *parse.Site) error {
+	names, err := c.names(ctx, s.Domain)
+	if err != nil {
+		return err
+	}
+
+	for i := range s.Subdomains {
+		if names[s.Subdomains[i].Domain] {
+			s.Subdomains[i].InCT = true
+			delete(names, s.Subdomains[i].Domain)
+		}
+	}
+
+	var rest []string
+	for n := range names {
+		rest = append(rest, n)
+	}
+	sort.Strings(rest)
+
+	for _, n := range rest {
+		s.Subdomains = append(s.Subdomains, parse.Subdomain{
+			Domain: n,
+			InCT:   true,
+		})
+	}
+
+	return nil
+}
+
+func (c *CrtSh) names(ctx context.Context, domain string) (map[string]bool, error) {
+	u := fmt.Sprintf("%s?q=%s&output=json", c.base, url.QueryEscape("%."+domain))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("crt.sh status code: %d", resp.StatusCode)
+	}
+
+	var entries []crtShEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for _, e := range entries {
+		for _, n := range strings.Split(e.NameValue, "\n") {
+			n = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(n)), "*.")
+			if n == domain || strings.HasSuffix(n, "."+domain) {
+				names[n] = true
+			}
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,48 @@
+package enrich
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestCrtSh(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != "%.example.org" {
+			t.Errorf("unexpected query %q", q)
+		}
+		w.Write([]byte(`[
+			{"name_value": "www.example.org\nexample.org"},
+			{"name_value": "*.mail.example.org"},
+			{"name_value": "example.org.evil.com"}
+		]`))
+	}))
+	defer ts.Close()
+
+	s := &parse.Site{
+		Domain: "example.org",
+		Subdomains: []parse.Subdomain{
+			{Domain: "www.example.org", InTraffic: true},
+			{Domain: "blog.example.org", InTraffic: true},
+		},
+	}
+
+	c := &CrtSh{ts.Client(), ts.URL + "/"}
+	if err := c.Enrich(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []parse.Subdomain{
+		{Domain: "www.example.org", InTraffic: true, InCT: true},
+		{Domain: "blog.example.org", InTraffic: true},
+		{Domain: "example.org", InCT: true},
+		{Domain: "mail.example.org", InCT: true},
+	}
+	if !reflect.DeepEqual(s.Subdomains, want) {
+		t.Fatalf("want %v, got %v", want, s.Subdomains)
+	}
+}
@@ -0,0 +1,95 @@
+// Package enrich augments parsed sites with data from other sources.
+package enrich
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+type resolver interface {
+	LookupIPAddr(context.Context, string) ([]net.IPAddr, error)
+	LookupNS(context.Context, string) ([]*net.NS, error)
+	LookupMX(context.Context, string) ([]*net.MX, error)
+}
+
+// DNS resolves A, AAAA, NS and MX records of the domain and its subdomains.
+type DNS struct {
+	r resolver
+}
+
+// NewDNS bootstraps a DNS enricher, r may be nil to use the default resolver.
+func NewDNS(r *net.Resolver) *DNS {
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	return &DNS{r}
+}
+
+// Enrich fills in s.DNS.
+func (d *DNS) Enrich(ctx context.Context, s *parse.Site) error {
+	hosts := []string{s.Domain}
+	for _, sd := range s.Subdomains {
+		if sd.Domain != s.Domain {
+			hosts = append(hosts, sd.Domain)
+		}
+	}
+
+	s.DNS = nil
+	for _, h := range hosts {
+		rs, err := d.lookup(ctx, h)
+		if err != nil {
+			return err
+		}
+		s.DNS = append(s.DNS, rs)
+	}
+
+	return nil
+}
+
+func (d *DNS) lookup(ctx context.Context, host string) (parse.DNSRecords, error) {
+	rs := parse.DNSRecords{Host: host}
+
+	ips, err := d.r.LookupIPAddr(ctx, host)
+	if failed(err) {
+		return rs, err
+	}
+	for _, ip := range ips {
+		if ip.IP.To4() != nil {
+			rs.A = append(rs.A, ip.IP.String())
+		} else {
+			rs.AAAA = append(rs.AAAA, ip.IP.String())
+		}
+	}
+
+	nss, err := d.r.LookupNS(ctx, host)
+	if failed(err) {
+		return rs, err
+	}
+	for _, ns := range nss {
+		rs.NS = append(rs.NS, strings.TrimSuffix(ns.Host, "."))
+	}
+
+	mxs, err := d.r.LookupMX(ctx, host)
+	if failed(err) {
+		return rs, err
+	}
+	for _, mx := range mxs {
+		rs.MX = append(rs.MX, strings.TrimSuffix(mx.Host, "."))
+	}
+
+	return rs, nil
+}
+
+// failed reports whether err is a real failure rather than a mere absence
+// of records.
+func failed(err error) bool {
+	var de *net.DNSError
+	if errors.As(err, &de) && de.IsNotFound {
+		return false
+	}
+	return err != nil
+}
@@ -0,0 +1,79 @@
+package enrich
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+type fakeResolver map[string]parse.DNSRecords
+
+func (f fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	rs, ok := f[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	var ips []net.IPAddr
+	for _, a := range append(rs.A, rs.AAAA...) {
+		ips = append(ips, net.IPAddr{IP: net.ParseIP(a)})
+	}
+	return ips, nil
+}
+
+func (f fakeResolver) LookupNS(_ context.Context, host string) ([]*net.NS, error) {
+	var nss []*net.NS
+	for _, ns := range f[host].NS {
+		nss = append(nss, &net.NS{Host: ns + "."})
+	}
+	return nss, nil
+}
+
+func (f fakeResolver) LookupMX(_ context.Context, host string) ([]*net.MX, error) {
+	var mxs []*net.MX
+	for _, mx := range f[host].MX {
+		mxs = append(mxs, &net.MX{Host: mx + "."})
+	}
+	return mxs, nil
+}
+
+func TestDNS(t *testing.T) {
+	records := []parse.DNSRecords{
+		{
+			Host: "example.org",
+			A:    []string{"93.184.216.34"},
+			AAAA: []string{"2606:2800:220:1:248:1893:25c8:1946"},
+			NS:   []string{"a.iana-servers.net"},
+			MX:   []string{"mail.example.org"},
+		},
+		{
+			Host: "www.example.org",
+			A:    []string{"93.184.216.34"},
+		},
+		{
+			Host: "gone.example.org",
+		},
+	}
+	r := make(fakeResolver)
+	for _, rs := range records[:2] {
+		r[rs.Host] = rs
+	}
+
+	s := &parse.Site{
+		Domain: "example.org",
+		Subdomains: []parse.Subdomain{
+			{Domain: "www.example.org"},
+			{Domain: "gone.example.org"},
+		},
+	}
+	if err := (&DNS{r}).Enrich(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(s.DNS, records) {
+		t.Fatalf("want %v, got %v", records, s.DNS)
+	}
+}
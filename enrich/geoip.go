@@ -0,0 +1,110 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+const (
+	geoIPLocation   = "https://geoip.maxmind.com/geoip/v2.1"
+	geoLiteLocation = "https://geolite.info/geoip/v2.1"
+)
+
+type ipResolver interface {
+	LookupIPAddr(context.Context, string) ([]net.IPAddr, error)
+}
+
+// GeoIP locates addresses of the domain with the MaxMind GeoIP2 or GeoLite2
+// Country web service and flags hosting outside the countries of its
+// audience.
+type GeoIP struct {
+	client     *http.Client
+	r          ipResolver
+	accountID  string
+	licenseKey string
+	base       string
+}
+
+// NewGeoIP bootstraps a GeoIP enricher using the free GeoLite2 service if
+// lite is set, c may be nil to use the default HTTP client.
+func NewGeoIP(accountID, licenseKey string, lite bool, c *http.Client) *GeoIP {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	base := geoIPLocation
+	if lite {
+		base = geoLiteLocation
+	}
+	return &GeoIP{c, net.DefaultResolver, accountID, licenseKey, base}
+}
+
+type geoIPCountry struct {
+	Country struct {
+		ISOCode string `json:"iso_code"`
+	} `json:"country"`
+}
+
+// Enrich fills in s.Enrichment.GeoIP.
+func (g *GeoIP) Enrich(ctx context.Context, s *parse.Site) error {
+	ips, err := g.r.LookupIPAddr(ctx, s.Domain)
+	if failed(err) {
+		return err
+	}
+
+	// nothing to compare hosts to without an audience, e.g. of unranked sites
+	known := s.MainCountryCode != "" || len(s.Visitors) > 0
+	info := &parse.GeoIP{}
+	for _, ip := range ips {
+		code, err := g.country(ctx, ip.IP)
+		if err != nil {
+			return err
+		}
+		info.Hosts = append(info.Hosts, parse.GeoIPHost{IP: ip.IP.String(), CountryCode: code})
+		if known && code != "" && !audience(s, code) {
+			info.Mismatch = true
+		}
+	}
+
+	s.Enrichment.GeoIP = info
+	return nil
+}
+
+func (g *GeoIP) country(ctx context.Context, ip net.IP) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.base+"/country/"+ip.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(g.accountID, g.licenseKey)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil // reserved or unknown address
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("geoip status code: %d", resp.StatusCode)
+	}
+
+	var c geoIPCountry
+	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+		return "", err
+	}
+	return c.Country.ISOCode, nil
+}
+
+// audience reports if visitors of s come from the country.
+func audience(s *parse.Site, code string) bool {
+	return s.MainCountryCode == code || slices.ContainsFunc(s.Visitors, func(v parse.Visitor) bool {
+		return v.CountryCode == code
+	})
+}
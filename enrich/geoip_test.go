@@ -0,0 +1,54 @@
+package enrich
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestGeoIP(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "42" || pass != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/country/192.0.2.1":
+			w.Write([]byte(`{"country": {"iso_code": "RU", "names": {"en": "Russia"}}}`))
+		case "/country/2001:db8::1":
+			w.Write([]byte(`{"country": {"iso_code": "PA", "names": {"en": "Panama"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	s := &parse.Site{Domain: "sberbank.ru", MainCountryCode: "RU"}
+	g := &GeoIP{ts.Client(), fakeResolver{"sberbank.ru": {A: []string{"192.0.2.1"}, AAAA: []string{"2001:db8::1"}}}, "42", "key", ts.URL}
+	if err := g.Enrich(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &parse.GeoIP{
+		Hosts: []parse.GeoIPHost{
+			{IP: "192.0.2.1", CountryCode: "RU"},
+			{IP: "2001:db8::1", CountryCode: "PA"},
+		},
+		Mismatch: true,
+	}
+	if !reflect.DeepEqual(s.Enrichment.GeoIP, want) {
+		t.Fatalf("want %v, got %v", want, s.Enrichment.GeoIP)
+	}
+
+	unranked := &parse.Site{Domain: "sberbank.ru"}
+	if err := g.Enrich(context.Background(), unranked); err != nil {
+		t.Fatal(err)
+	}
+	if got := unranked.Enrichment.GeoIP; len(got.Hosts) != 2 || got.Mismatch {
+		t.Fatalf("want hosts without a mismatch of a site without audience, got %v", got)
+	}
+}
@@ -0,0 +1,97 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+const securityTrailsLocation = "https://api.securitytrails.com/v1"
+
+// SecurityTrails fetches subdomains and historical DNS from the
+// SecurityTrails API.
+type SecurityTrails struct {
+	client *http.Client
+	token  string
+	base   string
+}
+
+// NewSecurityTrails bootstraps a SecurityTrails enricher authenticated by
+// token, c may be nil to use the default HTTP client.
+func NewSecurityTrails(token string, c *http.Client) *SecurityTrails {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &SecurityTrails{c, token, securityTrailsLocation}
+}
+
+type stSubdomains struct {
+	Subdomains []string `json:"subdomains"`
+}
+
+type stHistory struct {
+	Records []struct {
+		Values []struct {
+			IP string `json:"ip"`
+		} `json:"values"`
+		Organizations []string `json:"organizations"`
+		FirstSeen     string   `json:"first_seen"`
+		LastSeen      string   `json:"last_seen"`
+	} `json:"records"`
+}
+
+// Enrich fills in s.Enrichment.SecurityTrails.
+func (st *SecurityTrails) Enrich(ctx context.Context, s *parse.Site) error {
+	var subs stSubdomains
+	if err := st.get(ctx, "/domain/"+s.Domain+"/subdomains", &subs); err != nil {
+		return err
+	}
+
+	var hist stHistory
+	if err := st.get(ctx, "/history/"+s.Domain+"/dns/a", &hist); err != nil {
+		return err
+	}
+
+	info := &parse.SecurityTrails{}
+	for _, sub := range subs.Subdomains {
+		info.Subdomains = append(info.Subdomains, sub+"."+s.Domain)
+	}
+	for _, r := range hist.Records {
+		hr := parse.HistoricalRecord{
+			Organizations: r.Organizations,
+			FirstSeen:     r.FirstSeen,
+			LastSeen:      r.LastSeen,
+		}
+		for _, v := range r.Values {
+			hr.Values = append(hr.Values, v.IP)
+		}
+		info.HistoricalA = append(info.HistoricalA, hr)
+	}
+
+	s.Enrichment.SecurityTrails = info
+	return nil
+}
+
+func (st *SecurityTrails) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, st.base+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("APIKEY", st.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := st.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("securitytrails status code: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,68 @@
+package enrich
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestSecurityTrails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("APIKEY") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/domain/example.org/subdomains":
+			w.Write([]byte(`{"subdomains": ["www", "mail"], "subdomain_count": 2}`))
+		case "/history/example.org/dns/a":
+			w.Write([]byte(`{"records": [{
+				"values": [{"ip": "93.184.216.34", "ip_count": 1}],
+				"organizations": ["Edgecast Inc."],
+				"first_seen": "2013-08-02",
+				"last_seen": "2019-06-10"
+			}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	s := &parse.Site{Domain: "example.org"}
+	st := &SecurityTrails{ts.Client(), "secret", ts.URL}
+	if err := st.Enrich(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &parse.SecurityTrails{
+		Subdomains: []string{"www.example.org", "mail.example.org"},
+		HistoricalA: []parse.HistoricalRecord{
+			{
+				Values:        []string{"93.184.216.34"},
+				Organizations: []string{"Edgecast Inc."},
+				FirstSeen:     "2013-08-02",
+				LastSeen:      "2019-06-10",
+			},
+		},
+	}
+	if !reflect.DeepEqual(s.Enrichment.SecurityTrails, want) {
+		t.Fatalf("want %v, got %v", want, s.Enrichment.SecurityTrails)
+	}
+}
+
+func TestSecurityTrailsUnauthorized(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	st := &SecurityTrails{ts.Client(), "wrong", ts.URL}
+	if err := st.Enrich(context.Background(), &parse.Site{Domain: "example.org"}); err == nil {
+		t.Fatal("want error, but got no error")
+	}
+}
@@ -0,0 +1,90 @@
+package enrich
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+const (
+	// DefaultTitleConcurrency is a number of pages fetched at once.
+	DefaultTitleConcurrency = 4
+
+	// titleMaxBytes limits how much of a page is read looking for its title.
+	titleMaxBytes = 256 << 10
+)
+
+// Titles resolves titles of the pages linking to the website.
+type Titles struct {
+	client *http.Client
+	n      int
+}
+
+// NewTitles bootstraps a Titles enricher fetching up to n pages at once,
+// c may be nil to use the default HTTP client.
+func NewTitles(n int, c *http.Client) *Titles {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	if n < 1 {
+		n = DefaultTitleConcurrency
+	}
+	return &Titles{c, n}
+}
+
+// Enrich fills in Title of s.LinksFrom, leaving it empty for pages that
+// can not be fetched.
+func (t *Titles) Enrich(ctx context.Context, s *parse.Site) error {
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, t.n)
+	)
+	for i := range s.LinksFrom {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(l *parse.Link) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			l.Title = t.title(ctx, l.Page)
+		}(&s.LinksFrom[i])
+	}
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+func (t *Titles) title(ctx context.Context, page string) string {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, page, nil)
+	if err != nil {
+		return ""
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+
+	d, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, titleMaxBytes))
+	if err != nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(d.Find("title").First().Text()), " ")
+}
@@ -0,0 +1,46 @@
+package enrich
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestTitles(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/doc":
+			w.Write([]byte("<html><head><title>\n  Yandex Money\n</title></head></html>"))
+		case "/big":
+			w.Write([]byte("<html><head>" + strings.Repeat(" ", titleMaxBytes) + "<title>Too far</title></head></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	s := &parse.Site{
+		LinksFrom: []parse.Link{
+			{Site: "yandex.ru", Page: ts.URL + "/doc"},
+			{Site: "mail.ru", Page: ts.URL + "/gone"},
+			{Site: "fc2.com", Page: ts.URL + "/big"},
+		},
+	}
+	if err := NewTitles(2, ts.Client()).Enrich(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+
+	var titles []string
+	for _, l := range s.LinksFrom {
+		titles = append(titles, l.Title)
+	}
+	want := []string{"Yandex Money", "", ""}
+	if !reflect.DeepEqual(titles, want) {
+		t.Fatalf("want %q, got %q", want, titles)
+	}
+}
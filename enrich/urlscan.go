@@ -0,0 +1,143 @@
+package enrich
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+const (
+	urlScanLocation   = "https://urlscan.io"
+	urlScanVisibility = "unlisted"
+)
+
+// URLScan attaches the latest urlscan.io scan of the domain, submitting a
+// new one when there is none and submission is enabled.
+type URLScan struct {
+	client *http.Client
+	apiKey string
+	submit bool
+	base   string
+
+	// Visibility of submitted scans: public, unlisted or private. Public
+	// scans show the domain on urlscan.io to anyone, so the default is
+	// unlisted, which only urlscan.io and its security researchers see.
+	Visibility string
+}
+
+// NewURLScan bootstraps a urlscan.io enricher. The API key is only required
+// to submit scans, c may be nil to use the default HTTP client.
+func NewURLScan(apiKey string, submit bool, c *http.Client) *URLScan {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &URLScan{client: c, apiKey: apiKey, submit: submit, base: urlScanLocation, Visibility: urlScanVisibility}
+}
+
+type usSearch struct {
+	Results []struct {
+		ID         string `json:"_id"`
+		Result     string `json:"result"`
+		Screenshot string `json:"screenshot"`
+	} `json:"results"`
+}
+
+type usResult struct {
+	Verdicts struct {
+		Overall struct {
+			Score      int      `json:"score"`
+			Malicious  bool     `json:"malicious"`
+			Categories []string `json:"categories"`
+		} `json:"overall"`
+	} `json:"verdicts"`
+}
+
+type usSubmission struct {
+	UUID   string `json:"uuid"`
+	Result string `json:"result"`
+}
+
+// Enrich fills in s.Enrichment.URLScan, leaving it nil when there is no scan
+// and submission is disabled.
+func (us *URLScan) Enrich(ctx context.Context, s *parse.Site) error {
+	var found usSearch
+	q := url.Values{"q": {"domain:" + s.Domain}, "size": {"1"}}
+	if err := us.do(ctx, http.MethodGet, "/api/v1/search/?"+q.Encode(), nil, &found); err != nil {
+		return err
+	}
+
+	if len(found.Results) > 0 {
+		r := found.Results[0]
+		var res usResult
+		if err := us.do(ctx, http.MethodGet, "/api/v1/result/"+r.ID+"/", nil, &res); err != nil {
+			return err
+		}
+
+		s.Enrichment.URLScan = &parse.URLScan{
+			UUID:       r.ID,
+			ResultURL:  us.base + "/result/" + r.ID + "/",
+			Screenshot: r.Screenshot,
+			Malicious:  res.Verdicts.Overall.Malicious,
+			Score:      res.Verdicts.Overall.Score,
+			Categories: res.Verdicts.Overall.Categories,
+		}
+		return nil
+	}
+
+	if !us.submit {
+		return nil
+	}
+
+	visibility := us.Visibility
+	if visibility == "" {
+		visibility = urlScanVisibility
+	}
+	var sub usSubmission
+	body := map[string]string{"url": s.Domain, "visibility": visibility}
+	if err := us.do(ctx, http.MethodPost, "/api/v1/scan/", body, &sub); err != nil {
+		return err
+	}
+
+	s.Enrichment.URLScan = &parse.URLScan{
+		UUID:       sub.UUID,
+		ResultURL:  sub.Result,
+		Screenshot: us.base + "/screenshots/" + sub.UUID + ".png",
+		Pending:    true,
+	}
+	return nil
+}
+
+func (us *URLScan) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, us.base+path, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if us.apiKey != "" {
+		req.Header.Set("API-Key", us.apiKey)
+	}
+
+	resp, err := us.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("urlscan status code: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,79 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestURLScanExisting(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/search/":
+			w.Write([]byte(`{"results": [{
+				"_id": "abc",
+				"result": "https://urlscan.io/api/v1/result/abc/",
+				"screenshot": "https://urlscan.io/screenshots/abc.png"
+			}]}`))
+		case "/api/v1/result/abc/":
+			w.Write([]byte(`{"verdicts": {"overall": {"score": 100, "malicious": true, "categories": ["phishing"]}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	s := &parse.Site{Domain: "example.org"}
+	us := &URLScan{client: ts.Client(), submit: true, base: ts.URL}
+	if err := us.Enrich(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &parse.URLScan{
+		UUID:       "abc",
+		ResultURL:  ts.URL + "/result/abc/",
+		Screenshot: "https://urlscan.io/screenshots/abc.png",
+		Malicious:  true,
+		Score:      100,
+		Categories: []string{"phishing"},
+	}
+	if !reflect.DeepEqual(s.Enrichment.URLScan, want) {
+		t.Fatalf("want %v, got %v", want, s.Enrichment.URLScan)
+	}
+}
+
+func TestURLScanSubmit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/search/":
+			w.Write([]byte(`{"results": []}`))
+		case "/api/v1/scan/":
+			if r.Method != http.MethodPost || r.Header.Get("API-Key") != "key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var sub struct{ Visibility string }
+			if err := json.NewDecoder(r.Body).Decode(&sub); err != nil || sub.Visibility != "unlisted" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"uuid": "def", "result": "https://urlscan.io/result/def/"}`))
+		}
+	}))
+	defer ts.Close()
+
+	s := &parse.Site{Domain: "example.org"}
+	us := &URLScan{client: ts.Client(), apiKey: "key", submit: true, base: ts.URL}
+	if err := us.Enrich(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := s.Enrichment.URLScan; got == nil || got.UUID != "def" || !got.Pending {
+		t.Fatalf("want pending scan def, got %v", got)
+	}
+}
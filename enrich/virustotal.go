@@ -0,0 +1,90 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+const virusTotalLocation = "https://www.virustotal.com/api/v3"
+
+// VirusTotal attaches the VirusTotal domain report: detections, vendor
+// categories and popularity ranks.
+type VirusTotal struct {
+	client *http.Client
+	apiKey string
+	base   string
+}
+
+// NewVirusTotal bootstraps a VirusTotal enricher, c may be nil to use the
+// default HTTP client.
+func NewVirusTotal(apiKey string, c *http.Client) *VirusTotal {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &VirusTotal{c, apiKey, virusTotalLocation}
+}
+
+type vtDomain struct {
+	Data struct {
+		Attributes struct {
+			LastAnalysisStats struct {
+				Harmless   int `json:"harmless"`
+				Malicious  int `json:"malicious"`
+				Suspicious int `json:"suspicious"`
+				Undetected int `json:"undetected"`
+			} `json:"last_analysis_stats"`
+			Reputation      int               `json:"reputation"`
+			Categories      map[string]string `json:"categories"`
+			PopularityRanks map[string]struct {
+				Rank uint `json:"rank"`
+			} `json:"popularity_ranks"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// Enrich fills in s.Enrichment.VirusTotal.
+func (vt *VirusTotal) Enrich(ctx context.Context, s *parse.Site) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, vt.base+"/domains/"+s.Domain, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-apikey", vt.apiKey)
+
+	resp, err := vt.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("virustotal status code: %d", resp.StatusCode)
+	}
+
+	var d vtDomain
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return err
+	}
+
+	a := d.Data.Attributes
+	info := &parse.VirusTotal{
+		Malicious:  a.LastAnalysisStats.Malicious,
+		Suspicious: a.LastAnalysisStats.Suspicious,
+		Harmless:   a.LastAnalysisStats.Harmless,
+		Undetected: a.LastAnalysisStats.Undetected,
+		Reputation: a.Reputation,
+		Categories: a.Categories,
+	}
+	if len(a.PopularityRanks) > 0 {
+		info.PopularityRanks = make(map[string]uint, len(a.PopularityRanks))
+		for provider, r := range a.PopularityRanks {
+			info.PopularityRanks[provider] = r.Rank
+		}
+	}
+
+	s.Enrichment.VirusTotal = info
+	return nil
+}
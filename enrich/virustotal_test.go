@@ -0,0 +1,45 @@
+package enrich
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestVirusTotal(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/domains/example.org" || r.Header.Get("x-apikey") != "key" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"data": {"attributes": {
+			"last_analysis_stats": {"harmless": 70, "malicious": 1, "suspicious": 0, "undetected": 9},
+			"reputation": 5,
+			"categories": {"Forcepoint ThreatSeeker": "search engines and portals"},
+			"popularity_ranks": {"Alexa": {"rank": 506, "timestamp": 1684169881}}
+		}}}`))
+	}))
+	defer ts.Close()
+
+	s := &parse.Site{Domain: "example.org"}
+	vt := &VirusTotal{ts.Client(), "key", ts.URL}
+	if err := vt.Enrich(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &parse.VirusTotal{
+		Malicious:       1,
+		Harmless:        70,
+		Undetected:      9,
+		Reputation:      5,
+		Categories:      map[string]string{"Forcepoint ThreatSeeker": "search engines and portals"},
+		PopularityRanks: map[string]uint{"Alexa": 506},
+	}
+	if !reflect.DeepEqual(s.Enrichment.VirusTotal, want) {
+		t.Fatalf("want %v, got %v", want, s.Enrichment.VirusTotal)
+	}
+}
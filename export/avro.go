@@ -0,0 +1,273 @@
+// Package export serializes sites into formats for external storage.
+package export
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+const (
+	avroNamespace = "asip"
+	avroBlockSize = 100
+)
+
+var (
+	avroMagic = []byte{'O', 'b', 'j', 1}
+	timeType  = reflect.TypeOf(time.Time{})
+)
+
+type avroField struct {
+	Name string      `json:"name"`
+	Type interface{} `json:"type"`
+}
+
+type avroRecord struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Fields    []avroField `json:"fields"`
+}
+
+// AvroSchema returns an Avro schema of Site derived from the Go struct, so
+// it never drifts from what is actually encoded.
+func AvroSchema() string {
+	b, err := json.Marshal(avroType(reflect.TypeOf(parse.Site{}), map[string]bool{}))
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+func avroType(t reflect.Type, defined map[string]bool) interface{} {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "long"
+	case reflect.Float32, reflect.Float64:
+		return "double"
+	case reflect.String:
+		return "string"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes"
+		}
+		return map[string]interface{}{"type": "array", "items": avroType(t.Elem(), defined)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "map", "values": avroType(t.Elem(), defined)}
+	case reflect.Ptr:
+		return []interface{}{"null", avroType(t.Elem(), defined)}
+	case reflect.Struct:
+		if t == timeType {
+			return map[string]interface{}{"type": "long", "logicalType": "timestamp-millis"}
+		}
+		if defined[t.Name()] {
+			return t.Name()
+		}
+		defined[t.Name()] = true
+
+		r := avroRecord{Type: "record", Name: t.Name(), Namespace: avroNamespace}
+		for _, f := range avroFields(t) {
+			r.Fields = append(r.Fields, avroField{
+				Name: snakeCase(f.Name),
+				Type: avroType(f.Type, defined),
+			})
+		}
+		return r
+	}
+	panic(fmt.Sprintf("export: %s can not be represented in avro", t))
+}
+
+// avroFields lists struct fields that have an Avro representation.
+func avroFields(t reflect.Type) []reflect.StructField {
+	var fs []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || !avroable(f.Type) {
+			continue
+		}
+		fs = append(fs, f)
+	}
+	return fs
+}
+
+func avroable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Func, reflect.Chan, reflect.Interface, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && avroable(t.Elem())
+	case reflect.Slice, reflect.Array, reflect.Ptr:
+		return avroable(t.Elem())
+	}
+	return true
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	rs := []rune(s)
+	for i, r := range rs {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(rs[i-1]) || (i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// AvroEncoder writes sites as an Avro object container file.
+type AvroEncoder struct {
+	w      io.Writer
+	sync   [16]byte
+	block  bytes.Buffer
+	count  int64
+	header bool
+}
+
+// NewAvroEncoder bootstraps an AvroEncoder writing to w.
+func NewAvroEncoder(w io.Writer) *AvroEncoder {
+	e := &AvroEncoder{w: w}
+	rand.Read(e.sync[:])
+	return e
+}
+
+// Encode appends s to the current block, writing it out once full.
+func (e *AvroEncoder) Encode(s *parse.Site) error {
+	encodeAvro(&e.block, reflect.ValueOf(*s))
+	e.count++
+	if e.count >= avroBlockSize {
+		return e.Flush()
+	}
+	return nil
+}
+
+// Flush writes out the buffered block.
+func (e *AvroEncoder) Flush() error {
+	if !e.header {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+		e.header = true
+	}
+	if e.count == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	writeLong(&buf, e.count)
+	writeLong(&buf, int64(e.block.Len()))
+	buf.Write(e.block.Bytes())
+	buf.Write(e.sync[:])
+
+	e.block.Reset()
+	e.count = 0
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+// Close flushes the remaining records.
+func (e *AvroEncoder) Close() error {
+	return e.Flush()
+}
+
+func (e *AvroEncoder) writeHeader() error {
+	var buf bytes.Buffer
+	buf.Write(avroMagic)
+	writeLong(&buf, 3)
+	writeString(&buf, "avro.schema")
+	writeString(&buf, AvroSchema())
+	writeString(&buf, "avro.codec")
+	writeString(&buf, "null")
+	writeString(&buf, "asip.schema_version")
+	writeString(&buf, strconv.Itoa(parse.SchemaVersion))
+	writeLong(&buf, 0)
+	buf.Write(e.sync[:])
+
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+func encodeAvro(buf *bytes.Buffer, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeLong(buf, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		writeLong(buf, int64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v.Float()))
+		buf.Write(b[:])
+	case reflect.String:
+		writeString(buf, v.String())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			writeLong(buf, int64(v.Len()))
+			buf.Write(v.Bytes())
+			return
+		}
+		if v.Len() > 0 {
+			writeLong(buf, int64(v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				encodeAvro(buf, v.Index(i))
+			}
+		}
+		writeLong(buf, 0)
+	case reflect.Map:
+		if v.Len() > 0 {
+			writeLong(buf, int64(v.Len()))
+			iter := v.MapRange()
+			for iter.Next() {
+				writeString(buf, iter.Key().String())
+				encodeAvro(buf, iter.Value())
+			}
+		}
+		writeLong(buf, 0)
+	case reflect.Ptr:
+		if v.IsNil() {
+			writeLong(buf, 0)
+			return
+		}
+		writeLong(buf, 1)
+		encodeAvro(buf, v.Elem())
+	case reflect.Struct:
+		if v.Type() == timeType {
+			writeLong(buf, v.Interface().(time.Time).UnixMilli())
+			return
+		}
+		for _, f := range avroFields(v.Type()) {
+			encodeAvro(buf, v.FieldByIndex(f.Index))
+		}
+	}
+}
+
+func writeLong(buf *bytes.Buffer, n int64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutVarint(b[:], n)])
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	writeLong(buf, int64(len(s)))
+	buf.WriteString(s)
+}
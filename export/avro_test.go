@@ -0,0 +1,331 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestAvroSchema(t *testing.T) {
+	var r struct {
+		Name   string
+		Fields []struct {
+			Name string
+		}
+	}
+	if err := json.Unmarshal([]byte(AvroSchema()), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Name != "Site" {
+		t.Fatalf("want Site record, got %s", r.Name)
+	}
+	if n := reflect.TypeOf(parse.Site{}).NumField(); len(r.Fields) != n {
+		t.Fatalf("want %d fields, got %d", n, len(r.Fields))
+	}
+	if r.Fields[1].Name != "title" {
+		t.Fatalf("want title field, got %s", r.Fields[1].Name)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"GlobalRank": "global_rank",
+		"LinksFrom":  "links_from",
+		"SourceURL":  "source_url",
+		"HTTPStatus": "http_status",
+		"DNS":        "dns",
+	} {
+		if got := snakeCase(in); got != want {
+			t.Fatalf("want %s, got %s", want, got)
+		}
+	}
+}
+
+func TestEncodeAvro(t *testing.T) {
+	var buf bytes.Buffer
+	encodeAvro(&buf, reflect.ValueOf(parse.Keyword{
+		Word:    "ab",
+		Percent: parse.Percent{Raw: "", Value: 0},
+	}))
+
+	want := []byte{4, 'a', 'b', 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Fatalf("want %v, got %v", want, buf.Bytes())
+	}
+}
+
+func TestAvroEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewAvroEncoder(&buf)
+	if err := e.Encode(&parse.Site{Domain: "example.org", GlobalRank: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b := buf.Bytes()
+	if !bytes.HasPrefix(b, avroMagic) {
+		t.Fatal("no avro magic found")
+	}
+	if !bytes.HasSuffix(b, e.sync[:]) {
+		t.Fatal("block does not end with a sync marker")
+	}
+	if !bytes.Contains(b, []byte("example.org")) {
+		t.Fatal("record is not written")
+	}
+}
+
+// avroDecoder reads values of a schema back, the way a reader of the
+// container file would.
+type avroDecoder struct {
+	r     *bufio.Reader
+	named map[string]interface{}
+}
+
+func (d *avroDecoder) long() int64 {
+	n, err := binary.ReadVarint(d.r)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (d *avroDecoder) bytes() []byte {
+	b := make([]byte, d.long())
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (d *avroDecoder) decode(schema interface{}) interface{} {
+	switch s := schema.(type) {
+	case string:
+		switch s {
+		case "null":
+			return nil
+		case "boolean":
+			b, err := d.r.ReadByte()
+			if err != nil {
+				panic(err)
+			}
+			return b == 1
+		case "long":
+			return d.long()
+		case "double":
+			var b [8]byte
+			if _, err := io.ReadFull(d.r, b[:]); err != nil {
+				panic(err)
+			}
+			return math.Float64frombits(binary.LittleEndian.Uint64(b[:]))
+		case "string":
+			return string(d.bytes())
+		case "bytes":
+			return d.bytes()
+		}
+		return d.decode(d.named[s])
+	case []interface{}:
+		return d.decode(s[d.long()])
+	case map[string]interface{}:
+		switch s["type"] {
+		case "record":
+			rec := make(map[string]interface{})
+			for _, f := range s["fields"].([]interface{}) {
+				f := f.(map[string]interface{})
+				rec[f["name"].(string)] = d.decode(f["type"])
+			}
+			return rec
+		case "array":
+			a := []interface{}{}
+			for n := d.long(); n != 0; n = d.long() {
+				for ; n > 0; n-- {
+					a = append(a, d.decode(s["items"]))
+				}
+			}
+			return a
+		case "map":
+			m := map[string]interface{}{}
+			for n := d.long(); n != 0; n = d.long() {
+				for ; n > 0; n-- {
+					k := string(d.bytes())
+					m[k] = d.decode(s["values"])
+				}
+			}
+			return m
+		case "long":
+			return d.long()
+		}
+	}
+	panic(fmt.Sprintf("unknown schema %v", schema))
+}
+
+// define keeps named types of the schema, which may be referred to before
+// a value of their definition is read.
+func (d *avroDecoder) define(schema interface{}) {
+	switch s := schema.(type) {
+	case []interface{}:
+		for _, u := range s {
+			d.define(u)
+		}
+	case map[string]interface{}:
+		switch s["type"] {
+		case "record":
+			d.named[s["name"].(string)] = s
+			for _, f := range s["fields"].([]interface{}) {
+				d.define(f.(map[string]interface{})["type"])
+			}
+		case "array":
+			d.define(s["items"])
+		case "map":
+			d.define(s["values"])
+		}
+	}
+}
+
+// decodeAvro reads records out of an Avro object container file.
+func decodeAvro(t *testing.T, b []byte) []interface{} {
+	t.Helper()
+	if !bytes.HasPrefix(b, avroMagic) {
+		t.Fatal("no avro magic found")
+	}
+	d := &avroDecoder{r: bufio.NewReader(bytes.NewReader(b[len(avroMagic):])), named: map[string]interface{}{}}
+
+	meta := d.decode(map[string]interface{}{"type": "map", "values": "bytes"}).(map[string]interface{})
+	if codec := string(meta["avro.codec"].([]byte)); codec != "null" {
+		t.Fatalf("want null codec, got %s", codec)
+	}
+	var schema interface{}
+	if err := json.Unmarshal(meta["avro.schema"].([]byte), &schema); err != nil {
+		t.Fatal(err)
+	}
+	d.define(schema)
+	var sync [16]byte
+	if _, err := io.ReadFull(d.r, sync[:]); err != nil {
+		t.Fatal(err)
+	}
+
+	var records []interface{}
+	for {
+		if _, err := d.r.Peek(1); err == io.EOF {
+			return records
+		}
+		count, size := d.long(), d.long()
+		block := d.r
+		d.r = bufio.NewReader(io.LimitReader(block, size))
+		for ; count > 0; count-- {
+			records = append(records, d.decode(schema))
+		}
+		if _, err := d.r.Peek(1); err != io.EOF {
+			t.Fatal("block is longer than its records")
+		}
+		d.r = block
+
+		var marker [16]byte
+		if _, err := io.ReadFull(d.r, marker[:]); err != nil || marker != sync {
+			t.Fatalf("want sync marker after the block, got %v %v", marker, err)
+		}
+	}
+}
+
+// avroValue mirrors what a site decodes into.
+func avroValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return append([]byte{}, v.Bytes()...)
+		}
+		a := []interface{}{}
+		for i := 0; i < v.Len(); i++ {
+			a = append(a, avroValue(v.Index(i)))
+		}
+		return a
+	case reflect.Map:
+		m := map[string]interface{}{}
+		iter := v.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = avroValue(iter.Value())
+		}
+		return m
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return avroValue(v.Elem())
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface().(time.Time).UnixMilli()
+		}
+		rec := make(map[string]interface{})
+		for _, f := range avroFields(v.Type()) {
+			rec[snakeCase(f.Name)] = avroValue(v.FieldByIndex(f.Index))
+		}
+		return rec
+	}
+	panic(fmt.Sprintf("unknown kind %s", v.Kind()))
+}
+
+func TestAvroEncoderDecodes(t *testing.T) {
+	sites := make([]parse.Site, avroBlockSize+2)
+	for i := range sites {
+		sites[i] = parse.Site{Domain: fmt.Sprintf("%d.example", i), GlobalRank: uint(i)}
+	}
+	sites[1] = parse.Site{
+		Domain:         "example.org",
+		Title:          "Example",
+		GlobalRank:     506,
+		RankPercentile: 99.5,
+		Keywords:       parse.Keywords{{Word: "example", Percent: parse.Percent{Raw: "4.2%", Value: 4.2}}},
+		Related:        []string{"example.com", "example.net"},
+		LinksFrom:      []parse.Link{{Site: "a.example", Page: "a.example/", Rel: []string{"nofollow"}}},
+		Custom:         map[string]string{"owner": "IANA"},
+		Meta: parse.Meta{
+			FetchedAt:  time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC),
+			HTTPStatus: 200,
+			Duration:   time.Second,
+			FromCache:  true,
+			Headers:    map[string]string{"Server": "nginx"},
+		},
+	}
+
+	var buf bytes.Buffer
+	e := NewAvroEncoder(&buf)
+	for i := range sites {
+		if err := e.Encode(&sites[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records := decodeAvro(t, buf.Bytes())
+	if len(records) != len(sites) {
+		t.Fatalf("want %d records, got %d", len(sites), len(records))
+	}
+	for i, r := range records {
+		if want := avroValue(reflect.ValueOf(sites[i])); !reflect.DeepEqual(r, want) {
+			t.Fatalf("want %v, got %v", want, r)
+		}
+	}
+}
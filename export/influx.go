@@ -0,0 +1,95 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+// influxMetrics are fields written as measurements of their own, zero
+// values mean not ranked or not shown and are skipped.
+var influxMetrics = []struct {
+	name  string
+	value func(*parse.Site) uint
+}{
+	{"global_rank", func(s *parse.Site) uint { return s.GlobalRank }},
+	{"local_rank", func(s *parse.Site) uint { return s.LocalRank }},
+	{"linking_total", func(s *parse.Site) uint { return s.LinkingTotal }},
+}
+
+// influxTrends are engagement figures written with their 90-day change,
+// zero fields are skipped. Time on site has its change parsed only.
+var influxTrends = []struct {
+	name  string
+	trend func(*parse.Site) parse.Trend
+}{
+	{"bounce_rate", func(s *parse.Site) parse.Trend { return s.BounceRate }},
+	{"pageviews_per_visitor", func(s *parse.Site) parse.Trend { return s.PageviewsPerVisitor }},
+	{"time_on_site", func(s *parse.Site) parse.Trend { return parse.Trend{Change: s.Trends.TimeOnSite} }},
+	{"search_share", func(s *parse.Site) parse.Trend { return s.TrafficSources.Search }},
+}
+
+var influxEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+// InfluxEncoder writes rank and engagement metrics of sites as InfluxDB
+// line protocol with a measurement per metric tagged by domain, e.g.
+//
+//	global_rank,domain=sberbank.ru value=506i 1546300800000000000
+//	bounce_rate,domain=sberbank.ru value=25.1,change=-4 1546300800000000000
+type InfluxEncoder struct {
+	w *bufio.Writer
+}
+
+// NewInfluxEncoder bootstraps an InfluxEncoder writing to w.
+func NewInfluxEncoder(w io.Writer) *InfluxEncoder {
+	return &InfluxEncoder{bufio.NewWriter(w)}
+}
+
+// Encode writes a line per metric of s timestamped with the fetch time, the
+// local rank is tagged with the country as well.
+func (e *InfluxEncoder) Encode(s *parse.Site) error {
+	for _, m := range influxMetrics {
+		v := m.value(s)
+		if v == 0 {
+			continue
+		}
+		e.line(s, m.name, "value="+strconv.FormatUint(uint64(v), 10)+"i")
+	}
+
+	for _, m := range influxTrends {
+		t := m.trend(s)
+		var fields []string
+		if t.Value != 0 {
+			fields = append(fields, "value="+strconv.FormatFloat(t.Value, 'g', -1, 64))
+		}
+		if t.Change != 0 {
+			fields = append(fields, "change="+strconv.FormatFloat(t.Change, 'g', -1, 64))
+		}
+		if fields == nil {
+			continue
+		}
+		e.line(s, m.name, strings.Join(fields, ","))
+	}
+	return e.w.Flush()
+}
+
+// line writes a measurement of s with its fields.
+func (e *InfluxEncoder) line(s *parse.Site, name, fields string) {
+	e.w.WriteString(name)
+	e.w.WriteString(",domain=")
+	e.w.WriteString(influxEscaper.Replace(s.Domain))
+	if name == "local_rank" && s.MainCountryCode != "" {
+		e.w.WriteString(",country=")
+		e.w.WriteString(influxEscaper.Replace(s.MainCountryCode))
+	}
+	e.w.WriteByte(' ')
+	e.w.WriteString(fields)
+	if !s.Meta.FetchedAt.IsZero() {
+		e.w.WriteByte(' ')
+		e.w.WriteString(strconv.FormatInt(s.Meta.FetchedAt.UnixNano(), 10))
+	}
+	e.w.WriteByte('\n')
+}
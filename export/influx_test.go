@@ -0,0 +1,46 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestInfluxEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewInfluxEncoder(&buf)
+
+	sites := []*parse.Site{
+		{
+			Domain:          "sberbank.ru",
+			MainCountryCode: "RU",
+			GlobalRank:      506,
+			LocalRank:       17,
+			LinkingTotal:    8713,
+			Trends:          parse.Trends{TimeOnSite: -4},
+			BounceRate:      parse.Trend{Value: 25.1, Change: -4},
+			TrafficSources:  parse.TrafficSources{Search: parse.Trend{Value: 12.5}},
+			Meta:            parse.Meta{FetchedAt: time.Unix(1546300800, 0)},
+		},
+		{Domain: "odd name,=.example", LinkingTotal: 1},
+	}
+	for _, s := range sites {
+		if err := e.Encode(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := `global_rank,domain=sberbank.ru value=506i 1546300800000000000
+local_rank,domain=sberbank.ru,country=RU value=17i 1546300800000000000
+linking_total,domain=sberbank.ru value=8713i 1546300800000000000
+bounce_rate,domain=sberbank.ru value=25.1,change=-4 1546300800000000000
+time_on_site,domain=sberbank.ru change=-4 1546300800000000000
+search_share,domain=sberbank.ru value=12.5 1546300800000000000
+linking_total,domain=odd\ name\,\=.example value=1i
+`
+	if buf.String() != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, buf.String())
+	}
+}
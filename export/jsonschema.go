@@ -0,0 +1,286 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var percentType = reflect.TypeOf(parse.Percent{})
+
+// JSONSchema returns a JSON Schema of Site as encoded by encoding/json,
+// derived from the Go struct like AvroSchema.
+func JSONSchema() string {
+	b, err := json.MarshalIndent(jsonSchema(), "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return string(b) + "\n"
+}
+
+func jsonSchema() map[string]any {
+	defs := make(map[string]any)
+	root := jsonType(reflect.TypeOf(parse.Site{}), defs, "#/$defs/")
+	root["$schema"] = jsonSchemaDraft
+	root["title"] = "Site"
+	root["$defs"] = defs
+	return root
+}
+
+// JSONSchemas returns schemas of Site and the types it refers to by name,
+// referring to each other by prefix and name, e.g. for OpenAPI components
+// with the #/components/schemas/ prefix.
+func JSONSchemas(prefix string) map[string]any {
+	defs := make(map[string]any)
+	defs["Site"] = jsonType(reflect.TypeOf(parse.Site{}), defs, prefix)
+	return defs
+}
+
+func jsonType(t reflect.Type, defs map[string]any, prefix string) map[string]any {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case percentType:
+		// unbounded as the parser keeps implausible shares for Site.Validate
+		return map[string]any{"type": "number"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice:
+		return map[string]any{"type": []any{"array", "null"}, "items": jsonType(t.Elem(), defs, prefix)}
+	case reflect.Map:
+		return map[string]any{"type": []any{"object", "null"}, "additionalProperties": jsonType(t.Elem(), defs, prefix)}
+	case reflect.Ptr:
+		return map[string]any{"anyOf": []any{jsonType(t.Elem(), defs, prefix), map[string]any{"type": "null"}}}
+	case reflect.Struct:
+		if t.Name() == "Site" {
+			return jsonObject(t, defs, prefix)
+		}
+		if _, ok := defs[t.Name()]; !ok {
+			defs[t.Name()] = jsonObject(t, defs, prefix)
+		}
+		return map[string]any{"$ref": prefix + t.Name()}
+	}
+	panic(fmt.Sprintf("export: %s can not be represented in json schema", t))
+}
+
+func jsonObject(t reflect.Type, defs map[string]any, prefix string) map[string]any {
+	props := make(map[string]any)
+	required := []any{}
+	for _, f := range avroFields(t) {
+		name, omitempty := jsonName(f)
+		if name == "-" {
+			continue
+		}
+		props[name] = jsonType(f.Type, defs, prefix)
+		if !omitempty {
+			required = append(required, name)
+		}
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// jsonName returns the name encoding/json gives to f.
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "-", false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, strings.Contains(opts, "omitempty")
+}
+
+// ValidateJSON validates every JSON document read from r, like a single site
+// or NDJSON of them, against JSONSchema.
+func ValidateJSON(r io.Reader) error {
+	schema := jsonSchema()
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	// round trip to validate against the same values a consumer would see
+	var root map[string]any
+	if err := json.Unmarshal(b, &root); err != nil {
+		return err
+	}
+	v := &validator{defs: root["$defs"].(map[string]any)}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	for n := 1; ; n++ {
+		var doc any
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("document %d: %w", n, err)
+		}
+		if errs := v.validate(root, doc, ""); len(errs) > 0 {
+			return fmt.Errorf("document %d: %w", n, errors.Join(errs...))
+		}
+	}
+	return nil
+}
+
+type validator struct {
+	defs map[string]any
+}
+
+// validate checks doc against the subset of JSON Schema JSONSchema uses.
+func (v *validator) validate(schema map[string]any, doc any, path string) []error {
+	if ref, ok := schema["$ref"].(string); ok {
+		return v.validate(v.defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any), doc, path)
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		var errs []error
+		for _, s := range anyOf {
+			if errs = v.validate(s.(map[string]any), doc, path); len(errs) == 0 {
+				return nil
+			}
+		}
+		return errs
+	}
+
+	if !typeMatches(schema["type"], doc) {
+		return []error{fmt.Errorf("%s: want %v, got %s", where(path), schema["type"], typeOf(doc))}
+	}
+
+	var errs []error
+	switch d := doc.(type) {
+	case json.Number:
+		f, _ := d.Float64()
+		if min, ok := schema["minimum"].(float64); ok && f < min {
+			errs = append(errs, fmt.Errorf("%s: %v is less than %v", where(path), d, min))
+		}
+		if max, ok := schema["maximum"].(float64); ok && f > max {
+			errs = append(errs, fmt.Errorf("%s: %v is greater than %v", where(path), d, max))
+		}
+	case string:
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, d); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %q is not a date-time", where(path), d))
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, e := range d {
+				errs = append(errs, v.validate(items, e, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case map[string]any:
+		errs = append(errs, v.validateObject(schema, d, path)...)
+	}
+	return errs
+}
+
+func (v *validator) validateObject(schema map[string]any, doc map[string]any, path string) []error {
+	var errs []error
+	props, _ := schema["properties"].(map[string]any)
+	required, _ := schema["required"].([]any)
+	for _, name := range required {
+		if _, ok := doc[name.(string)]; !ok {
+			errs = append(errs, fmt.Errorf("%s: missing %s", where(path), name))
+		}
+	}
+
+	keys := make([]string, 0, len(doc))
+	for k := range doc {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		switch ap := schema["additionalProperties"].(type) {
+		case map[string]any:
+			if props == nil {
+				errs = append(errs, v.validate(ap, doc[k], p)...)
+				continue
+			}
+		case bool:
+			if _, ok := props[k]; !ok && !ap {
+				errs = append(errs, fmt.Errorf("%s: unexpected property", p))
+				continue
+			}
+		}
+		if s, ok := props[k].(map[string]any); ok {
+			errs = append(errs, v.validate(s, doc[k], p)...)
+		}
+	}
+	return errs
+}
+
+func typeMatches(want any, doc any) bool {
+	switch w := want.(type) {
+	case nil:
+		return true
+	case string:
+		return w == typeOf(doc) || (w == "number" && typeOf(doc) == "integer")
+	case []any:
+		for _, t := range w {
+			if typeMatches(t, doc) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeOf(doc any) string {
+	switch d := doc.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if bytes.ContainsAny([]byte(d), ".eE") {
+			return "number"
+		}
+		return "integer"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", doc)
+}
+
+func where(path string) string {
+	if path == "" {
+		return "document"
+	}
+	return path
+}
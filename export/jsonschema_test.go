@@ -0,0 +1,63 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+var update = flag.Bool("update", false, "rewrite site.schema.json")
+
+func TestJSONSchemaFile(t *testing.T) {
+	if *update {
+		if err := os.WriteFile("site.schema.json", []byte(JSONSchema()), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := os.ReadFile("site.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != JSONSchema() {
+		t.Fatal("site.schema.json is out of date, run go test ./export -update")
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	f, err := os.Open("../parse/testdata/body.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	s, err := parse.Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.Encode(s)
+	enc.Encode(&parse.Site{Domain: "example.org"})
+	enc.Encode(&parse.Site{Domain: "example.org", Keywords: parse.Keywords{{Word: "x", Percent: parse.Percent{Raw: "120%", Value: 120}}}})
+	if err := ValidateJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	bad := `{"Domain": "example.org", "GlobalRank": -1, "Keywords": [{"Word": "x", "Percent": "2%"}], "Extra": true}`
+	err = ValidateJSON(strings.NewReader(bad))
+	if err == nil {
+		t.Fatal("want a validation error")
+	}
+	for _, want := range []string{"GlobalRank: -1 is less than 0", "Keywords[0].Percent: want", "Extra: unexpected property", "missing Meta"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("want %q in %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+// migrations upgrade a decoded record from the version it is indexed by to
+// the next one.
+var migrations = map[int]func(any) any{
+	1: numericPercents,
+}
+
+// Migrate upgrades a JSON record, e.g. a site or a line of the CLI output,
+// stored by an older version to parse.SchemaVersion. Records without a
+// schema_version are assumed to be of version 1.
+func Migrate(record []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(record))
+	dec.UseNumber()
+
+	var m map[string]any
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	v := 1
+	if n, ok := m["schema_version"].(json.Number); ok {
+		i, err := n.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("schema_version: %w", err)
+		}
+		v = int(i)
+	}
+	if v > parse.SchemaVersion {
+		return nil, fmt.Errorf("schema version %d is newer than %d", v, parse.SchemaVersion)
+	}
+	if v == parse.SchemaVersion {
+		return record, nil
+	}
+
+	var r any = m
+	for ; v < parse.SchemaVersion; v++ {
+		r = migrations[v](r)
+	}
+	r.(map[string]any)["schema_version"] = parse.SchemaVersion
+
+	return json.Marshal(r)
+}
+
+// numericPercents replaces {"Raw", "Value"} objects with their values.
+func numericPercents(r any) any {
+	switch v := r.(type) {
+	case map[string]any:
+		if len(v) == 2 && v["Raw"] != nil && v["Value"] != nil {
+			return v["Value"]
+		}
+		for k, e := range v {
+			v[k] = numericPercents(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = numericPercents(e)
+		}
+	}
+	return r
+}
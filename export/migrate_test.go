@@ -0,0 +1,37 @@
+package export
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestMigrate(t *testing.T) {
+	old := `{"domain":"sberbank.ru","site":{"GlobalRank":506,"Keywords":[{"Word":"sber","Percent":{"Raw":"2.0%","Value":2.0}}]}}`
+
+	b, err := Migrate([]byte(old))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rec struct {
+		SchemaVersion int         `json:"schema_version"`
+		Site          *parse.Site `json:"site"`
+	}
+	if err := json.Unmarshal(b, &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.SchemaVersion != parse.SchemaVersion {
+		t.Fatalf("want schema version %d, got %d", parse.SchemaVersion, rec.SchemaVersion)
+	}
+	want := parse.Keywords{{Word: "sber", Percent: parse.Percent{Raw: "2%", Value: 2}}}
+	if !reflect.DeepEqual(rec.Site.Keywords, want) {
+		t.Fatalf("want %v, got %v", want, rec.Site.Keywords)
+	}
+
+	if _, err := Migrate([]byte(`{"schema_version":99}`)); err == nil {
+		t.Fatal("want an error for a newer schema version")
+	}
+}
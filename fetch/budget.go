@@ -0,0 +1,48 @@
+package fetch
+
+import "sync"
+
+// WithRetryBudget caps retries of all requests of the Client to ratio of
+// their number plus min, e.g. 0.1 and 10 allow 10% on top of a few spare
+// ones, so retries do not amplify the load when alexa.com struggles.
+func WithRetryBudget(ratio float64, min int) Option {
+	return func(f *Client) {
+		f.budget = &budget{ratio: ratio, min: min}
+	}
+}
+
+// budget accounts attempts of a Client.
+type budget struct {
+	ratio float64
+	min   int
+
+	mu                sync.Mutex
+	requests, retries int
+}
+
+// request accounts a first attempt.
+func (b *budget) request() {
+	b.mu.Lock()
+	b.requests++
+	b.mu.Unlock()
+}
+
+// retry accounts a repeated attempt if the budget allows it.
+func (b *budget) retry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.ratio >= 0 && float64(b.retries) >= float64(b.min)+b.ratio*float64(b.requests) {
+		return false
+	}
+	b.retries++
+	return true
+}
+
+// Attempts returns how many requests the Client made and how many of them
+// were retried.
+func (f *Client) Attempts() (requests, retries int) {
+	f.budget.mu.Lock()
+	defer f.budget.mu.Unlock()
+	return f.budget.requests, f.budget.retries
+}
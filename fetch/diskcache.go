@@ -0,0 +1,328 @@
+package fetch
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// FromCacheHeader is set on responses served by DiskCache.
+	FromCacheHeader = "X-From-Cache"
+
+	// RetriesHeader is set by Client.Fetch on responses that took retries.
+	RetriesHeader = "X-Asip-Retries"
+
+	storedAtHeader = "X-Asip-Stored-At"
+	varyPrefix     = "X-Asip-Vary-"
+)
+
+var cacheableStatus = map[int]bool{
+	http.StatusOK:                   true,
+	http.StatusNonAuthoritativeInfo: true,
+	http.StatusMovedPermanently:     true,
+	http.StatusNotFound:             true,
+	http.StatusGone:                 true,
+}
+
+// DiskCache is an http.RoundTripper keeping responses on disk following
+// RFC 7234 freshness and validation rules for a private cache, so they are
+// reused across process restarts. The least recently used responses are
+// evicted once the directory grows over its size limit.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+	next     http.RoundTripper
+	now      func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewDiskCache bootstraps a DiskCache in dir limited to maxBytes, zero means
+// no limit. Requests are passed to next, http.DefaultTransport if nil.
+func NewDiskCache(dir string, maxBytes int64, next http.RoundTripper) *DiskCache {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &DiskCache{dir: dir, maxBytes: maxBytes, next: next, now: time.Now}
+}
+
+// WithDiskCache puts a DiskCache in front of the HTTP client transport.
+func WithDiskCache(dir string, maxBytes int64) Option {
+	return func(f *Client) {
+		f.wrappers = append(f.wrappers, func(rt http.RoundTripper) http.RoundTripper {
+			return NewDiskCache(dir, maxBytes, rt)
+		})
+	}
+}
+
+// RoundTrip serves fresh stored responses, revalidates stale ones and stores
+// cacheable new ones.
+func (c *DiskCache) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqCC := cacheControl(req.Header)
+	if req.Method != http.MethodGet || reqCC.has("no-store") {
+		return c.next.RoundTrip(req)
+	}
+
+	key := c.key(req.URL.String())
+	stored, err := c.load(key, req)
+	if err != nil || stored == nil {
+		return c.fetch(key, req)
+	}
+
+	if !reqCC.has("no-cache") && c.fresh(stored) {
+		c.touch(key)
+		stored.Header.Set(FromCacheHeader, "1")
+		return stored, nil
+	}
+
+	etag, lastModified := stored.Header.Get("ETag"), stored.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		stored.Body.Close()
+		return c.fetch(key, req)
+	}
+
+	creq := req.Clone(req.Context())
+	if etag != "" {
+		creq.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		creq.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := c.next.RoundTrip(creq)
+	if err != nil {
+		stored.Body.Close()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusNotModified {
+		stored.Body.Close()
+		return c.store(key, req, resp)
+	}
+	resp.Body.Close()
+
+	for k, v := range resp.Header {
+		stored.Header[k] = v
+	}
+	stored, err = c.store(key, req, stored)
+	if err != nil {
+		return nil, err
+	}
+	stored.Header.Set(FromCacheHeader, "1")
+	return stored, nil
+}
+
+func (c *DiskCache) fetch(key string, req *http.Request) (*http.Response, error) {
+	resp, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.store(key, req, resp)
+}
+
+// store writes resp to disk if it may be cached and returns a response with
+// the body still readable.
+func (c *DiskCache) store(key string, req *http.Request, resp *http.Response) (*http.Response, error) {
+	cc := cacheControl(resp.Header)
+	if !cacheableStatus[resp.StatusCode] || cc.has("no-store") {
+		return resp, nil
+	}
+
+	resp.Header.Set(storedAtHeader, c.now().UTC().Format(http.TimeFormat))
+	for _, h := range strings.Split(resp.Header.Get("Vary"), ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			resp.Header.Set(varyPrefix+h, req.Header.Get(h))
+		}
+	}
+
+	b, err := httputil.DumpResponse(resp, true)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	err = c.write(key, b)
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), req)
+}
+
+func (c *DiskCache) write(key string, b []byte) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), filepath.Join(c.dir, key)); err != nil {
+		return err
+	}
+
+	return c.evict()
+}
+
+// evict removes least recently used responses until the limit is met.
+func (c *DiskCache) evict() error {
+	if c.maxBytes <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	var (
+		infos []os.FileInfo
+		total int64
+	)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			continue
+		}
+		infos = append(infos, fi)
+		total += fi.Size()
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().Before(infos[j].ModTime())
+	})
+	for _, fi := range infos {
+		if total <= c.maxBytes {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, fi.Name())); err != nil {
+			return err
+		}
+		total -= fi.Size()
+	}
+
+	return nil
+}
+
+func (c *DiskCache) load(key string, req *http.Request) (*http.Response, error) {
+	b, err := os.ReadFile(filepath.Join(c.dir, key))
+	if err != nil {
+		return nil, nil
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(b)), req)
+	if err != nil {
+		return nil, err
+	}
+
+	for k := range resp.Header {
+		if h := strings.TrimPrefix(k, varyPrefix); h != k && resp.Header.Get(k) != req.Header.Get(h) {
+			resp.Body.Close()
+			return nil, nil
+		}
+	}
+
+	return resp, nil
+}
+
+// touch marks the response as recently used.
+func (c *DiskCache) touch(key string) {
+	now := c.now()
+	os.Chtimes(filepath.Join(c.dir, key), now, now)
+}
+
+// fresh reports whether the age of resp is within its freshness lifetime.
+func (c *DiskCache) fresh(resp *http.Response) bool {
+	storedAt, err := http.ParseTime(resp.Header.Get(storedAtHeader))
+	if err != nil {
+		return false
+	}
+
+	age := c.now().Sub(storedAt)
+	if a, err := strconv.Atoi(resp.Header.Get("Age")); err == nil {
+		age += time.Duration(a) * time.Second
+	}
+
+	return age < lifetime(resp.Header)
+}
+
+func lifetime(h http.Header) time.Duration {
+	cc := cacheControl(h)
+	if cc.has("no-cache") {
+		return 0
+	}
+	if v, ok := cc["max-age"]; ok {
+		s, err := strconv.Atoi(v)
+		if err != nil {
+			return 0
+		}
+		return time.Duration(s) * time.Second
+	}
+
+	date, err := http.ParseTime(h.Get("Date"))
+	if err != nil {
+		return 0
+	}
+	if exp := h.Get("Expires"); exp != "" {
+		t, err := http.ParseTime(exp)
+		if err != nil {
+			return 0
+		}
+		return t.Sub(date)
+	}
+	if lm, err := http.ParseTime(h.Get("Last-Modified")); err == nil {
+		return date.Sub(lm) / 10 // heuristic freshness
+	}
+
+	return 0
+}
+
+type directives map[string]string
+
+func (d directives) has(name string) bool {
+	_, ok := d[name]
+	return ok
+}
+
+func cacheControl(h http.Header) directives {
+	d := make(directives)
+	for _, part := range strings.Split(h.Get("Cache-Control"), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(part, "=")
+		d[strings.ToLower(strings.TrimSpace(k))] = strings.Trim(strings.TrimSpace(v), `"`)
+	}
+	return d
+}
+
+func (c *DiskCache) key(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,129 @@
+package fetch
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, c *http.Client, url string) (string, bool) {
+	t.Helper()
+
+	resp, err := c.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b), resp.Header.Get(FromCacheHeader) == "1"
+}
+
+func TestDiskCacheMaxAge(t *testing.T) {
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte("page"))
+	}))
+	defer ts.Close()
+
+	dc := NewDiskCache(t.TempDir(), 0, nil)
+	c := &http.Client{Transport: dc}
+
+	if body, cached := get(t, c, ts.URL); body != "page" || cached {
+		t.Fatalf("want fetched page, got %q, cached %t", body, cached)
+	}
+	if body, cached := get(t, c, ts.URL); body != "page" || !cached {
+		t.Fatalf("want cached page, got %q, cached %t", body, cached)
+	}
+
+	// survives a restart
+	c = &http.Client{Transport: NewDiskCache(dc.dir, 0, nil)}
+	if _, cached := get(t, c, ts.URL); !cached {
+		t.Fatal("want cached page after restart")
+	}
+
+	dc.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	c = &http.Client{Transport: dc}
+	if _, cached := get(t, c, ts.URL); cached {
+		t.Fatal("want expired page refetched")
+	}
+	if hits != 2 {
+		t.Fatalf("want 2 requests, got %d", hits)
+	}
+}
+
+func TestDiskCacheRevalidate(t *testing.T) {
+	var hits, notModified int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Write([]byte("page"))
+	}))
+	defer ts.Close()
+
+	c := &http.Client{Transport: NewDiskCache(t.TempDir(), 0, nil)}
+	get(t, c, ts.URL)
+	if body, cached := get(t, c, ts.URL); body != "page" || !cached {
+		t.Fatalf("want revalidated page, got %q, cached %t", body, cached)
+	}
+	if hits != 2 || notModified != 1 {
+		t.Fatalf("want a conditional request, got %d requests and %d not modified", hits, notModified)
+	}
+}
+
+func TestDiskCacheNoStore(t *testing.T) {
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Cache-Control", "no-store, max-age=60")
+		w.Write([]byte("page"))
+	}))
+	defer ts.Close()
+
+	c := &http.Client{Transport: NewDiskCache(t.TempDir(), 0, nil)}
+	get(t, c, ts.URL)
+	get(t, c, ts.URL)
+	if hits != 2 {
+		t.Fatalf("want 2 requests, got %d", hits)
+	}
+}
+
+func TestDiskCacheEviction(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write(make([]byte, 1000))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	c := &http.Client{Transport: NewDiskCache(dir, 2500, nil)}
+	for _, p := range []string{"/a", "/b", "/c"} {
+		get(t, c, ts.URL+p)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("want 2 responses kept, got %d", len(entries))
+	}
+	if _, cached := get(t, c, ts.URL+"/a"); cached {
+		t.Fatal("want the oldest response evicted")
+	}
+}
@@ -0,0 +1,214 @@
+// Package fetch downloads Alexa Website Info pages.
+package fetch
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Client fetches pages over HTTP, retrying transient failures.
+type Client struct {
+	client   *http.Client
+	proxy    *url.URL
+	resolver *net.Resolver
+	tuning   *Transport
+	limiter  *Limiter
+	wrappers []func(http.RoundTripper) http.RoundTripper
+	retries  int
+	backoff  time.Duration
+	budget   *budget
+	pacer    *pacer
+
+	requestHooks  []RequestHook
+	responseHooks []ResponseHook
+}
+
+// Option customizes a Client.
+type Option func(*Client)
+
+// WithClient sets an underlying HTTP client.
+func WithClient(c *http.Client) Option {
+	return func(f *Client) {
+		f.client = c
+	}
+}
+
+// WithProxy routes requests through a proxy.
+func WithProxy(u *url.URL) Option {
+	return func(f *Client) {
+		f.proxy = u
+	}
+}
+
+// WithResolver pins name resolution to r, e.g. one dialing specific DNS
+// servers in restricted environments.
+func WithResolver(r *net.Resolver) Option {
+	return func(f *Client) {
+		f.resolver = r
+	}
+}
+
+// Transport tunes the connection pool, zero fields keep the defaults.
+type Transport struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	MaxConnsPerHost     int
+	IdleConnTimeout     time.Duration
+	ForceHTTP1          bool // never negotiate HTTP/2
+	ForceHTTP2          bool // attempt HTTP/2 even with a custom dialer
+}
+
+// WithTransport tunes the connection pool, e.g. to keep more idle
+// connections for large concurrent batches instead of exhausting ports.
+func WithTransport(t Transport) Option {
+	return func(f *Client) {
+		f.tuning = &t
+	}
+}
+
+func (tt *Transport) apply(t *http.Transport) {
+	if tt.MaxIdleConns != 0 {
+		t.MaxIdleConns = tt.MaxIdleConns
+	}
+	if tt.MaxIdleConnsPerHost != 0 {
+		t.MaxIdleConnsPerHost = tt.MaxIdleConnsPerHost
+	}
+	if tt.MaxConnsPerHost != 0 {
+		t.MaxConnsPerHost = tt.MaxConnsPerHost
+	}
+	if tt.IdleConnTimeout != 0 {
+		t.IdleConnTimeout = tt.IdleConnTimeout
+	}
+	switch {
+	case tt.ForceHTTP1:
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	case tt.ForceHTTP2:
+		t.ForceAttemptHTTP2 = true
+	}
+}
+
+// WithRetries sets how many times a failed request is repeated and a pause
+// before the first repeat, doubled for every next one.
+func WithRetries(n int, backoff time.Duration) Option {
+	return func(f *Client) {
+		f.retries = n
+		f.backoff = backoff
+	}
+}
+
+// New bootstraps a Client.
+func New(opts ...Option) *Client {
+	f := &Client{client: http.DefaultClient, budget: &budget{ratio: -1}}
+	for _, o := range opts {
+		o(f)
+	}
+
+	if f.proxy != nil {
+		f.client = tuned(f.client, func(t *http.Transport) {
+			t.Proxy = http.ProxyURL(f.proxy)
+		})
+	}
+	if f.resolver != nil {
+		d := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Resolver: f.resolver}
+		f.client = tuned(f.client, func(t *http.Transport) {
+			t.DialContext = d.DialContext
+		})
+	}
+	if f.tuning != nil {
+		f.client = tuned(f.client, f.tuning.apply)
+	}
+	if f.limiter != nil {
+		f.client = f.limiter.Client(f.client)
+	}
+	if len(f.wrappers) > 0 {
+		f.client = wrapped(f.client, f.wrappers)
+	}
+
+	return f
+}
+
+func wrapped(c *http.Client, wrappers []func(http.RoundTripper) http.RoundTripper) *http.Client {
+	rt := c.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	for _, w := range wrappers {
+		rt = w(rt)
+	}
+
+	wc := *c
+	wc.Transport = rt
+	return &wc
+}
+
+// tuned returns a copy of c with its transport modified by fn, transports
+// other than *http.Transport are left as is.
+func tuned(c *http.Client, fn func(*http.Transport)) *http.Client {
+	var t *http.Transport
+	switch rt := c.Transport.(type) {
+	case nil:
+		t = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		t = rt.Clone()
+	default:
+		return c
+	}
+	fn(t)
+
+	tc := *c
+	tc.Transport = t
+	return &tc
+}
+
+// Fetch requests url and returns the response as soon as it is not a
+// transient failure or retries or the retry budget are exhausted.
+func (f *Client) Fetch(ctx context.Context, url string) (*http.Response, error) {
+	f.budget.request()
+
+	backoff := f.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := f.do(ctx, url)
+		if attempt == f.retries || !transient(resp, err) || !f.budget.retry() {
+			if resp != nil && attempt > 0 {
+				resp.Header.Set(RetriesHeader, strconv.Itoa(attempt))
+			}
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (f *Client) do(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if f.pacer != nil {
+		if err := f.pacer.wait(ctx, req.URL.Host); err != nil {
+			return nil, err
+		}
+	}
+	return f.send(req)
+}
+
+func transient(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
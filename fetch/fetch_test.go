@@ -0,0 +1,146 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestFetchRetries(t *testing.T) {
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	resp, err := New(WithRetries(2, 0)).Fetch(context.Background(), ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("want status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if hits != 3 {
+		t.Fatalf("want 3 requests, got %d", hits)
+	}
+	if m := Meta(resp, "alexa.com"); m.Retries != 2 {
+		t.Fatalf("want 2 retries in meta, got %d", m.Retries)
+	}
+}
+
+func TestFetchNoRetries(t *testing.T) {
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	resp, err := New().Fetch(context.Background(), ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if hits != 1 {
+		t.Fatalf("want 1 request, got %d", hits)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := New(WithRetries(3, 0), WithRetryBudget(0.5, 1))
+	for i := 0; i < 4; i++ {
+		resp, err := c.Fetch(context.Background(), ts.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	// 4 requests allow 1 + 0.5 * 4 = 3 retries in total
+	if hits != 7 {
+		t.Fatalf("want 7 hits, got %d", hits)
+	}
+	if requests, retries := c.Attempts(); requests != 4 || retries != 3 {
+		t.Fatalf("want 4 requests and 3 retries, got %d and %d", requests, retries)
+	}
+}
+
+func TestProxy(t *testing.T) {
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	u, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := New(WithProxy(u)).Fetch(context.Background(), "http://www.alexa.com/siteinfo/example.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if proxiedURL != "http://www.alexa.com/siteinfo/example.org" {
+		t.Fatalf("request did not go through the proxy: %q", proxiedURL)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	var dialed string
+	r := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialed = address
+			return nil, errors.New("refused")
+		},
+	}
+
+	_, err := New(WithResolver(r)).Fetch(context.Background(), "http://alexa.invalid/siteinfo/example.org")
+	if err == nil {
+		t.Fatal("want an error resolving through the refusing resolver")
+	}
+	if dialed == "" {
+		t.Fatal("the resolver was not used")
+	}
+}
+
+func TestTransport(t *testing.T) {
+	f := New(WithTransport(Transport{MaxIdleConnsPerHost: 64, IdleConnTimeout: time.Minute, ForceHTTP1: true}))
+
+	tr, ok := f.client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("want *http.Transport, got %T", f.client.Transport)
+	}
+	if tr.MaxIdleConnsPerHost != 64 || tr.IdleConnTimeout != time.Minute {
+		t.Fatalf("pool is not tuned: %d, %s", tr.MaxIdleConnsPerHost, tr.IdleConnTimeout)
+	}
+	if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Fatal("HTTP/2 is not disabled")
+	}
+	if http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost == 64 {
+		t.Fatal("the default transport was modified")
+	}
+}
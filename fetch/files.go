@@ -0,0 +1,147 @@
+package fetch
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+// FileFetcher replays pages saved as files named after domains like
+// example.org.html, for offline and reproducible processing.
+type FileFetcher struct {
+	open func(name string) (io.ReadCloser, error)
+}
+
+// NewFileFetcher serves pages from fsys, e.g. os.DirFS(dir).
+func NewFileFetcher(fsys fs.FS) *FileFetcher {
+	return &FileFetcher{open: func(name string) (io.ReadCloser, error) {
+		return fsys.Open(name)
+	}}
+}
+
+// OpenFileFetcher serves pages from a directory, a .zip or a .tar(.gz)
+// archive at path.
+func OpenFileFetcher(path string) (*FileFetcher, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case fi.IsDir():
+		return NewFileFetcher(os.DirFS(path)), nil
+	case strings.HasSuffix(path, ".zip"):
+		z, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+		return NewFileFetcher(z), nil
+	case strings.HasSuffix(path, ".tar"), strings.HasSuffix(path, ".tar.gz"), strings.HasSuffix(path, ".tgz"):
+		return openTar(path)
+	}
+	return nil, errors.New("fetch: " + path + " is neither a directory nor an archive")
+}
+
+// openTar reads a tar archive into memory as tar has no index to seek by.
+func openTar(name string) (*FileFetcher, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if !strings.HasSuffix(name, ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Clean(h.Name)] = b
+	}
+
+	return &FileFetcher{open: func(name string) (io.ReadCloser, error) {
+		b, ok := files[name]
+		if !ok {
+			return nil, fs.ErrNotExist
+		}
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}}, nil
+}
+
+// Fetch returns the page of the domain in a siteinfo URL, or a 404 response
+// if there is none.
+func (f *FileFetcher) Fetch(ctx context.Context, rawURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &http.Response{
+		Status:     "404 Not Found",
+		StatusCode: http.StatusNotFound,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}
+
+	domain, err := siteinfoDomain(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	body, err := f.open(domain + ".html")
+	if errors.Is(err, fs.ErrNotExist) {
+		return resp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Status = "200 OK"
+	resp.StatusCode = http.StatusOK
+	resp.Header.Set("Content-Type", "text/html; charset=utf-8")
+	resp.Body = body
+	return resp, nil
+}
+
+func siteinfoDomain(u *url.URL) (string, error) {
+	i := strings.LastIndex(u.Path, "/siteinfo/")
+	if i < 0 {
+		return "", errors.New("fetch: no domain in " + u.String())
+	}
+	d := u.Path[i+len("/siteinfo/"):]
+	if d == "" || strings.Contains(d, "/") {
+		return "", errors.New("fetch: no domain in " + u.String())
+	}
+	return d, nil
+}
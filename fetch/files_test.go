@@ -0,0 +1,54 @@
+package fetch
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileFetcher(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "example.org.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "pages.tar")
+	af, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(af)
+	tw.WriteHeader(&tar.Header{Name: "example.org.html", Mode: 0644, Size: 13, Typeflag: tar.TypeReg})
+	tw.Write([]byte("<html></html>"))
+	tw.Close()
+	af.Close()
+
+	for _, path := range []string{dir, archive} {
+		f, err := OpenFileFetcher(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := f.Fetch(context.Background(), "https://www.alexa.com/siteinfo/example.org?ver=classic")
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(b) != "<html></html>" {
+			t.Fatalf("%s: unexpected response %d %q", path, resp.StatusCode, b)
+		}
+
+		resp, err = f.Fetch(context.Background(), "https://www.alexa.com/siteinfo/example.com?ver=classic")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("%s: want 404 for a missing page, got %d", path, resp.StatusCode)
+		}
+	}
+}
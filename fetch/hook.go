@@ -0,0 +1,48 @@
+package fetch
+
+import "net/http"
+
+// RequestHook is called with every request before it is sent, e.g. to log
+// it or set headers. An error aborts the attempt.
+type RequestHook func(req *http.Request) error
+
+// ResponseHook is called with every response before it is returned or
+// retried, e.g. to record it or detect a block page. A hook reading the
+// body must replace it. An error fails the attempt, retried like a network
+// error.
+type ResponseHook func(resp *http.Response) error
+
+// WithRequestHook calls h with every request, after hooks added earlier.
+func WithRequestHook(h RequestHook) Option {
+	return func(f *Client) {
+		f.requestHooks = append(f.requestHooks, h)
+	}
+}
+
+// WithResponseHook calls h with every response, after hooks added earlier.
+func WithResponseHook(h ResponseHook) Option {
+	return func(f *Client) {
+		f.responseHooks = append(f.responseHooks, h)
+	}
+}
+
+// send sends req running the hooks around it.
+func (f *Client) send(req *http.Request) (*http.Response, error) {
+	for _, h := range f.requestHooks {
+		if err := h(req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range f.responseHooks {
+		if err := h(resp); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+	}
+	return resp, nil
+}
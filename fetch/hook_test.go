@@ -0,0 +1,63 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHooks(t *testing.T) {
+	var (
+		hits   int
+		header string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		header = r.Header.Get("X-Test")
+		if hits < 2 {
+			w.Write([]byte("captcha"))
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	var statuses []int
+	blocked := errors.New("blocked")
+	c := New(
+		WithRetries(1, 0),
+		WithRequestHook(func(req *http.Request) error {
+			req.Header.Set("X-Test", "1")
+			return nil
+		}),
+		WithResponseHook(func(resp *http.Response) error {
+			statuses = append(statuses, resp.StatusCode)
+			return nil
+		}),
+		WithResponseHook(func(resp *http.Response) error {
+			if hits < 2 {
+				return blocked
+			}
+			return nil
+		}),
+	)
+
+	resp, err := c.Fetch(context.Background(), ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if hits != 2 || len(statuses) != 2 {
+		t.Fatalf("want a block page retried, got %d requests and %v", hits, statuses)
+	}
+	if header != "1" {
+		t.Fatalf("want the header set by the hook, got %q", header)
+	}
+
+	hits = 0
+	if _, err := New(WithResponseHook(func(resp *http.Response) error { return blocked })).Fetch(context.Background(), ts.URL); !errors.Is(err, blocked) {
+		t.Fatalf("want %v, got %v", blocked, err)
+	}
+}
@@ -0,0 +1,146 @@
+package fetch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Limits caps concurrent requests, zero fields mean no limit.
+type Limits struct {
+	Global  int            `json:"global,omitempty"`   // to all hosts together
+	PerHost int            `json:"per_host,omitempty"` // to every host
+	Hosts   map[string]int `json:"hosts,omitempty"`    // to particular hosts overriding PerHost
+}
+
+// Limiter enforces Limits on HTTP clients sharing it, e.g. the one of
+// alexa.com lookups and the ones of enrichers, so a misconfigured batch
+// does not overload anything. A request holds its slot until its response
+// body is closed.
+type Limiter struct {
+	mu     sync.Mutex
+	limits Limits
+	global chan struct{}
+	hosts  map[string]chan struct{}
+}
+
+// NewLimiter bootstraps a Limiter.
+func NewLimiter(l Limits) *Limiter {
+	lim := &Limiter{}
+	lim.SetLimits(l)
+	return lim
+}
+
+// SetLimits replaces the limits, requests in flight keep their slots until
+// done but don't count against the new limits.
+func (l *Limiter) SetLimits(lim Limits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limits = lim
+	l.global = nil
+	if lim.Global > 0 {
+		l.global = make(chan struct{}, lim.Global)
+	}
+	l.hosts = make(map[string]chan struct{})
+}
+
+// WithLimiter caps concurrent requests of the Client with l.
+func WithLimiter(l *Limiter) Option {
+	return func(f *Client) {
+		f.limiter = l
+	}
+}
+
+// Client returns a copy of c limited by l, c may be nil to use the default
+// HTTP client.
+func (l *Limiter) Client(c *http.Client) *http.Client {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return wrapped(c, []func(http.RoundTripper) http.RoundTripper{l.Wrap})
+}
+
+// Wrap limits requests made through rt.
+func (l *Limiter) Wrap(rt http.RoundTripper) http.RoundTripper {
+	return &limited{l, rt}
+}
+
+// sems returns the global semaphore and the one of the host, nil if they
+// are not limited.
+func (l *Limiter) sems(h string) (global, host chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n, ok := l.limits.Hosts[h]
+	if !ok {
+		n = l.limits.PerHost
+	}
+	if n <= 0 {
+		return l.global, nil
+	}
+
+	sem, ok := l.hosts[h]
+	if !ok {
+		sem = make(chan struct{}, n)
+		l.hosts[h] = sem
+	}
+	return l.global, sem
+}
+
+// acquire takes slots of sems in order, returning a func giving them back.
+func acquire(ctx context.Context, sems ...chan struct{}) (func(), error) {
+	var taken []chan struct{}
+	release := func() {
+		for _, sem := range taken {
+			<-sem
+		}
+	}
+	for _, sem := range sems {
+		if sem == nil {
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+			taken = append(taken, sem)
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+type limited struct {
+	l    *Limiter
+	next http.RoundTripper
+}
+
+func (lt *limited) RoundTrip(req *http.Request) (*http.Response, error) {
+	global, host := lt.l.sems(req.URL.Hostname())
+	release, err := acquire(req.Context(), global, host)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lt.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releasingBody gives slots back once closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
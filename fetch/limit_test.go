@@ -0,0 +1,83 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	var inflight, peak atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	c := New(WithLimiter(NewLimiter(Limits{Global: 5, Hosts: map[string]int{"127.0.0.1": 2}})))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Fetch(context.Background(), ts.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p != 2 {
+		t.Fatalf("want at most 2 concurrent requests, got %d", p)
+	}
+}
+
+func TestLimiterContext(t *testing.T) {
+	l := NewLimiter(Limits{Global: 1})
+	release, err := acquire(context.Background(), l.global)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://127.0.0.1:1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Client(nil).Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestLimiterSetLimits(t *testing.T) {
+	l := NewLimiter(Limits{Global: 1})
+	release, err := acquire(context.Background(), l.global)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	l.SetLimits(Limits{PerHost: 3})
+	global, host := l.sems("example.org")
+	if global != nil || cap(host) != 3 {
+		t.Fatalf("want only 3 requests per host limited, got global %v and host of %d", global, cap(host))
+	}
+}
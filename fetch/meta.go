@@ -0,0 +1,52 @@
+package fetch
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+// Meta describes resp as received from the provider for Site.Meta, the
+// redirect chain is recovered from resp.Request and the headers to capture
+// are recorded only if present. Set-Cookie is recorded as present rather
+// than by its value.
+func Meta(resp *http.Response, provider string, capture ...string) parse.Meta {
+	m := parse.Meta{
+		FetchedAt:  time.Now().UTC(),
+		HTTPStatus: resp.StatusCode,
+		FromCache:  resp.Header.Get(FromCacheHeader) == "1",
+		Provider:   provider,
+	}
+	m.Retries, _ = strconv.Atoi(resp.Header.Get(RetriesHeader))
+	if resp.Request != nil {
+		m.FinalURL = resp.Request.URL.String()
+		m.SourceURL = m.FinalURL
+		for req := resp.Request; req.Response != nil && req.Response.Request != nil; {
+			req = req.Response.Request
+			m.SourceURL = req.URL.String()
+			m.Redirects = append([]string{m.SourceURL}, m.Redirects...)
+		}
+	}
+
+	for _, name := range capture {
+		vs := resp.Header.Values(name)
+		if len(vs) == 0 {
+			continue
+		}
+		if m.Headers == nil {
+			m.Headers = make(map[string]string)
+		}
+
+		name = http.CanonicalHeaderKey(name)
+		if name == "Set-Cookie" {
+			m.Headers[name] = "present"
+		} else {
+			m.Headers[name] = strings.Join(vs, ", ")
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,63 @@
+package fetch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestMetaHeaders(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Server":     {"AmazonS3"},
+			"X-Cache":    {"Hit from cloudfront"},
+			"Set-Cookie": {"session=secret", "lang=en"},
+		},
+	}
+
+	m := Meta(resp, "alexa.com", "x-cache", "Set-Cookie", "Via", "server")
+
+	want := map[string]string{
+		"X-Cache":    "Hit from cloudfront",
+		"Set-Cookie": "present",
+		"Server":     "AmazonS3",
+	}
+	if !reflect.DeepEqual(m.Headers, want) {
+		t.Fatalf("want %v, got %v", want, m.Headers)
+	}
+	if m.HTTPStatus != http.StatusOK || m.Provider != "alexa.com" {
+		t.Fatalf("unexpected meta %v", m)
+	}
+}
+
+func TestMetaRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/siteinfo/www.example.com":
+			http.Redirect(w, r, "/siteinfo/example.com", http.StatusMovedPermanently)
+		case "/siteinfo/example.com":
+			http.Redirect(w, r, "/siteinfo/example.org", http.StatusFound)
+		}
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/siteinfo/www.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	m := Meta(resp, "alexa.com")
+	if m.SourceURL != srv.URL+"/siteinfo/www.example.com" {
+		t.Fatalf("unexpected source url %s", m.SourceURL)
+	}
+	if m.FinalURL != srv.URL+"/siteinfo/example.org" {
+		t.Fatalf("unexpected final url %s", m.FinalURL)
+	}
+	want := []string{srv.URL + "/siteinfo/www.example.com", srv.URL + "/siteinfo/example.com"}
+	if !reflect.DeepEqual(m.Redirects, want) {
+		t.Fatalf("want %v, got %v", want, m.Redirects)
+	}
+}
@@ -0,0 +1,53 @@
+package fetch
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// WithPoliteness pauses a random time between min and max between
+// consecutive requests to the same host, mimicking human pacing in long
+// crawls.
+func WithPoliteness(min, max time.Duration) Option {
+	return func(f *Client) {
+		f.pacer = &pacer{min: min, max: max, next: make(map[string]time.Time)}
+	}
+}
+
+// pacer spaces requests to every host.
+type pacer struct {
+	min, max time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func (p *pacer) delay() time.Duration {
+	if p.max <= p.min {
+		return p.min
+	}
+	return p.min + rand.N(p.max-p.min)
+}
+
+// wait blocks until a request to host is due, reserving a slot for it.
+func (p *pacer) wait(ctx context.Context, host string) error {
+	p.mu.Lock()
+	now := time.Now()
+	at := p.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	p.next[host] = at.Add(p.delay())
+	p.mu.Unlock()
+
+	t := time.NewTimer(at.Sub(now))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
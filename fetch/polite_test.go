@@ -0,0 +1,41 @@
+package fetch
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPacer(t *testing.T) {
+	p := &pacer{min: 20 * time.Millisecond, max: 40 * time.Millisecond, next: make(map[string]time.Time)}
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 3 {
+		if err := p.wait(ctx, "www.alexa.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Fatalf("want at least two pauses, took %s", d)
+	}
+
+	start = time.Now()
+	if err := p.wait(ctx, "web.archive.org"); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 10*time.Millisecond {
+		t.Fatalf("want no pause for another host, took %s", d)
+	}
+}
+
+func TestPacerCanceled(t *testing.T) {
+	p := &pacer{min: time.Hour, max: time.Hour, next: make(map[string]time.Time)}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p.wait(ctx, "www.alexa.com")
+	if err := p.wait(ctx, "www.alexa.com"); err != context.Canceled {
+		t.Fatalf("want %v, got %v", context.Canceled, err)
+	}
+}
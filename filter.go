@@ -0,0 +1,43 @@
+package asip
+
+// Filter is a predicate over looked up sites.
+type Filter func(*Site) bool
+
+// MaxGlobalRank keeps ranked sites with global rank of n or better.
+func MaxGlobalRank(n uint) Filter {
+	return func(s *Site) bool {
+		return s.GlobalRank != 0 && s.GlobalRank <= n
+	}
+}
+
+// MinLinkingTotal keeps sites with at least n sites linking in.
+func MinLinkingTotal(n uint) Filter {
+	return func(s *Site) bool {
+		return s.LinkingTotal >= n
+	}
+}
+
+// And keeps sites matching every filter.
+func And(fs ...Filter) Filter {
+	return func(s *Site) bool {
+		for _, f := range fs {
+			if !f(s) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Select returns sites matching every filter.
+func Select(sites []*Site, fs ...Filter) []*Site {
+	match := And(fs...)
+
+	var ss []*Site
+	for _, s := range sites {
+		if s != nil && match(s) {
+			ss = append(ss, s)
+		}
+	}
+	return ss
+}
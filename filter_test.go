@@ -0,0 +1,21 @@
+package asip
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	a := &Site{Domain: "a.example", GlobalRank: 100, LinkingTotal: 10}
+	b := &Site{Domain: "b.example", GlobalRank: 5000, LinkingTotal: 1000}
+	c := &Site{Domain: "c.example", LinkingTotal: 1000}
+	d := &Site{Domain: "d.example", GlobalRank: 50, LinkingTotal: 2000}
+	sites := []*Site{a, b, nil, c, d}
+
+	if got, want := Select(sites, MaxGlobalRank(1000)), []*Site{a, d}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	if got, want := Select(sites, MaxGlobalRank(10000), MinLinkingTotal(1000)), []*Site{b, d}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
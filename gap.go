@@ -0,0 +1,35 @@
+package asip
+
+import "github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+
+// Gap holds keywords only one of two sites gets search traffic for, along
+// with their shares.
+type Gap struct {
+	OnlyA Keywords
+	OnlyB Keywords
+}
+
+// KeywordGap compares top keywords of a and b, words are matched after
+// normalization.
+func KeywordGap(a, b *Site) Gap {
+	return Gap{
+		OnlyA: missingKeywords(a.Keywords, b.Keywords),
+		OnlyB: missingKeywords(b.Keywords, a.Keywords),
+	}
+}
+
+// missingKeywords returns keywords of ks absent from other.
+func missingKeywords(ks, other Keywords) Keywords {
+	seen := make(map[string]bool, len(other))
+	for _, k := range other {
+		seen[parse.NormalizeKeyword(k.Word)] = true
+	}
+
+	var m Keywords
+	for _, k := range ks {
+		if !seen[parse.NormalizeKeyword(k.Word)] {
+			m = append(m, k)
+		}
+	}
+	return m
+}
@@ -0,0 +1,25 @@
+package asip
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeywordGap(t *testing.T) {
+	a := &Site{Keywords: Keywords{
+		{Word: "sberbank", Percent: Percent{Raw: "2.65%", Value: 2.65}},
+		{Word: "сбербанк онлайн", Percent: Percent{Raw: "49.69%", Value: 49.69}},
+	}}
+	b := &Site{Keywords: Keywords{
+		{Word: "Sberbank ", Percent: Percent{Raw: "10%", Value: 10}},
+		{Word: "tinkoff", Percent: Percent{Raw: "30%", Value: 30}},
+	}}
+
+	want := Gap{
+		OnlyA: Keywords{{Word: "сбербанк онлайн", Percent: Percent{Raw: "49.69%", Value: 49.69}}},
+		OnlyB: Keywords{{Word: "tinkoff", Percent: Percent{Raw: "30%", Value: 30}}},
+	}
+	if got := KeywordGap(a, b); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
@@ -1,5 +1,11 @@
-module github.com/ilyaglow/alexa-siteinfo-parser
+module github.com/ilyaglow/alexa-siteinfo-parser/v2
 
-go 1.12
+go 1.23
 
-require github.com/PuerkitoBio/goquery v1.5.0
+require (
+	github.com/PuerkitoBio/goquery v1.5.0
+	github.com/andybalholm/cascadia v1.0.0
+	golang.org/x/net v0.0.0-20181114220301-adae6a3d119a
+	golang.org/x/text v0.14.0
+	google.golang.org/protobuf v1.36.10
+)
@@ -0,0 +1,127 @@
+package history
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+const (
+	chartWidth  = 120
+	chartHeight = 30
+	chartMargin = 2
+)
+
+var chartColor = color.RGBA{0x1f, 0x77, 0xb4, 0xff}
+
+// SVG renders global ranks of the points as a sparkline, better ranks are
+// drawn higher and unranked points are left out.
+func SVG(w io.Writer, ps []Point) error {
+	var coords []string
+	for _, p := range chartPoints(ps) {
+		coords = append(coords, fmt.Sprintf("%d,%d", p.X, p.Y))
+	}
+
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %[1]d %[2]d">`+
+		`<polyline fill="none" stroke="#1f77b4" stroke-width="1.5" points="%s"/></svg>`,
+		chartWidth, chartHeight, strings.Join(coords, " "))
+	return err
+}
+
+// PNG is like SVG but renders a PNG image.
+func PNG(w io.Writer, ps []Point) error {
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	cs := chartPoints(ps)
+	for i := range cs {
+		if i == 0 {
+			img.Set(cs[i].X, cs[i].Y, chartColor)
+			continue
+		}
+		line(img, cs[i-1], cs[i])
+	}
+	return png.Encode(w, img)
+}
+
+// DataURI renders an SVG sparkline as a data URI to embed into HTML or
+// Markdown as an image.
+func DataURI(ps []Point) string {
+	var buf bytes.Buffer
+	SVG(&buf, ps)
+	return "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// chartPoints scales ranked points into the chart by time and rank.
+func chartPoints(ps []Point) []image.Point {
+	var ranked []Point
+	for _, p := range ps {
+		if p.GlobalRank != 0 {
+			ranked = append(ranked, p)
+		}
+	}
+	if len(ranked) == 0 {
+		return nil
+	}
+
+	first, last := ranked[0].Time, ranked[len(ranked)-1].Time
+	best, worst := ranked[0].GlobalRank, ranked[0].GlobalRank
+	for _, p := range ranked {
+		best, worst = min(best, p.GlobalRank), max(worst, p.GlobalRank)
+	}
+
+	w, h := chartWidth-1-2*chartMargin, chartHeight-1-2*chartMargin
+	cs := make([]image.Point, len(ranked))
+	for i, p := range ranked {
+		x, y := 0, h/2
+		if span := last.Sub(first); span > 0 {
+			x = int(int64(w) * int64(p.Time.Sub(first)) / int64(span))
+		}
+		if worst > best {
+			y = int(uint(h) * (p.GlobalRank - best) / (worst - best))
+		}
+		cs[i] = image.Pt(chartMargin+x, chartMargin+y)
+	}
+	return cs
+}
+
+// line draws a line from a to b with Bresenham's algorithm.
+func line(img *image.RGBA, a, b image.Point) {
+	dx, dy := abs(b.X-a.X), -abs(b.Y-a.Y)
+	sx, sy := sign(b.X-a.X), sign(b.Y-a.Y)
+	for err := dx + dy; ; {
+		img.Set(a.X, a.Y, chartColor)
+		if a == b {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			a.X += sx
+		}
+		if e2 <= dx {
+			err += dx
+			a.Y += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
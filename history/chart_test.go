@@ -0,0 +1,45 @@
+package history
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+)
+
+var testSeries = []Point{
+	{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), GlobalRank: 300},
+	{Time: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+	{Time: time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC), GlobalRank: 100},
+	{Time: time.Date(2023, 1, 5, 0, 0, 0, 0, time.UTC), GlobalRank: 200},
+}
+
+func TestSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SVG(&buf, testSeries); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<svg xmlns="http://www.w3.org/2000/svg" width="120" height="30" viewBox="0 0 120 30">` +
+		`<polyline fill="none" stroke="#1f77b4" stroke-width="1.5" points="2,27 59,2 117,14"/></svg>`
+	if buf.String() != want {
+		t.Fatalf("want %s, got %s", want, buf.String())
+	}
+}
+
+func TestPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PNG(&buf, testSeries); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range chartPoints(testSeries) {
+		if _, _, _, a := img.At(p.X, p.Y).RGBA(); a == 0 {
+			t.Fatalf("want %v drawn", p)
+		}
+	}
+}
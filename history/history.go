@@ -0,0 +1,135 @@
+// Package history keeps time series of looked up sites.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/export"
+)
+
+// Point is a standing of a site at the time it was fetched.
+type Point struct {
+	Time         time.Time `json:"time"`
+	GlobalRank   uint      `json:"global_rank"`
+	LocalRank    uint      `json:"local_rank"`
+	LinkingTotal uint      `json:"linking_total"`
+}
+
+// Store holds series of points by domain.
+type Store struct {
+	series map[string][]Point
+}
+
+// NewStore bootstraps an empty Store.
+func NewStore() *Store {
+	return &Store{series: make(map[string][]Point)}
+}
+
+// record is a line of the asip lookup output.
+type record struct {
+	Domain string     `json:"domain"`
+	Site   *asip.Site `json:"site"`
+}
+
+// Load reads a dataset of asip lookup outputs, e.g. consolidated with asip
+// merge -all, failed lookups are skipped.
+func Load(r io.Reader) (*Store, error) {
+	s := NewStore()
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			return s, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+
+		b, err := export.Migrate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+		var rec record
+		if err := json.Unmarshal(b, &rec); err != nil {
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+		if rec.Site != nil {
+			s.Add(rec.Domain, rec.Site)
+		}
+	}
+}
+
+// Open loads a dataset file.
+func Open(name string) (*Store, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Add records the site as of its fetch time, replacing a point of the same
+// time, along with its linking history. Points of the history have no
+// ranks.
+func (s *Store) Add(domain string, site *asip.Site) {
+	d := asip.Normalize(domain)
+	p := Point{
+		Time:         site.Meta.FetchedAt,
+		GlobalRank:   site.GlobalRank,
+		LocalRank:    site.LocalRank,
+		LinkingTotal: site.LinkingTotal,
+	}
+
+	s.put(d, p, func(old *Point) { *old = p })
+
+	// back-fill counts of the linking history the page charts
+	for _, c := range site.LinkingHistory {
+		s.put(d, Point{Time: c.Date, LinkingTotal: c.Total}, func(old *Point) {
+			if old.LinkingTotal == 0 {
+				old.LinkingTotal = c.Total
+			}
+		})
+	}
+}
+
+// put inserts p into the series of domain d keeping it sorted, a point of
+// the same time is updated instead.
+func (s *Store) put(d string, p Point, update func(old *Point)) {
+	ps := s.series[d]
+	i := sort.Search(len(ps), func(i int) bool { return !ps[i].Time.Before(p.Time) })
+	if i < len(ps) && ps[i].Time.Equal(p.Time) {
+		update(&ps[i])
+		return
+	}
+	s.series[d] = append(ps[:i], append([]Point{p}, ps[i:]...)...)
+}
+
+// Domains lists domains with points in order.
+func (s *Store) Domains() []string {
+	ds := make([]string, 0, len(s.series))
+	for d := range s.series {
+		ds = append(ds, d)
+	}
+	sort.Strings(ds)
+	return ds
+}
+
+// Series returns points of the domain within [from, to] in time order, zero
+// bounds are open.
+func (s *Store) Series(domain string, from, to time.Time) []Point {
+	var ps []Point
+	for _, p := range s.series[asip.Normalize(domain)] {
+		if (!from.IsZero() && p.Time.Before(from)) || (!to.IsZero() && p.Time.After(to)) {
+			continue
+		}
+		ps = append(ps, p)
+	}
+	return ps
+}
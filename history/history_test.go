@@ -0,0 +1,58 @@
+package history
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+const dataset = `{"domain": "example.org", "site": {"GlobalRank": 10, "Meta": {"FetchedAt": "2023-02-01T00:00:00Z"}}}
+{"schema_version": 2, "domain": "example.com", "error": "no enough data"}
+{"schema_version": 2, "domain": "www.example.org", "site": {"GlobalRank": 20, "LinkingTotal": 5, "Meta": {"FetchedAt": "2023-01-01T00:00:00Z"}}}
+{"schema_version": 2, "domain": "example.org", "site": {"GlobalRank": 30, "Meta": {"FetchedAt": "2023-03-01T00:00:00Z"}}}
+`
+
+func TestSeries(t *testing.T) {
+	s, err := Load(strings.NewReader(dataset))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := s.Domains(); !reflect.DeepEqual(got, []string{"example.org"}) {
+		t.Fatalf("want only example.org, got %v", got)
+	}
+
+	want := []Point{
+		{Time: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), GlobalRank: 10},
+		{Time: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), GlobalRank: 30},
+	}
+	got := s.Series("example.org", time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), time.Time{})
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestLinkingHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	s := NewStore()
+	s.Add("example.org", &asip.Site{GlobalRank: 10, LinkingTotal: 7, Meta: asip.Meta{FetchedAt: day(3)}})
+	s.Add("example.org", &asip.Site{
+		GlobalRank:     9,
+		LinkingTotal:   8,
+		LinkingHistory: []asip.LinkingCount{{Date: day(1), Total: 5}, {Date: day(3), Total: 6}, {Date: day(4), Total: 8}},
+		Meta:           asip.Meta{FetchedAt: day(4)},
+	})
+
+	want := []Point{
+		{Time: day(1), LinkingTotal: 5},
+		{Time: day(3), GlobalRank: 10, LinkingTotal: 7},
+		{Time: day(4), GlobalRank: 9, LinkingTotal: 8},
+	}
+	if got := s.Series("example.org", time.Time{}, time.Time{}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
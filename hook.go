@@ -0,0 +1,44 @@
+package asip
+
+import (
+	"context"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/fetch"
+)
+
+// ParseHook is called once a page is parsed with the Site, nil on failure,
+// and the error, which it may replace, e.g. to reject a page that parsed
+// fine but is a block page in disguise.
+type ParseHook func(ctx context.Context, domain string, s *Site, err error) error
+
+// WithRequestHook calls h with every request to alexa.com, e.g. to log it
+// or set headers.
+func WithRequestHook(h fetch.RequestHook) Option {
+	return func(conf *Conf) {
+		conf.fetchOpts = append(conf.fetchOpts, fetch.WithRequestHook(h))
+	}
+}
+
+// WithResponseHook calls h with every response from alexa.com, e.g. to
+// record it or detect a block page and have it retried.
+func WithResponseHook(h fetch.ResponseHook) Option {
+	return func(conf *Conf) {
+		conf.fetchOpts = append(conf.fetchOpts, fetch.WithResponseHook(h))
+	}
+}
+
+// WithParseHook calls h with the outcome of parsing every page, after hooks
+// added earlier.
+func WithParseHook(h ParseHook) Option {
+	return func(conf *Conf) {
+		conf.page.hooks = append(conf.page.hooks, h)
+	}
+}
+
+// hooked runs the parse hooks of p returning the error they leave.
+func (p page) hooked(ctx context.Context, domain string, s *Site, err error) error {
+	for _, h := range p.hooks {
+		err = h(ctx, domain, s, err)
+	}
+	return err
+}
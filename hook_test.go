@@ -0,0 +1,34 @@
+package asip
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParseHook(t *testing.T) {
+	blocked := errors.New("blocked")
+	var seen []string
+	p := page{hooks: []ParseHook{
+		func(ctx context.Context, domain string, s *Site, err error) error {
+			seen = append(seen, domain)
+			return err
+		},
+		func(ctx context.Context, domain string, s *Site, err error) error {
+			if s != nil && s.Meta.Bytes > 0 {
+				return blocked
+			}
+			return err
+		},
+	}}
+
+	if _, err := siteInfo(context.Background(), "sberbank.ru", fileGet, p); !errors.Is(err, blocked) {
+		t.Fatalf("want %v, got %v", blocked, err)
+	}
+	if _, err := siteInfo(context.Background(), "example.org", fileGet, p); !errors.Is(err, ErrNoEnoughData) {
+		t.Fatalf("want %v, got %v", ErrNoEnoughData, err)
+	}
+	if len(seen) != 2 || seen[0] != "sberbank.ru" || seen[1] != "example.org" {
+		t.Fatalf("want hooks called for both domains, got %v", seen)
+	}
+}
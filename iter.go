@@ -0,0 +1,94 @@
+package asip
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// Result is an outcome of a single domain lookup.
+type Result struct {
+	Site *Site
+	Err  error
+}
+
+// All looks up domains one by one and yields a Result for each of them.
+// Iteration stops early when the context is done or the loop breaks.
+func All(ctx context.Context, domains []string) iter.Seq2[string, Result] {
+	return defaultConf.All(ctx, domains)
+}
+
+// All is like the package level All but uses customised parameters.
+func (c *Conf) All(ctx context.Context, domains []string) iter.Seq2[string, Result] {
+	return all(ctx, domains, c.SiteInfo)
+}
+
+type lookupFunc func(context.Context, string) (*Site, error)
+
+func all(ctx context.Context, domains []string, f lookupFunc) iter.Seq2[string, Result] {
+	return func(yield func(string, Result) bool) {
+		for _, domain := range domains {
+			if err := ctx.Err(); err != nil {
+				yield(domain, Result{Err: err})
+				return
+			}
+
+			s, err := f(ctx, domain)
+			if !yield(domain, Result{Site: s, Err: err}) {
+				return
+			}
+		}
+	}
+}
+
+// Stream looks up up to window domains at a time and yields results in
+// order. A new lookup starts only once a result is consumed, so a slow
+// consumer throttles fetching rather than results piling up.
+func Stream(ctx context.Context, domains []string, window int) iter.Seq2[string, Result] {
+	return defaultConf.Stream(ctx, domains, window)
+}
+
+// Stream is like the package level Stream but uses customised parameters.
+func (c *Conf) Stream(ctx context.Context, domains []string, window int) iter.Seq2[string, Result] {
+	return stream(ctx, domains, window, c.SiteInfo)
+}
+
+func stream(ctx context.Context, domains []string, window int, f lookupFunc) iter.Seq2[string, Result] {
+	return func(yield func(string, Result) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		defer func() {
+			cancel()
+			wg.Wait()
+		}()
+
+		var (
+			results = make([]chan Result, len(domains))
+			next    int
+		)
+		start := func() {
+			i := next
+			next++
+			results[i] = make(chan Result, 1)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s, err := f(ctx, domains[i])
+				results[i] <- Result{Site: s, Err: err}
+			}()
+		}
+
+		for next < len(domains) && next < max(window, 1) {
+			start()
+		}
+		for i, domain := range domains {
+			res := <-results[i]
+			if !yield(domain, res) {
+				return
+			}
+			if next < len(domains) {
+				start()
+			}
+		}
+	}
+}
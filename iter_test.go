@@ -0,0 +1,95 @@
+package asip
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func fileLookup(ctx context.Context, domain string) (*Site, error) {
+	return siteInfo(ctx, domain, fileGet, page{})
+}
+
+func TestAll(t *testing.T) {
+	var got []string
+	for domain, res := range all(context.Background(), []string{"sberbank.ru", "example.org"}, fileLookup) {
+		got = append(got, domain)
+		switch domain {
+		case "sberbank.ru":
+			if res.Err != nil || res.Site.GlobalRank != 506 {
+				t.Fatalf("unexpected result: %v, %v", res.Site, res.Err)
+			}
+		case "example.org":
+			if res.Err != ErrNoEnoughData {
+				t.Fatalf("want %v, got %v", ErrNoEnoughData, res.Err)
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(got, []string{"sberbank.ru", "example.org"}) {
+		t.Fatalf("unexpected domains yielded: %v", got)
+	}
+}
+
+func TestAllBreak(t *testing.T) {
+	n := 0
+	for range all(context.Background(), []string{"sberbank.ru", "example.org"}, fileLookup) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Fatalf("want 1 iteration, got %d", n)
+	}
+}
+
+func TestAllCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, res := range all(ctx, []string{"sberbank.ru", "example.org"}, fileLookup) {
+		if res.Err != context.Canceled {
+			t.Fatalf("want %v, got %v", context.Canceled, res.Err)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inflight int
+		peak     int
+	)
+	slow := func(ctx context.Context, domain string) (*Site, error) {
+		mu.Lock()
+		inflight++
+		peak = max(peak, inflight)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+		return &Site{Domain: domain}, nil
+	}
+
+	domains := []string{"a.example", "b.example", "c.example", "d.example", "e.example"}
+	var got []string
+	for domain, res := range stream(context.Background(), domains, 2, slow) {
+		got = append(got, res.Site.Domain)
+		if domain != res.Site.Domain {
+			t.Fatalf("result of %s yielded for %s", res.Site.Domain, domain)
+		}
+
+		// results are consumed slowly, still no more than the window is fetched
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inflight--
+		mu.Unlock()
+	}
+
+	if !reflect.DeepEqual(got, domains) {
+		t.Fatalf("want %v, got %v", domains, got)
+	}
+	if peak != 2 {
+		t.Fatalf("want 2 lookups at most in flight, got %d", peak)
+	}
+}
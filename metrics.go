@@ -0,0 +1,53 @@
+package asip
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/metrics"
+)
+
+// Fetch metrics reported to metrics.Default.
+var (
+	fetchesTotal   = metrics.Default.NewCounter("asip_fetches_total", "Website Info pages requested.")
+	fetchErrors    = metrics.Default.NewCounter("asip_fetch_errors_total", "Website Info pages that failed to fetch or parse.")
+	fetchRetries   = metrics.Default.NewCounter("asip_fetch_retries_total", "Requests repeated after transient failures.")
+	fetchBytes     = metrics.Default.NewCounter("asip_fetch_bytes_total", "Bytes of Website Info pages downloaded.")
+	fetchDurations = metrics.Default.NewHistogram("asip_fetch_duration_seconds", "Time to fetch and read a Website Info page.", metrics.DefaultBuckets)
+)
+
+// gauge sends ranks of s to a StatsD agent, losing them like UDP does when
+// the agent is unavailable.
+func gauge(sd *metrics.StatsD, s *Site) {
+	domain := metrics.Tag{Key: "domain", Value: s.Domain}
+	if s.GlobalRank != 0 {
+		sd.Gauge("global_rank", float64(s.GlobalRank), domain)
+	}
+	sd.Gauge("linking_total", float64(s.LinkingTotal), domain)
+}
+
+// tailSize is how much of the end of a page is kept to find the closing
+// html tag.
+const tailSize = 64
+
+// countingReader counts bytes read through it and keeps the last ones.
+type countingReader struct {
+	r    io.Reader
+	n    int64
+	tail []byte
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	cr.tail = append(cr.tail, p[:n]...)
+	if len(cr.tail) > tailSize {
+		cr.tail = append(cr.tail[:0], cr.tail[len(cr.tail)-tailSize:]...)
+	}
+	return n, err
+}
+
+// ended reports if the page read so far ends with the closing html tag.
+func (cr *countingReader) ended() bool {
+	return bytes.Contains(bytes.ToLower(cr.tail), []byte("</html>"))
+}
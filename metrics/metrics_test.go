@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	r := &Registry{}
+	c := r.NewCounter("asip_fetches_total", "Pages fetched.")
+	h := r.NewHistogram("asip_fetch_duration_seconds", "Fetch latency.", []float64{.1, 1})
+
+	c.Inc()
+	c.Add(2)
+	h.Observe(.05)
+	h.Observe(.5)
+	h.Observe(5)
+
+	var b strings.Builder
+	if _, err := r.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP asip_fetch_duration_seconds Fetch latency.
+# TYPE asip_fetch_duration_seconds histogram
+asip_fetch_duration_seconds_bucket{le="0.1"} 1
+asip_fetch_duration_seconds_bucket{le="1"} 2
+asip_fetch_duration_seconds_bucket{le="+Inf"} 3
+asip_fetch_duration_seconds_sum 5.55
+asip_fetch_duration_seconds_count 3
+# HELP asip_fetches_total Pages fetched.
+# TYPE asip_fetches_total counter
+asip_fetches_total 3
+`
+	if b.String() != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, b.String())
+	}
+}
+
+func TestCounterVec(t *testing.T) {
+	r := &Registry{}
+	v := r.NewCounterVec("asip_selector_results_total", "Selector lookups.", "section", "result")
+
+	v.With("keywords", "matched").Inc()
+	v.With("keywords", "matched").Inc()
+	v.With("global rank", "empty").Inc()
+
+	var b strings.Builder
+	if _, err := r.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP asip_selector_results_total Selector lookups.
+# TYPE asip_selector_results_total counter
+asip_selector_results_total{section="global rank",result="empty"} 1
+asip_selector_results_total{section="keywords",result="matched"} 2
+`
+	if b.String() != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, b.String())
+	}
+}
+
+func TestDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("want a panic on duplicate registration")
+		}
+	}()
+
+	r := &Registry{}
+	r.NewCounter("asip_fetches_total", "")
+	r.NewCounter("asip_fetches_total", "")
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Tag is a dimension of a StatsD metric.
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// StatsD sends gauges to a StatsD or DogStatsD agent over UDP.
+type StatsD struct {
+	conn   net.Conn
+	prefix string
+	dog    bool
+}
+
+// NewStatsD dials the agent at addr, names of metrics are prefixed with
+// prefix. With dog set, tags are sent in the DogStatsD format, otherwise
+// their values are folded into names as plain StatsD has no tags.
+func NewStatsD(addr, prefix string, dog bool) (*StatsD, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &StatsD{conn, prefix, dog}, nil
+}
+
+// Gauge sends a gauge.
+func (s *StatsD) Gauge(name string, value float64, tags ...Tag) error {
+	_, err := s.conn.Write([]byte(s.line(name, value, tags)))
+	return err
+}
+
+// Close closes the connection to the agent.
+func (s *StatsD) Close() error {
+	return s.conn.Close()
+}
+
+func (s *StatsD) line(name string, value float64, tags []Tag) string {
+	var b strings.Builder
+	if s.prefix != "" {
+		b.WriteString(s.prefix)
+		b.WriteByte('.')
+	}
+	b.WriteString(name)
+	if !s.dog {
+		for _, t := range tags {
+			b.WriteByte('.')
+			b.WriteString(statsdName(t.Value))
+		}
+	}
+
+	b.WriteByte(':')
+	b.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+	b.WriteString("|g")
+
+	if s.dog && len(tags) > 0 {
+		b.WriteString("|#")
+		for i, t := range tags {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(t.Key)
+			b.WriteByte(':')
+			b.WriteString(t.Value)
+		}
+	}
+	return b.String()
+}
+
+// statsdName replaces characters that separate or delimit StatsD names.
+func statsdName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ':', '|', '@', '#', ' ':
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"net"
+	"testing"
+)
+
+func TestStatsD(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	for _, tt := range []struct {
+		dog  bool
+		want string
+	}{
+		{false, "asip.global_rank.sberbank_ru:506|g"},
+		{true, "asip.global_rank:506|g|#domain:sberbank.ru"},
+	} {
+		s, err := NewStatsD(pc.LocalAddr().String(), "asip", tt.dog)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Gauge("global_rank", 506, Tag{"domain", "sberbank.ru"}); err != nil {
+			t.Fatal(err)
+		}
+		s.Close()
+
+		buf := make([]byte, 512)
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(buf[:n]); got != tt.want {
+			t.Fatalf("want %q, got %q", tt.want, got)
+		}
+	}
+}
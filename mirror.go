@@ -0,0 +1,45 @@
+package asip
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// failover returns a Fetcher trying f with the URL rebased onto every mirror
+// in turn while the previous attempt is unusable.
+func failover(f Fetcher, mirrors []string) Fetcher {
+	if len(mirrors) == 0 {
+		return f
+	}
+
+	return FetcherFunc(func(ctx context.Context, url string) (*http.Response, error) {
+		resp, err := f.Fetch(ctx, url)
+		path, ok := strings.CutPrefix(url, asiBase)
+		if !ok {
+			return resp, err
+		}
+
+		for _, base := range mirrors {
+			if !unusable(resp, err) || ctx.Err() != nil {
+				break
+			}
+			if resp != nil {
+				resp.Body.Close()
+			}
+			resp, err = f.Fetch(ctx, strings.TrimSuffix(base, "/")+path)
+		}
+		return resp, err
+	})
+}
+
+// unusable reports if a response is worth retrying elsewhere, 404 is not as
+// it means the domain is unknown.
+func unusable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= http.StatusInternalServerError ||
+		!isHTML(resp.Header.Get("Content-Type"))
+}
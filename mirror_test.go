@@ -0,0 +1,58 @@
+package asip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestFailover(t *testing.T) {
+	var tried []string
+	get := FetcherFunc(func(ctx context.Context, url string) (*http.Response, error) {
+		tried = append(tried, url)
+		switch len(tried) {
+		case 1:
+			return nil, errors.New("connection refused")
+		case 2:
+			return statusGet(http.StatusBadGateway)(ctx, url)
+		}
+		return fileGet(ctx, fmt.Sprintf(asiLocation, "sberbank.ru"))
+	})
+
+	f := failover(get, []string{"http://mirror.internal/", "https://web.archive.org/web/2022/https://www.alexa.com", "http://unused"})
+	s, err := siteInfo(context.Background(), "sberbank.ru", f, page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.GlobalRank != 506 {
+		t.Fatalf("want global rank 506, got %d", s.GlobalRank)
+	}
+
+	want := []string{
+		"https://www.alexa.com/siteinfo/sberbank.ru?ver=classic",
+		"http://mirror.internal/siteinfo/sberbank.ru?ver=classic",
+		"https://web.archive.org/web/2022/https://www.alexa.com/siteinfo/sberbank.ru?ver=classic",
+	}
+	if !reflect.DeepEqual(tried, want) {
+		t.Fatalf("want %v, got %v", want, tried)
+	}
+}
+
+func TestFailoverNotFound(t *testing.T) {
+	var hits int
+	get := FetcherFunc(func(ctx context.Context, url string) (*http.Response, error) {
+		hits++
+		return statusGet(http.StatusNotFound)(ctx, url)
+	})
+
+	ranked, err := isRanked(context.Background(), "example.org", failover(get, []string{"http://mirror.internal"}))
+	if err != nil || ranked {
+		t.Fatalf("want unranked, got %v, %v", ranked, err)
+	}
+	if hits != 1 {
+		t.Fatalf("want no failover on 404, got %d requests", hits)
+	}
+}
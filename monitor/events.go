@@ -0,0 +1,181 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// DefaultReplay is how many recent alerts are replayed to new
+	// subscribers by default.
+	DefaultReplay = 100
+
+	// subscriberBuffer is how many alerts a subscriber may lag behind
+	// before missing some.
+	subscriberBuffer = 64
+)
+
+// Event is an alert numbered in order of notification.
+type Event struct {
+	ID uint64
+	Alert
+}
+
+// Events fans alerts out to subscribers, e.g. dashboards over WebSocket or
+// Server-Sent Events, keeping the recent ones to replay.
+type Events struct {
+	mu     sync.Mutex
+	subs   map[chan Event]struct{}
+	recent []Event // ring of the last alerts, the oldest at next once full
+	next   int
+	lastID uint64
+}
+
+// NewEvents bootstraps Events keeping up to replay recent alerts.
+func NewEvents(replay int) *Events {
+	return &Events{
+		subs:   make(map[chan Event]struct{}),
+		recent: make([]Event, 0, max(replay, 0)),
+	}
+}
+
+// Notify passes the alert on to every subscriber, skipping the ones lagging
+// behind rather than waiting for them.
+func (e *Events) Notify(_ context.Context, a Alert) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.lastID++
+	ev := Event{ID: e.lastID, Alert: a}
+	switch {
+	case cap(e.recent) == 0:
+	case len(e.recent) < cap(e.recent):
+		e.recent = append(e.recent, ev)
+	default:
+		e.recent[e.next] = ev
+		e.next = (e.next + 1) % len(e.recent)
+	}
+
+	for ch := range e.subs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe returns kept events numbered after the ID, oldest first, and a
+// channel of events notified from now on, cancel stops them and closes it.
+func (e *Events) Subscribe(after uint64) (recent []Event, events <-chan Event, cancel func()) {
+	ch := make(chan Event, subscriberBuffer)
+	e.mu.Lock()
+	for i := range e.recent {
+		if ev := e.recent[(e.next+i)%len(e.recent)]; ev.ID > after {
+			recent = append(recent, ev)
+		}
+	}
+	e.subs[ch] = struct{}{}
+	e.mu.Unlock()
+
+	var once sync.Once
+	return recent, ch, func() {
+		once.Do(func() {
+			e.mu.Lock()
+			delete(e.subs, ch)
+			e.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// WebSocket streams alerts as JSON messages shaped like Webhook posts to
+// every client until it disconnects. Clients of any origin are accepted as
+// the stream is read only.
+func (e *Events) WebSocket() http.Handler {
+	return websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			_, events, cancel := e.Subscribe(math.MaxUint64)
+			defer cancel()
+
+			gone := make(chan struct{})
+			go func() {
+				io.Copy(io.Discard, ws)
+				close(gone)
+			}()
+
+			for {
+				select {
+				case <-gone:
+					return
+				case ev := <-events:
+					if err := websocket.JSON.Send(ws, payload(ev.Alert)); err != nil {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+// SSE streams alerts as Server-Sent Events of JSON shaped like Webhook
+// posts, replaying the kept ones after Last-Event-ID, or all of them, on
+// connect.
+func (e *Events) SSE() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		var after uint64
+		if id := r.Header.Get("Last-Event-ID"); id != "" {
+			after, _ = strconv.ParseUint(id, 10, 64)
+		}
+		recent, events, cancel := e.Subscribe(after)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		send := func(ev Event) error {
+			b, err := json.Marshal(payload(ev.Alert))
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\ndata: %s\n\n", ev.ID, b); err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		}
+
+		for _, ev := range recent {
+			if send(ev) != nil {
+				return
+			}
+		}
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case ev := <-events:
+				if send(ev) != nil {
+					return
+				}
+			}
+		}
+	})
+}
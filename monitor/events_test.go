@@ -0,0 +1,133 @@
+package monitor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"golang.org/x/net/websocket"
+)
+
+func TestEventsWebSocket(t *testing.T) {
+	events := NewEvents(0)
+	srv := httptest.NewServer(events.WebSocket())
+	defer srv.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	// the handler subscribes once the handshake is done
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		events.mu.Lock()
+		n := len(events.subs)
+		events.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("no subscriber")
+		}
+	}
+
+	e := Entry{Domain: "example.org", Thresholds: Thresholds{MaxGlobalRank: 10}}
+	m, err := New(sites(500), &Watchlist{Entries: []Entry{e}}, &recorder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Events = events
+	m.Check(context.Background(), e)
+
+	var got webhookPayload
+	ws.SetReadDeadline(time.Now().Add(time.Second))
+	if err := websocket.JSON.Receive(ws, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Domain != "example.org" || got.GlobalRank != 500 || got.Reason != "global rank 500 is worse than 10" {
+		t.Fatalf("want the alert, got %+v", got)
+	}
+}
+
+func TestEventsSlowSubscriber(t *testing.T) {
+	events := NewEvents(0)
+	_, alerts, cancel := events.Subscribe(0)
+	a := Alert{Site: &asip.Site{}}
+	for range subscriberBuffer + 1 {
+		events.Notify(context.Background(), a)
+	}
+	if len(alerts) != subscriberBuffer {
+		t.Fatalf("want %d buffered alerts, got %d", subscriberBuffer, len(alerts))
+	}
+
+	cancel()
+	cancel()
+	events.Notify(context.Background(), a)
+}
+
+func TestEventsSSE(t *testing.T) {
+	events := NewEvents(2)
+	notify := func(domain string) {
+		events.Notify(context.Background(), Alert{Entry: Entry{Domain: domain}, Site: &asip.Site{}, Reason: "not ranked"})
+	}
+	for _, d := range []string{"a.example", "b.example", "c.example"} {
+		notify(d)
+	}
+	srv := httptest.NewServer(events.SSE())
+	defer srv.Close()
+
+	// read returns n events as "ID DOMAIN", calling replayed after the
+	// first one
+	read := func(lastID string, n int, replayed func()) []string {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		if lastID != "" {
+			req.Header.Set("Last-Event-ID", lastID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+			t.Fatalf("want an event stream, got %q", ct)
+		}
+
+		var (
+			got []string
+			id  string
+		)
+		sc := bufio.NewScanner(resp.Body)
+		for len(got) < n && sc.Scan() {
+			if v, ok := strings.CutPrefix(sc.Text(), "id: "); ok {
+				id = v
+			}
+			if v, ok := strings.CutPrefix(sc.Text(), "data: "); ok {
+				var p webhookPayload
+				if err := json.Unmarshal([]byte(v), &p); err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, id+" "+p.Domain)
+				if len(got) == 1 && replayed != nil {
+					replayed()
+				}
+			}
+		}
+		return got
+	}
+
+	if got, want := fmt.Sprint(read("", 2, nil)), "[2 b.example 3 c.example]"; got != want {
+		t.Fatalf("want the last 2 alerts replayed, got %s", got)
+	}
+	if got, want := fmt.Sprint(read("2", 2, func() { notify("d.example") })), "[3 c.example 4 d.example]"; got != want {
+		t.Fatalf("want alerts after Last-Event-ID and new ones, got %s", got)
+	}
+}
@@ -0,0 +1,241 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+// Alert is raised when a watched domain crosses a threshold.
+type Alert struct {
+	Entry     Entry
+	Site      *asip.Site
+	Threshold string // JSON name of the crossed field of Thresholds
+	Reason    string
+	Time      time.Time
+}
+
+func (a Alert) String() string {
+	return fmt.Sprintf("%s: %s", a.Entry.Domain, a.Reason)
+}
+
+// Notifier delivers alerts.
+type Notifier interface {
+	Notify(context.Context, Alert) error
+}
+
+// LookupFunc fetches a site, (*asip.Conf).SiteInfo fits.
+type LookupFunc func(context.Context, string) (*asip.Site, error)
+
+// Monitor checks watchlist entries on their schedules.
+type Monitor struct {
+	lookup   LookupFunc
+	notifier Notifier
+	reloaded chan struct{}
+
+	mu        sync.Mutex
+	list      *Watchlist
+	notifiers map[string]Notifier
+	previous  map[string]uint // last global ranks by domain
+
+	// OnError is called on failed lookups and notifications if set.
+	OnError func(domain string, err error)
+
+	// Events receives every alert besides notifiers of entries if set.
+	Events *Events
+}
+
+// New bootstraps a Monitor. Alerts of entries that do not pick notifiers of
+// the watchlist go to n, which may be nil if every entry picks some.
+func New(lookup LookupFunc, list *Watchlist, n Notifier) (*Monitor, error) {
+	ns, err := buildNotifiers(list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Monitor{
+		lookup:    lookup,
+		list:      list,
+		notifier:  n,
+		notifiers: ns,
+		previous:  make(map[string]uint),
+		reloaded:  make(chan struct{}, 1),
+	}, nil
+}
+
+func buildNotifiers(list *Watchlist) (map[string]Notifier, error) {
+	ns := make(map[string]Notifier, len(list.Notifiers))
+	for name, nc := range list.Notifiers {
+		n, err := nc.Build()
+		if err != nil {
+			return nil, fmt.Errorf("notifier %s: %w", name, err)
+		}
+		ns[name] = n
+	}
+	return ns, nil
+}
+
+// Reload swaps in list while Run goes on: domains still watched keep their
+// schedules and last ranks, new ones are checked right away.
+func (m *Monitor) Reload(list *Watchlist) error {
+	ns, err := buildNotifiers(list)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.list, m.notifiers = list, ns
+	m.mu.Unlock()
+
+	select {
+	case m.reloaded <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run checks every entry right away and then on its schedule until the
+// context is done.
+func (m *Monitor) Run(ctx context.Context) error {
+	next := make(map[string]time.Time)
+	for {
+		m.mu.Lock()
+		entries, notifiers := m.list.Entries, m.notifiers
+		m.mu.Unlock()
+
+		now := time.Now()
+		wake := now.Add(DefaultSchedule)
+		due := make(map[string]time.Time, len(entries))
+		for _, e := range entries {
+			n := next[e.Domain]
+			if !n.After(now) {
+				m.check(ctx, e, notifiers)
+				n = now.Add(e.Every())
+			}
+			due[e.Domain] = n
+			if n.Before(wake) {
+				wake = n
+			}
+		}
+		next = due
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-m.reloaded:
+		case <-time.After(time.Until(wake)):
+		}
+	}
+}
+
+// Check looks up a single entry and notifies about crossed thresholds.
+func (m *Monitor) Check(ctx context.Context, e Entry) {
+	m.mu.Lock()
+	notifiers := m.notifiers
+	m.mu.Unlock()
+	m.check(ctx, e, notifiers)
+}
+
+// check is Check delivering alerts to notifiers of the watchlist the entry
+// comes from, which Reload may have swapped since.
+func (m *Monitor) check(ctx context.Context, e Entry, notifiers map[string]Notifier) {
+	s, err := m.lookup(ctx, e.Domain)
+	if err != nil && !errors.Is(err, asip.ErrNoEnoughData) {
+		m.fail(e.Domain, err)
+		return
+	}
+	if s == nil {
+		s = &asip.Site{Domain: e.Domain}
+	}
+
+	for _, a := range m.evaluate(e, s) {
+		a.Entry, a.Site, a.Time = e, s, time.Now()
+		for _, n := range m.notifiersOf(e, notifiers) {
+			if err := n.Notify(ctx, a); err != nil {
+				m.fail(e.Domain, err)
+			}
+		}
+		if m.Events != nil {
+			m.Events.Notify(ctx, a)
+		}
+	}
+}
+
+func (m *Monitor) notifiersOf(e Entry, notifiers map[string]Notifier) []Notifier {
+	if len(e.Notify) == 0 {
+		if m.notifier == nil {
+			m.fail(e.Domain, errors.New("no notifier to deliver alerts"))
+			return nil
+		}
+		return []Notifier{m.notifier}
+	}
+
+	var ns []Notifier
+	for _, name := range e.Notify {
+		n, ok := notifiers[name]
+		if !ok {
+			m.fail(e.Domain, fmt.Errorf("unknown notifier %s", name))
+			continue
+		}
+		ns = append(ns, n)
+	}
+	return ns
+}
+
+// evaluate returns alerts of crossed thresholds with their reasons.
+func (m *Monitor) evaluate(e Entry, s *asip.Site) []Alert {
+	var alerts []Alert
+	raise := func(threshold, reason string) {
+		alerts = append(alerts, Alert{Threshold: threshold, Reason: reason})
+	}
+	t := e.Thresholds
+
+	if t.MaxGlobalRank != 0 {
+		switch {
+		case s.GlobalRank == 0:
+			raise("max_global_rank", "not ranked")
+		case s.GlobalRank > t.MaxGlobalRank:
+			raise("max_global_rank", fmt.Sprintf("global rank %d is worse than %d", s.GlobalRank, t.MaxGlobalRank))
+		}
+	}
+
+	m.mu.Lock()
+	prev, seen := m.previous[e.Domain]
+	if s.GlobalRank != 0 {
+		m.previous[e.Domain] = s.GlobalRank
+	}
+	m.mu.Unlock()
+	if seen && t.MaxRankChange != 0 && s.GlobalRank != 0 {
+		if diff := absDiff(prev, s.GlobalRank); diff > t.MaxRankChange {
+			raise("max_rank_change", fmt.Sprintf("global rank moved by %d from %d to %d", diff, prev, s.GlobalRank))
+		}
+	}
+
+	if t.MinLinkingTotal != 0 && s.LinkingTotal < t.MinLinkingTotal {
+		raise("min_linking_total", fmt.Sprintf("linking total %d is below %d", s.LinkingTotal, t.MinLinkingTotal))
+	}
+
+	if c := s.BounceRate.Change; t.MaxBounceRateChange != 0 && math.Abs(c) > t.MaxBounceRateChange {
+		raise("max_bounce_rate_change", fmt.Sprintf("bounce rate changed by %+g%% to %g%%", c, s.BounceRate.Value))
+	}
+
+	return alerts
+}
+
+func (m *Monitor) fail(domain string, err error) {
+	if m.OnError != nil {
+		m.OnError(domain, err)
+	}
+}
+
+func absDiff(a, b uint) uint {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,217 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+type recorder []Alert
+
+func (r *recorder) Notify(_ context.Context, a Alert) error {
+	*r = append(*r, a)
+	return nil
+}
+
+func sites(ranks ...uint) LookupFunc {
+	return func(_ context.Context, domain string) (*asip.Site, error) {
+		r := ranks[0]
+		ranks = ranks[1:]
+		if r == 0 {
+			return nil, asip.ErrNoEnoughData
+		}
+		return &asip.Site{Domain: domain, GlobalRank: r, LinkingTotal: 10}, nil
+	}
+}
+
+func TestCheck(t *testing.T) {
+	e := Entry{
+		Domain: "example.org",
+		Thresholds: Thresholds{
+			MaxGlobalRank:   1000,
+			MaxRankChange:   100,
+			MinLinkingTotal: 5,
+		},
+	}
+
+	var r recorder
+	m, err := New(sites(500, 550, 900, 0), &Watchlist{Entries: []Entry{e}}, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		m.Check(context.Background(), e)
+	}
+
+	want := []string{
+		"global rank moved by 350 from 550 to 900",
+		"not ranked",
+		"linking total 0 is below 5",
+	}
+	if len(r) != len(want) {
+		t.Fatalf("want %d alerts, got %v", len(want), r)
+	}
+	for i, a := range r {
+		if a.Reason != want[i] {
+			t.Fatalf("want %q, got %q", want[i], a.Reason)
+		}
+	}
+}
+
+func TestReload(t *testing.T) {
+	checked := make(chan string, 10)
+	lookup := func(_ context.Context, domain string) (*asip.Site, error) {
+		checked <- domain
+		return &asip.Site{Domain: domain, GlobalRank: 1}, nil
+	}
+
+	m, err := New(lookup, &Watchlist{Entries: []Entry{{Domain: "a.example"}}}, &recorder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+
+	if d := <-checked; d != "a.example" {
+		t.Fatalf("want a.example checked, got %s", d)
+	}
+	if err := m.Reload(&Watchlist{Entries: []Entry{{Domain: "a.example"}, {Domain: "b.example"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if d := <-checked; d != "b.example" {
+		t.Fatalf("want only the new b.example checked, got %s", d)
+	}
+
+	if err := m.Reload(&Watchlist{Notifiers: map[string]NotifierConfig{"broken": {Type: "webhook"}}}); err == nil {
+		t.Fatal("want an invalid notifier error")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("want %v, got %v", context.Canceled, err)
+	}
+	if len(checked) != 0 {
+		t.Fatalf("want no more checks, got %d", len(checked))
+	}
+}
+
+func TestCheckUnknownNotifier(t *testing.T) {
+	e := Entry{Domain: "example.org", Notify: []string{"gone"}, Thresholds: Thresholds{MaxGlobalRank: 10}}
+	m, err := New(sites(500), &Watchlist{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var errs []error
+	m.OnError = func(_ string, err error) { errs = append(errs, err) }
+	m.Check(context.Background(), e)
+
+	if len(errs) != 1 || errs[0].Error() != "unknown notifier gone" {
+		t.Fatalf("want an unknown notifier error, got %v", errs)
+	}
+}
+
+func TestCheckWrappedNoData(t *testing.T) {
+	e := Entry{Domain: "example.org", Thresholds: Thresholds{MaxGlobalRank: 10}}
+	lookup := func(context.Context, string) (*asip.Site, error) {
+		return nil, fmt.Errorf("parse: %w", asip.ErrNoEnoughData)
+	}
+	var r recorder
+	m, err := New(lookup, &Watchlist{}, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.OnError = func(_ string, err error) { t.Fatal(err) }
+	m.Check(context.Background(), e)
+
+	if len(r) != 1 || r[0].Reason != "not ranked" || r[0].Threshold != "max_global_rank" {
+		t.Fatalf("want a not ranked alert, got %v", r)
+	}
+}
+
+func TestCheckOnErrorReloads(t *testing.T) {
+	e := Entry{Domain: "example.org", Thresholds: Thresholds{MaxGlobalRank: 10}}
+	m, err := New(sites(500), &Watchlist{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.OnError = func(string, error) {
+		if err := m.Reload(&Watchlist{}); err != nil {
+			t.Error(err)
+		}
+	}
+	m.Check(context.Background(), e)
+}
+
+func TestCheckConcurrently(t *testing.T) {
+	e := Entry{Domain: "example.org", Thresholds: Thresholds{MaxRankChange: 1}}
+	lookup := func(_ context.Context, domain string) (*asip.Site, error) {
+		return &asip.Site{Domain: domain, GlobalRank: 5}, nil
+	}
+	m, err := New(lookup, &Watchlist{}, &recorder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Check(context.Background(), e)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCheckBounceRate(t *testing.T) {
+	e := Entry{Domain: "example.org", Thresholds: Thresholds{MaxBounceRateChange: 5}}
+	changes := []float64{4, -7.5}
+	lookup := func(_ context.Context, domain string) (*asip.Site, error) {
+		c := changes[0]
+		changes = changes[1:]
+		return &asip.Site{Domain: domain, BounceRate: asip.Trend{Value: 30, Change: c}}, nil
+	}
+
+	var r recorder
+	m, err := New(lookup, &Watchlist{Entries: []Entry{e}}, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Check(context.Background(), e)
+	m.Check(context.Background(), e)
+
+	if len(r) != 1 || r[0].Reason != "bounce rate changed by -7.5% to 30%" {
+		t.Fatalf("want a bounce rate alert, got %v", r)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	var got webhookPayload
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer ts.Close()
+
+	wh := &Webhook{URL: ts.URL}
+	err := wh.Notify(context.Background(), Alert{
+		Entry:  Entry{Domain: "example.org"},
+		Site:   &asip.Site{GlobalRank: 2000},
+		Reason: "global rank 2000 is worse than 1000",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Domain != "example.org" || got.GlobalRank != 2000 {
+		t.Fatalf("unexpected payload %v", got)
+	}
+}
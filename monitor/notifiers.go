@@ -0,0 +1,175 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Notifier types of a NotifierConfig.
+const (
+	TypeWebhook   = "webhook"
+	TypeSlack     = "slack"
+	TypeSMTP      = "smtp"
+	TypePagerDuty = "pagerduty"
+)
+
+const pagerDutyLocation = "https://events.pagerduty.com/v2/enqueue"
+
+// NotifierConfig describes a notifier in a watchlist.
+type NotifierConfig struct {
+	Type string `json:"type"`
+
+	// webhook and slack
+	URL string `json:"url,omitempty"`
+
+	// smtp
+	Addr     string   `json:"addr,omitempty"` // host:port
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+
+	// pagerduty
+	RoutingKey string `json:"routing_key,omitempty"`
+	Severity   string `json:"severity,omitempty"` // warning if empty
+}
+
+func (nc NotifierConfig) validate() error {
+	switch nc.Type {
+	case TypeWebhook, TypeSlack:
+		if nc.URL == "" {
+			return errors.New("no url")
+		}
+	case TypeSMTP:
+		if nc.Addr == "" || nc.From == "" || len(nc.To) == 0 {
+			return errors.New("addr, from and to are required")
+		}
+	case TypePagerDuty:
+		if nc.RoutingKey == "" {
+			return errors.New("no routing_key")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", nc.Type)
+	}
+	return nil
+}
+
+// Build makes a Notifier out of the config.
+func (nc NotifierConfig) Build() (Notifier, error) {
+	if err := nc.validate(); err != nil {
+		return nil, err
+	}
+
+	switch nc.Type {
+	case TypeSlack:
+		return &Slack{URL: nc.URL}, nil
+	case TypeSMTP:
+		host := nc.Addr
+		if i := strings.LastIndexByte(host, ':'); i >= 0 {
+			host = host[:i]
+		}
+		var auth smtp.Auth
+		if nc.Username != "" {
+			auth = smtp.PlainAuth("", nc.Username, nc.Password, host)
+		}
+		return &Email{Addr: nc.Addr, Auth: auth, From: nc.From, To: nc.To}, nil
+	case TypePagerDuty:
+		return &PagerDuty{RoutingKey: nc.RoutingKey, Severity: nc.Severity}, nil
+	}
+	return &Webhook{URL: nc.URL}, nil
+}
+
+// Slack posts alerts to a Slack incoming webhook.
+type Slack struct {
+	URL    string
+	Client *http.Client
+}
+
+// Notify posts the alert.
+func (s *Slack) Notify(ctx context.Context, a Alert) error {
+	b, err := json.Marshal(map[string]string{"text": a.String()})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, s.Client, s.URL, b)
+}
+
+// Email sends alerts over SMTP.
+type Email struct {
+	Addr string
+	Auth smtp.Auth
+	From string
+	To   []string
+
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// Notify sends the alert.
+func (e *Email) Notify(_ context.Context, a Alert) error {
+	send := e.send
+	if send == nil {
+		send = smtp.SendMail
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: asip alert: %s\r\nDate: %s\r\n\r\n%s\r\n",
+		e.From, strings.Join(e.To, ", "), a.Entry.Domain, a.Time.Format(time.RFC1123Z), a)
+	return send(e.Addr, e.Auth, e.From, e.To, []byte(msg))
+}
+
+// PagerDuty triggers incidents with the PagerDuty Events API v2.
+type PagerDuty struct {
+	RoutingKey string
+	Severity   string
+	Client     *http.Client
+
+	url string
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// Notify triggers an incident deduplicated by domain and crossed threshold,
+// so later checks update it however the numbers move.
+func (p *PagerDuty) Notify(ctx context.Context, a Alert) error {
+	severity := p.Severity
+	if severity == "" {
+		severity = "warning"
+	}
+	u := p.url
+	if u == "" {
+		u = pagerDutyLocation
+	}
+
+	b, err := json.Marshal(pagerDutyEvent{
+		RoutingKey:  p.RoutingKey,
+		EventAction: "trigger",
+		DedupKey:    a.Entry.Domain + "/" + a.Threshold,
+		Payload: pagerDutyPayload{
+			Summary:       a.String(),
+			Source:        "asip",
+			Severity:      severity,
+			CustomDetails: a.Entry.Labels,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, p.Client, u, b)
+}
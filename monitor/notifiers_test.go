@@ -0,0 +1,124 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+var testAlert = Alert{
+	Entry:     Entry{Domain: "example.org", Labels: map[string]string{"team": "brand"}},
+	Site:      &asip.Site{GlobalRank: 2000},
+	Threshold: "max_global_rank",
+	Reason:    "global rank 2000 is worse than 1000",
+	Time:      time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestSlack(t *testing.T) {
+	var got map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer ts.Close()
+
+	if err := (&Slack{URL: ts.URL}).Notify(context.Background(), testAlert); err != nil {
+		t.Fatal(err)
+	}
+	if got["text"] != "example.org: global rank 2000 is worse than 1000" {
+		t.Fatalf("unexpected text %q", got["text"])
+	}
+}
+
+func TestEmail(t *testing.T) {
+	var msg string
+	e := &Email{
+		Addr: "localhost:25",
+		From: "asip@example.org",
+		To:   []string{"soc@example.org"},
+		send: func(addr string, _ smtp.Auth, from string, to []string, b []byte) error {
+			msg = string(b)
+			return nil
+		},
+	}
+	if err := e.Notify(context.Background(), testAlert); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(msg, "Subject: asip alert: example.org\r\n") || !strings.HasSuffix(msg, "worse than 1000\r\n") {
+		t.Fatalf("unexpected message %q", msg)
+	}
+}
+
+func TestPagerDuty(t *testing.T) {
+	var got pagerDutyEvent
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	p := &PagerDuty{RoutingKey: "key", url: ts.URL}
+	if err := p.Notify(context.Background(), testAlert); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.RoutingKey != "key" || got.EventAction != "trigger" || got.Payload.Severity != "warning" {
+		t.Fatalf("unexpected event %v", got)
+	}
+
+	key := got.DedupKey
+	worse := testAlert
+	worse.Site, worse.Reason = &asip.Site{GlobalRank: 3000}, "global rank 3000 is worse than 1000"
+	if err := p.Notify(context.Background(), worse); err != nil {
+		t.Fatal(err)
+	}
+	if key != "example.org/max_global_rank" || got.DedupKey != key {
+		t.Fatalf("want the incident of the domain and threshold updated, got keys %q and %q", key, got.DedupKey)
+	}
+}
+
+func TestPerEntryNotifiers(t *testing.T) {
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer ts.Close()
+
+	w, err := Load(strings.NewReader(`{
+		"notifiers": {"chat": {"type": "slack", "url": "` + ts.URL + `"}},
+		"entries": [
+			{"domain": "example.org", "thresholds": {"max_global_rank": 1}, "notify": ["chat"]},
+			{"domain": "example.com", "thresholds": {"max_global_rank": 1}}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r recorder
+	m, err := New(sites(10, 10), w, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range w.Entries {
+		m.Check(context.Background(), e)
+	}
+
+	if hits != 1 || len(r) != 1 || r[0].Entry.Domain != "example.com" {
+		t.Fatalf("want one alert per notifier, got %d on slack and %v by default", hits, r)
+	}
+}
+
+func TestUnknownNotifier(t *testing.T) {
+	_, err := Load(strings.NewReader(`{"entries": [{"domain": "example.org", "notify": ["nope"]}]}`))
+	if err == nil || !strings.Contains(err.Error(), "unknown notifier nope") {
+		t.Fatalf("want unknown notifier error, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+// Package monitor periodically looks up watched domains and raises alerts
+// when they cross configured thresholds.
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/fetch"
+)
+
+const (
+	// DefaultSchedule is used for entries that do not set one.
+	DefaultSchedule = 24 * time.Hour
+	minSchedule     = time.Minute
+)
+
+// Watchlist is a set of domains to monitor, stored as JSON:
+//
+//	{
+//		"notifiers": {"oncall": {"type": "pagerduty", "routing_key": "..."}},
+//		"limits": {"global": 4, "per_host": 1},
+//		"entries": [{
+//			"domain": "example.org",
+//			"schedule": "6h",
+//			"labels": {"team": "brand"},
+//			"thresholds": {"max_global_rank": 100000, "max_rank_change": 5000},
+//			"notify": ["oncall"]
+//		}]
+//	}
+type Watchlist struct {
+	Notifiers map[string]NotifierConfig `json:"notifiers,omitempty"`
+	Limits    fetch.Limits              `json:"limits"` // of concurrent lookups
+	Entries   []Entry                   `json:"entries"`
+}
+
+// Entry is a single watched domain.
+type Entry struct {
+	Domain     string            `json:"domain"`
+	Schedule   Duration          `json:"schedule,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Thresholds Thresholds        `json:"thresholds"`
+	Notify     []string          `json:"notify,omitempty"` // names of notifiers, the default one if empty
+}
+
+// Thresholds are alert conditions of an Entry, zero values are disabled.
+type Thresholds struct {
+	MaxGlobalRank   uint `json:"max_global_rank,omitempty"`   // alert when ranked worse or not at all
+	MaxRankChange   uint `json:"max_rank_change,omitempty"`   // alert when rank moves more between checks
+	MinLinkingTotal uint `json:"min_linking_total,omitempty"` // alert when fewer sites link in
+
+	// alert when bounce rate changed by more percent over 90 days
+	MaxBounceRateChange float64 `json:"max_bounce_rate_change,omitempty"`
+}
+
+// Duration is a time.Duration written as "6h" or "30m".
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON writes a duration string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Every returns how often the entry is checked.
+func (e *Entry) Every() time.Duration {
+	if e.Schedule == 0 {
+		return DefaultSchedule
+	}
+	return time.Duration(e.Schedule)
+}
+
+// Load reads and validates a watchlist.
+func Load(r io.Reader) (*Watchlist, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var w Watchlist
+	if err := dec.Decode(&w); err != nil {
+		return nil, fmt.Errorf("watchlist: %w", err)
+	}
+
+	return &w, w.Validate()
+}
+
+// LoadFile is like Load but reads a file.
+func LoadFile(name string) (*Watchlist, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Validate reports every problem of the watchlist at once.
+func (w *Watchlist) Validate() error {
+	var errs []error
+	for name, nc := range w.Notifiers {
+		if err := nc.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("notifier %s: %w", name, err))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, e := range w.Entries {
+		d := strings.ToLower(strings.TrimSpace(e.Domain))
+		switch {
+		case d == "":
+			errs = append(errs, fmt.Errorf("entry %d: no domain", i))
+		case strings.ContainsAny(d, " /:"):
+			errs = append(errs, fmt.Errorf("entry %d: %q is not a domain", i, e.Domain))
+		case seen[d]:
+			errs = append(errs, fmt.Errorf("entry %d: duplicate domain %s", i, d))
+		}
+		seen[d] = true
+
+		if e.Schedule != 0 && time.Duration(e.Schedule) < minSchedule {
+			errs = append(errs, fmt.Errorf("entry %d: schedule %s is shorter than %s", i, time.Duration(e.Schedule), minSchedule))
+		}
+
+		for _, n := range e.Notify {
+			if _, ok := w.Notifiers[n]; !ok {
+				errs = append(errs, fmt.Errorf("entry %d: unknown notifier %s", i, n))
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("watchlist: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	w, err := Load(strings.NewReader(`{"entries": [
+		{"domain": "example.org", "schedule": "6h", "labels": {"team": "brand"}, "thresholds": {"max_global_rank": 1000}},
+		{"domain": "example.com"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(w.Entries) != 2 {
+		t.Fatalf("want 2 entries, got %d", len(w.Entries))
+	}
+	if got := w.Entries[0].Every(); got != 6*time.Hour {
+		t.Fatalf("want 6h schedule, got %s", got)
+	}
+	if got := w.Entries[1].Every(); got != DefaultSchedule {
+		t.Fatalf("want default schedule, got %s", got)
+	}
+	if w.Entries[0].Thresholds.MaxGlobalRank != 1000 {
+		t.Fatalf("want max global rank 1000, got %d", w.Entries[0].Thresholds.MaxGlobalRank)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	_, err := Load(strings.NewReader(`{"entries": [
+		{"domain": "example.org", "schedule": "10s"},
+		{"domain": "Example.org"},
+		{"domain": "https://example.com/"},
+		{"domain": ""}
+	]}`))
+	if err == nil {
+		t.Fatal("want error, but got no error")
+	}
+
+	for _, want := range []string{"entry 0: schedule", "entry 1: duplicate", "entry 2:", "entry 3: no domain"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("want %q reported, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadUnknownField(t *testing.T) {
+	if _, err := Load(strings.NewReader(`{"entries": [{"domian": "example.org"}]}`)); err == nil {
+		t.Fatal("want error, but got no error")
+	}
+}
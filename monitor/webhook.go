@@ -0,0 +1,68 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Webhook posts alerts as JSON to a URL.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+// webhookPayload is the JSON of an alert, posted or streamed as an event.
+type webhookPayload struct {
+	Domain     string            `json:"domain"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Reason     string            `json:"reason"`
+	GlobalRank uint              `json:"global_rank"`
+	Time       string            `json:"time"`
+}
+
+// Notify posts the alert.
+func (w *Webhook) Notify(ctx context.Context, a Alert) error {
+	b, err := json.Marshal(payload(a))
+	if err != nil {
+		return err
+	}
+
+	return postJSON(ctx, w.Client, w.URL, b)
+}
+
+func payload(a Alert) webhookPayload {
+	return webhookPayload{
+		Domain:     a.Entry.Domain,
+		Labels:     a.Entry.Labels,
+		Reason:     a.Reason,
+		GlobalRank: a.Site.GlobalRank,
+		Time:       a.Time.UTC().Format(time.RFC3339),
+	}
+}
+
+func postJSON(ctx context.Context, c *http.Client, url string, b []byte) error {
+	if c == nil {
+		c = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package parse
+
+import "strings"
+
+// Continent is a two-letter continent code.
+type Continent string
+
+// Continents.
+const (
+	Africa       Continent = "AF"
+	Antarctica   Continent = "AN"
+	Asia         Continent = "AS"
+	Europe       Continent = "EU"
+	NorthAmerica Continent = "NA"
+	Oceania      Continent = "OC"
+	SouthAmerica Continent = "SA"
+)
+
+// continents maps ISO 3166-1 alpha-2 codes to continents, transcontinental
+// countries go by their capital.
+var continents = map[string]Continent{
+	"AD": Europe,
+	"AE": Asia,
+	"AF": Asia,
+	"AG": NorthAmerica,
+	"AI": NorthAmerica,
+	"AL": Europe,
+	"AM": Asia,
+	"AO": Africa,
+	"AQ": Antarctica,
+	"AR": SouthAmerica,
+	"AS": Oceania,
+	"AT": Europe,
+	"AU": Oceania,
+	"AW": NorthAmerica,
+	"AX": Europe,
+	"AZ": Asia,
+	"BA": Europe,
+	"BB": NorthAmerica,
+	"BD": Asia,
+	"BE": Europe,
+	"BF": Africa,
+	"BG": Europe,
+	"BH": Asia,
+	"BI": Africa,
+	"BJ": Africa,
+	"BL": NorthAmerica,
+	"BM": NorthAmerica,
+	"BN": Asia,
+	"BO": SouthAmerica,
+	"BQ": NorthAmerica,
+	"BR": SouthAmerica,
+	"BS": NorthAmerica,
+	"BT": Asia,
+	"BV": Antarctica,
+	"BW": Africa,
+	"BY": Europe,
+	"BZ": NorthAmerica,
+	"CA": NorthAmerica,
+	"CC": Asia,
+	"CD": Africa,
+	"CF": Africa,
+	"CG": Africa,
+	"CH": Europe,
+	"CI": Africa,
+	"CK": Oceania,
+	"CL": SouthAmerica,
+	"CM": Africa,
+	"CN": Asia,
+	"CO": SouthAmerica,
+	"CR": NorthAmerica,
+	"CU": NorthAmerica,
+	"CV": Africa,
+	"CW": NorthAmerica,
+	"CX": Asia,
+	"CY": Asia,
+	"CZ": Europe,
+	"DE": Europe,
+	"DJ": Africa,
+	"DK": Europe,
+	"DM": NorthAmerica,
+	"DO": NorthAmerica,
+	"DZ": Africa,
+	"EC": SouthAmerica,
+	"EE": Europe,
+	"EG": Africa,
+	"EH": Africa,
+	"ER": Africa,
+	"ES": Europe,
+	"ET": Africa,
+	"FI": Europe,
+	"FJ": Oceania,
+	"FK": SouthAmerica,
+	"FM": Oceania,
+	"FO": Europe,
+	"FR": Europe,
+	"GA": Africa,
+	"GB": Europe,
+	"GD": NorthAmerica,
+	"GE": Asia,
+	"GF": SouthAmerica,
+	"GG": Europe,
+	"GH": Africa,
+	"GI": Europe,
+	"GL": NorthAmerica,
+	"GM": Africa,
+	"GN": Africa,
+	"GP": NorthAmerica,
+	"GQ": Africa,
+	"GR": Europe,
+	"GS": Antarctica,
+	"GT": NorthAmerica,
+	"GU": Oceania,
+	"GW": Africa,
+	"GY": SouthAmerica,
+	"HK": Asia,
+	"HM": Antarctica,
+	"HN": NorthAmerica,
+	"HR": Europe,
+	"HT": NorthAmerica,
+	"HU": Europe,
+	"ID": Asia,
+	"IE": Europe,
+	"IL": Asia,
+	"IM": Europe,
+	"IN": Asia,
+	"IO": Africa,
+	"IQ": Asia,
+	"IR": Asia,
+	"IS": Europe,
+	"IT": Europe,
+	"JE": Europe,
+	"JM": NorthAmerica,
+	"JO": Asia,
+	"JP": Asia,
+	"KE": Africa,
+	"KG": Asia,
+	"KH": Asia,
+	"KI": Oceania,
+	"KM": Africa,
+	"KN": NorthAmerica,
+	"KP": Asia,
+	"KR": Asia,
+	"KW": Asia,
+	"KY": NorthAmerica,
+	"KZ": Asia,
+	"LA": Asia,
+	"LB": Asia,
+	"LC": NorthAmerica,
+	"LI": Europe,
+	"LK": Asia,
+	"LR": Africa,
+	"LS": Africa,
+	"LT": Europe,
+	"LU": Europe,
+	"LV": Europe,
+	"LY": Africa,
+	"MA": Africa,
+	"MC": Europe,
+	"MD": Europe,
+	"ME": Europe,
+	"MF": NorthAmerica,
+	"MG": Africa,
+	"MH": Oceania,
+	"MK": Europe,
+	"ML": Africa,
+	"MM": Asia,
+	"MN": Asia,
+	"MO": Asia,
+	"MP": Oceania,
+	"MQ": NorthAmerica,
+	"MR": Africa,
+	"MS": NorthAmerica,
+	"MT": Europe,
+	"MU": Africa,
+	"MV": Asia,
+	"MW": Africa,
+	"MX": NorthAmerica,
+	"MY": Asia,
+	"MZ": Africa,
+	"NA": Africa,
+	"NC": Oceania,
+	"NE": Africa,
+	"NF": Oceania,
+	"NG": Africa,
+	"NI": NorthAmerica,
+	"NL": Europe,
+	"NO": Europe,
+	"NP": Asia,
+	"NR": Oceania,
+	"NU": Oceania,
+	"NZ": Oceania,
+	"OM": Asia,
+	"PA": NorthAmerica,
+	"PE": SouthAmerica,
+	"PF": Oceania,
+	"PG": Oceania,
+	"PH": Asia,
+	"PK": Asia,
+	"PL": Europe,
+	"PM": NorthAmerica,
+	"PN": Oceania,
+	"PR": NorthAmerica,
+	"PS": Asia,
+	"PT": Europe,
+	"PW": Oceania,
+	"PY": SouthAmerica,
+	"QA": Asia,
+	"RE": Africa,
+	"RO": Europe,
+	"RS": Europe,
+	"RU": Europe,
+	"RW": Africa,
+	"SA": Asia,
+	"SB": Oceania,
+	"SC": Africa,
+	"SD": Africa,
+	"SE": Europe,
+	"SG": Asia,
+	"SH": Africa,
+	"SI": Europe,
+	"SJ": Europe,
+	"SK": Europe,
+	"SL": Africa,
+	"SM": Europe,
+	"SN": Africa,
+	"SO": Africa,
+	"SR": SouthAmerica,
+	"SS": Africa,
+	"ST": Africa,
+	"SV": NorthAmerica,
+	"SX": NorthAmerica,
+	"SY": Asia,
+	"SZ": Africa,
+	"TC": NorthAmerica,
+	"TD": Africa,
+	"TF": Antarctica,
+	"TG": Africa,
+	"TH": Asia,
+	"TJ": Asia,
+	"TK": Oceania,
+	"TL": Asia,
+	"TM": Asia,
+	"TN": Africa,
+	"TO": Oceania,
+	"TR": Asia,
+	"TT": NorthAmerica,
+	"TV": Oceania,
+	"TW": Asia,
+	"TZ": Africa,
+	"UA": Europe,
+	"UG": Africa,
+	"UM": NorthAmerica,
+	"US": NorthAmerica,
+	"UY": SouthAmerica,
+	"UZ": Asia,
+	"VA": Europe,
+	"VC": NorthAmerica,
+	"VE": SouthAmerica,
+	"VG": NorthAmerica,
+	"VI": NorthAmerica,
+	"VN": Asia,
+	"VU": Oceania,
+	"WF": Oceania,
+	"WS": Oceania,
+	"XK": Europe,
+	"YE": Asia,
+	"YT": Africa,
+	"ZA": Africa,
+	"ZM": Africa,
+	"ZW": Africa,
+}
+
+// ContinentOf returns the continent of an ISO 3166-1 alpha-2 code, or an
+// empty one if it is unknown.
+func ContinentOf(code string) Continent {
+	return continents[strings.ToUpper(code)]
+}
+
+// Flag returns the flag emoji of the visitors country.
+func (v Visitor) Flag() string {
+	if len(v.CountryCode) != 2 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, r := range strings.ToUpper(v.CountryCode) {
+		if r < 'A' || r > 'Z' {
+			return ""
+		}
+		b.WriteRune(r - 'A' + '\U0001F1E6')
+	}
+	return b.String()
+}
+
+// Continent returns the continent of the visitors country.
+func (v Visitor) Continent() Continent {
+	return ContinentOf(v.CountryCode)
+}
+
+// VisitorsByContinent groups visitors by continent keeping their order,
+// visitors of unknown countries are grouped under an empty continent.
+func (s *Site) VisitorsByContinent() map[Continent][]Visitor {
+	g := make(map[Continent][]Visitor)
+	for _, v := range s.Visitors {
+		g[v.Continent()] = append(g[v.Continent()], v)
+	}
+	return g
+}
+
+// ContinentShares adds percents of visitors up by continent.
+func (s *Site) ContinentShares() map[Continent]Percent {
+	shares := make(map[Continent]Percent)
+	for c, vs := range s.VisitorsByContinent() {
+		shares[c] = Sum(VisitorPercents(vs)...)
+	}
+	return shares
+}
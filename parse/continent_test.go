@@ -0,0 +1,37 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlag(t *testing.T) {
+	if f := (Visitor{CountryCode: "RU"}).Flag(); f != "\U0001F1F7\U0001F1FA" {
+		t.Fatalf("unexpected flag %q", f)
+	}
+	if f := (Visitor{Country: "Unknown"}).Flag(); f != "" {
+		t.Fatalf("want no flag, got %q", f)
+	}
+}
+
+func TestContinentShares(t *testing.T) {
+	want := map[Continent]Percent{
+		Europe:       {"88.9%", 88.9},
+		NorthAmerica: {"1.3%", 1.3},
+	}
+	got := successTestSite.ContinentShares()
+	if len(got) != len(want) || got[NorthAmerica] != want[NorthAmerica] {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	if v := got[Europe].Value; v < 88.89 || v > 88.91 {
+		t.Fatalf("want 88.9%% of Europe, got %v", got[Europe])
+	}
+
+	var countries []string
+	for _, v := range successTestSite.VisitorsByContinent()[Europe] {
+		countries = append(countries, v.Country)
+	}
+	if want := []string{"Russia", "Netherlands", "Germany", "United Kingdom"}; !reflect.DeepEqual(countries, want) {
+		t.Fatalf("want %v, got %v", want, countries)
+	}
+}
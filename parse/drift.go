@@ -0,0 +1,57 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/metrics"
+)
+
+// DefaultLayoutThreshold is how many sections may be missing before Parse
+// reports a layout change.
+const DefaultLayoutThreshold = 3
+
+// ErrLayoutChanged is matched by a LayoutError.
+var ErrLayoutChanged = errors.New("asip: page layout changed")
+
+// LayoutError is returned when too many sections are missing from a page,
+// most likely because its format changed.
+type LayoutError struct {
+	Missing []string
+}
+
+func (e *LayoutError) Error() string {
+	return fmt.Sprintf("%v: no %s found", ErrLayoutChanged, strings.Join(e.Missing, ", "))
+}
+
+// Unwrap makes errors.Is match ErrLayoutChanged.
+func (e *LayoutError) Unwrap() error {
+	return ErrLayoutChanged
+}
+
+// selectorResults counts sections of ranked pages by whether they matched,
+// so a field starting to fail shows up on dashboards.
+var selectorResults = metrics.Default.NewCounterVec("asip_selector_results_total", "Sections of ranked pages by whether their selector matched.", "layout", "section", "result")
+
+// probe is a selector expected to match on every ranked page of a layout.
+type probe struct {
+	field    string
+	selector string
+}
+
+// missing returns fields of l whose selectors matched nothing, counting
+// them in selectorResults.
+func missing(d *goquery.Document, l *layout) []string {
+	var m []string
+	for _, p := range l.probes {
+		if d.FindMatcher(sel(l.effective(p))).Length() == 0 {
+			m = append(m, p.field)
+			selectorResults.With(l.version, p.field, "empty").Inc()
+			continue
+		}
+		selectorResults.With(l.version, p.field, "matched").Inc()
+	}
+	return m
+}
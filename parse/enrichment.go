@@ -0,0 +1,67 @@
+package parse
+
+// DNSRecords are resolved records of a single host, filled in by an enricher
+// rather than parsed from the page.
+type DNSRecords struct {
+	Host string
+	A    []string
+	AAAA []string
+	NS   []string
+	MX   []string
+}
+
+// Enrichment holds data gathered from third-party services.
+type Enrichment struct {
+	SecurityTrails *SecurityTrails
+	URLScan        *URLScan
+	VirusTotal     *VirusTotal
+	GeoIP          *GeoIP
+}
+
+// SecurityTrails is a domain summary from the SecurityTrails API.
+type SecurityTrails struct {
+	Subdomains  []string
+	HistoricalA []HistoricalRecord
+}
+
+// HistoricalRecord is a set of DNS values seen together over a period.
+type HistoricalRecord struct {
+	Values        []string
+	Organizations []string
+	FirstSeen     string
+	LastSeen      string
+}
+
+// URLScan is a urlscan.io scan of the domain.
+type URLScan struct {
+	UUID       string
+	ResultURL  string
+	Screenshot string
+	Pending    bool // submitted but not finished yet, no verdicts
+	Malicious  bool
+	Score      int
+	Categories []string
+}
+
+// VirusTotal is a VirusTotal domain report.
+type VirusTotal struct {
+	Malicious       int
+	Suspicious      int
+	Harmless        int
+	Undetected      int
+	Reputation      int
+	Categories      map[string]string // by vendor
+	PopularityRanks map[string]uint   // by rank provider
+}
+
+// GeoIP is where addresses of the domain are hosted.
+type GeoIP struct {
+	Hosts    []GeoIPHost
+	Mismatch bool // hosted outside the countries of visitors
+}
+
+// GeoIPHost is an address of the domain and its country.
+type GeoIPHost struct {
+	IP          string
+	CountryCode string // ISO 3166-1 alpha-2
+}
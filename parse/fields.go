@@ -0,0 +1,106 @@
+package parse
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// fieldSections are sections filling fields of Site, the fields missing are
+// filled outside of Parse.
+var fieldSections = map[string][]string{
+	"Title":               {"site title"},
+	"Description":         {"site description"},
+	"MainCountry":         {"country"},
+	"MainCountryCode":     {"country"},
+	"GlobalRank":          {"global rank"},
+	"LocalRank":           {"local rank"},
+	"RankSummary":         {"rank summary"},
+	"RankPercentile":      {"rank summary"},
+	"LinkingTotal":        {"linking total"},
+	"LinkingHistory":      {"linking history"},
+	"Trends":              {"trends", "linking history"},
+	"Visitors":            {"visitors"},
+	"Keywords":            {"keywords"},
+	"Upstreams":           {"upstream servers"},
+	"Related":             {"related sites"},
+	"Subdomains":          {"subdomains"},
+	"Categories":          {"categories"},
+	"CategoryPaths":       {"categories"},
+	"CategoryURLs":        {"categories"},
+	"LinksFrom":           {"linking sites"},
+	"PageviewsPerVisitor": {"trends"},
+	"BounceRate":          {"trends"},
+	"TrafficSources":      {"trends"},
+}
+
+// fieldAliases are short names of fields.
+var fieldAliases = map[string][]string{
+	"country": {"MainCountry", "MainCountryCode"},
+}
+
+// ParseFields resolves a comma separated list of fields of Site named like
+// GlobalRank or global_rank.
+func ParseFields(list string) ([]string, error) {
+	t := reflect.TypeOf(Site{})
+	var fields []string
+	for _, name := range strings.Split(list, ",") {
+		key := fieldKey(name)
+		if key == "" {
+			continue
+		}
+		if a, ok := fieldAliases[key]; ok {
+			fields = append(fields, a...)
+			continue
+		}
+
+		f, ok := t.FieldByNameFunc(func(n string) bool { return fieldKey(n) == key })
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", strings.TrimSpace(name))
+		}
+		fields = append(fields, f.Name)
+	}
+	return fields, nil
+}
+
+// WithFields parses only sections filling the fields, as resolved by
+// ParseFields, leaving the others empty.
+func WithFields(fields ...string) Option {
+	return func(o *options) {
+		o.fields = make(map[string]bool, len(fields))
+		for _, f := range fields {
+			o.fields[f] = true
+		}
+	}
+}
+
+// wanted tells whether the section of l fills any of the fields asked with
+// WithFields, sections unknown to l are registered ones filling Custom.
+func (o *options) wanted(l *layout, name string) bool {
+	if o.fields == nil {
+		return true
+	}
+	for f := range o.fields {
+		if slices.Contains(fieldSections[f], name) {
+			return true
+		}
+	}
+	return o.fields["Custom"] && !slices.ContainsFunc(l.sections, func(s section) bool { return s.name == name })
+}
+
+// SelectFields returns the fields of s keyed as they are encoded to JSON.
+func SelectFields(s *Site, fields []string) map[string]any {
+	v := reflect.ValueOf(s).Elem()
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		if fv := v.FieldByName(f); fv.IsValid() {
+			m[f] = fv.Interface()
+		}
+	}
+	return m
+}
+
+func fieldKey(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
@@ -0,0 +1,42 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields("global_rank, country,LinkingTotal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"GlobalRank", "MainCountry", "MainCountryCode", "LinkingTotal"}; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("want %v, got %v", want, fields)
+	}
+
+	if _, err := ParseFields("global_rank,popularity"); err == nil {
+		t.Fatal("want an unknown field error")
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	body, err := testDoc(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Parse(body, WithFields("GlobalRank", "MainCountry"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.GlobalRank != 506 || s.MainCountry != "Russia" {
+		t.Fatalf("want global rank 506 in Russia, got %d in %q", s.GlobalRank, s.MainCountry)
+	}
+	if s.LocalRank != 0 || s.Keywords != nil || s.Visitors != nil {
+		t.Fatalf("want other sections skipped, got %+v", s)
+	}
+
+	want := map[string]any{"GlobalRank": uint(506), "MainCountry": "Russia"}
+	if got := SelectFields(s, []string{"GlobalRank", "MainCountry"}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
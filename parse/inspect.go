@@ -0,0 +1,47 @@
+package parse
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Match is how a selector fared on a page.
+type Match struct {
+	Field    string
+	Selector string
+	Matches  int    // number of matched elements
+	Text     string // text of the matches with spaces collapsed
+}
+
+// Inspect reports how selectors of the layout of the page, detected or set
+// with WithLayout, match it, to diagnose breakage when the markup changes.
+func Inspect(body io.Reader, opts ...Option) (layout string, ms []Match, err error) {
+	o := newOptions(opts)
+	d, err := goquery.NewDocumentFromReader(body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	l := detect(d)
+	if o.layout != "" {
+		if l = findLayout(o.layout); l == nil {
+			return "", nil, fmt.Errorf("asip: unknown layout %q", o.layout)
+		}
+	}
+
+	probes := append([]probe{{"layout marker", l.marker}, {"no data", l.noData}}, l.probes...)
+	for _, p := range probes {
+		selector := l.effective(p)
+		s := d.FindMatcher(sel(selector))
+		ms = append(ms, Match{
+			Field:    p.field,
+			Selector: selector,
+			Matches:  s.Length(),
+			Text:     strings.Join(strings.Fields(s.Text()), " "),
+		})
+	}
+	return l.version, ms, nil
+}
@@ -0,0 +1,37 @@
+package parse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	body, err := testDoc(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, ms, err := Inspect(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != Layout2017 || len(ms) != len(layout2017.probes)+2 {
+		t.Fatalf("want %d selectors of layout %s, got %d of %q", len(layout2017.probes)+2, Layout2017, len(ms), v)
+	}
+
+	for _, m := range ms {
+		switch m.Field {
+		case "no data":
+			if m.Matches != 0 {
+				t.Fatalf("want no data selector unmatched, got %+v", m)
+			}
+		case "global rank":
+			if m.Matches != 1 || m.Text != "506" {
+				t.Fatalf("want global rank 506, got %+v", m)
+			}
+		}
+	}
+
+	if _, _, err := Inspect(strings.NewReader("<html></html>"), WithLayout("1999")); err == nil {
+		t.Fatal("want an unknown layout error")
+	}
+}
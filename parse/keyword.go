@@ -0,0 +1,67 @@
+package parse
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Keywords are top keywords from search engines.
+type Keywords []Keyword
+
+// NormalizeKeyword composes the word to Unicode NFC, lowercases it and
+// collapses whitespace.
+func NormalizeKeyword(w string) string {
+	return strings.Join(strings.Fields(strings.ToLower(norm.NFC.String(w))), " ")
+}
+
+// Normalize returns keywords with normalized words.
+func (ks Keywords) Normalize() Keywords {
+	n := make(Keywords, len(ks))
+	for i, k := range ks {
+		n[i] = Keyword{Word: NormalizeKeyword(k.Word), Percent: k.Percent}
+	}
+	return n
+}
+
+// Dedupe merges keywords with the same word adding their percents up, the
+// first occurrence keeps its place.
+func (ks Keywords) Dedupe() Keywords {
+	var (
+		d   Keywords
+		pos = make(map[string]int)
+	)
+	for _, k := range ks {
+		i, ok := pos[k.Word]
+		if !ok {
+			pos[k.Word] = len(d)
+			d = append(d, k)
+			continue
+		}
+		d[i].Percent = Sum(d[i].Percent, k.Percent)
+	}
+	return d
+}
+
+// TotalShare adds percents of search traffic of the keywords up.
+func (ks Keywords) TotalShare() Percent {
+	ps := make([]Percent, len(ks))
+	for i, k := range ks {
+		ps[i] = k.Percent
+	}
+	return Sum(ps...)
+}
+
+// TopN returns up to n keywords with the biggest share of search traffic.
+func (ks Keywords) TopN(n int) Keywords {
+	top := make(Keywords, len(ks))
+	copy(top, ks)
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].Percent.Value > top[j].Percent.Value
+	})
+	if n < len(top) {
+		top = top[:max(n, 0)]
+	}
+	return top
+}
@@ -0,0 +1,82 @@
+package parse
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestNormalizeKeyword(t *testing.T) {
+	tests := map[string]string{
+		"  Sberbank   Online ":           "sberbank online",
+		"\u0415\u0308\u041b\u041a\u0410": "\u0451\u043b\u043a\u0430", // decomposed Ё
+		"Cafe\u0301":                     "caf\u00e9",
+		"Vie\u0323\u0302t":               "vi\u1ec7t", // two marks
+		"Vie\u0302\u0323t":               "vi\u1ec7t", // reordered marks
+		"\u1100\u1161":                   "\uac00",    // Hangul jamo
+	}
+	for in, want := range tests {
+		if got := NormalizeKeyword(in); got != want {
+			t.Fatalf("%q: want %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestKeywordsDedupe(t *testing.T) {
+	ks := Keywords{
+		{"Sberbank", Percent{"10%", 10}},
+		{"сбер", Percent{"5%", 5}},
+		{"sberbank ", Percent{"2.5%", 2.5}},
+	}
+
+	want := Keywords{
+		{"sberbank", Percent{"12.5%", 12.5}},
+		{"сбер", Percent{"5%", 5}},
+	}
+	if got := ks.Normalize().Dedupe(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestKeywordsTopN(t *testing.T) {
+	ks := Keywords{
+		{"sberbank", Percent{"2.65%", 2.65}},
+		{"сбербанк онлайн", Percent{"49.69%", 49.69}},
+		{"сбербанк", Percent{"7.87%", 7.87}},
+	}
+
+	want := Keywords{
+		{"сбербанк онлайн", Percent{"49.69%", 49.69}},
+		{"сбербанк", Percent{"7.87%", 7.87}},
+	}
+	if got := ks.TopN(2); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+	if got := ks.TopN(2).TotalShare().Value; math.Abs(got-57.56) > 1e-9 {
+		t.Fatalf("want total share 57.56, got %v", got)
+	}
+}
+
+func TestKeywordsShareColumn(t *testing.T) {
+	page := `<table id="keywords_top_keywords_table">` +
+		`<thead><tr><th>Keyword</th><th>% of Search Traffic</th><th>Share of Voice</th></tr></thead>` +
+		`<tbody><tr><td><span>1.</span><span>sberbank</span></td><td><span>2.65%</span></td><td>0.5%</td></tr></tbody>` +
+		`</table>`
+
+	d, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ks, err := keywords(d, newOptions(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Keywords{{"sberbank", Percent{"2.65%", 2.65}}}
+	if !reflect.DeepEqual(ks, want) {
+		t.Fatalf("want %v, got %v", want, ks)
+	}
+}
@@ -0,0 +1,89 @@
+package parse
+
+import "github.com/PuerkitoBio/goquery"
+
+// Layout2017 is the version of the Website Info page served since 2017.
+const Layout2017 = "2017"
+
+// layout is a version of the Website Info page parsed by its own sections.
+type layout struct {
+	version  string
+	marker   string // matches pages of the layout only
+	noData   string // matches pages of domains out of top 1M
+	probes   []probe
+	sections []section
+}
+
+var layout2017 = &layout{
+	version: Layout2017,
+	marker:  "section#alx-content",
+	noData:  seNoData,
+	probes: []probe{
+		{"global rank", seGlobalRank},
+		{"local rank", seLocalRank},
+		{"country", seCountry},
+		{"linking total", seLinkingTotal},
+		{"site title", seTitle},
+		{"site description", seDescription},
+		{"visitors", seVisitors},
+		{"keywords", seKeywords},
+		{"upstream servers", seUpstreams},
+		{"linking sites", seLinks},
+		{"related sites", seRelated},
+		{"categories", seCategories},
+		{"subdomains", seSubdomains},
+	},
+	sections: sections2017,
+}
+
+var (
+	// layouts are detected in order.
+	layouts = []*layout{layout2021, layout2017, layoutLegacy}
+
+	// fallback is assumed when no layout is detected.
+	fallback = layout2017
+)
+
+// Layouts lists versions of the page Parse tells apart.
+func Layouts() []string {
+	vs := make([]string, len(layouts))
+	for i, l := range layouts {
+		vs[i] = l.version
+	}
+	return vs
+}
+
+// detect returns the layout whose marker d matches, or the fallback one.
+func detect(d *goquery.Document) *layout {
+	for _, l := range layouts {
+		if d.FindMatcher(sel(l.marker)).Length() > 0 {
+			return l
+		}
+	}
+	return fallback
+}
+
+// findLayout returns the layout of version v, nil if there is none.
+func findLayout(v string) *layout {
+	for _, l := range layouts {
+		if l.version == v {
+			return l
+		}
+	}
+	return nil
+}
+
+// rows returns elements matching row within the one of selector, like tr
+// of a table.
+func rows(d *goquery.Document, selector, row, field string) ([]*goquery.Selection, error) {
+	body := d.FindMatcher(sel(selector))
+	if body.Length() == 0 {
+		return nil, &FieldError{field}
+	}
+
+	var rs []*goquery.Selection
+	body.FindMatcher(sel(row)).Each(func(_ int, r *goquery.Selection) {
+		rs = append(rs, r)
+	})
+	return rs, nil
+}
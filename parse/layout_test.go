@@ -0,0 +1,29 @@
+package parse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectLayout(t *testing.T) {
+	body, err := testDoc(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Parse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Meta.Layout != Layout2017 {
+		t.Fatalf("want layout %s, got %q", Layout2017, s.Meta.Layout)
+	}
+
+	if _, err := Parse(strings.NewReader("<html></html>"), WithLayout("1999")); err == nil || !strings.Contains(err.Error(), "unknown layout") {
+		t.Fatalf("want an unknown layout error, got %v", err)
+	}
+
+	if want := []string{Layout2021, Layout2017, LayoutLegacy}; !reflect.DeepEqual(Layouts(), want) {
+		t.Fatalf("want %v, got %v", want, Layouts())
+	}
+}
@@ -0,0 +1,152 @@
+package parse
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// LayoutLegacy is the version of the Website Info page served before 2017,
+// found in archived snapshots and old crawls.
+const LayoutLegacy = "legacy"
+
+const (
+	leGlobalRank   = "div#siteStats div.globalRank div.data"
+	leLocalRank    = "div#siteStats div.regionalRank div.data"
+	leCountry      = "div#siteStats div.regionalRank h4 a"
+	leLinkingTotal = "div#siteStats div.linksIn div.data"
+	leTitle        = "div#siteDescription h2"
+	leDescription  = "div#siteDescription p"
+	leVisitors     = "table#visitors-by-country tbody"
+	leKeywords     = "table#top-keywords tbody"
+	leUpstreams    = "table#upstream-sites tbody"
+	leLinks        = "table#linking-sites tbody"
+	leRelated      = "table#related-links tbody"
+	leCategories   = "table#categories tbody"
+	leNoData       = "div#siteStats div.no-data"
+)
+
+var layoutLegacy = &layout{
+	version: LayoutLegacy,
+	marker:  "div#siteStats",
+	noData:  leNoData,
+	probes: []probe{
+		{"global rank", leGlobalRank},
+		{"local rank", leLocalRank},
+		{"country", leCountry},
+		{"linking total", leLinkingTotal},
+		{"site title", leTitle},
+		{"visitors", leVisitors},
+		{"keywords", leKeywords},
+		{"upstream servers", leUpstreams},
+		{"linking sites", leLinks},
+		{"related sites", leRelated},
+		{"categories", leCategories},
+	},
+	sections: sectionsLegacy,
+}
+
+// sectionsLegacy fill a Site from a page of the legacy layout, which has no
+// subdomains and shows no local ranks of visitors.
+var sectionsLegacy = []section{
+	{"global rank", func(d *goquery.Document, o *options, s *Site) error {
+		gr, err := getUint(d, o.selector(leGlobalRank), "global rank")
+		s.GlobalRank = uint(gr)
+		return err
+	}},
+	{"local rank", func(d *goquery.Document, o *options, s *Site) error {
+		lr, err := getUint(d, o.selector(leLocalRank), "local rank")
+		s.LocalRank = uint(lr)
+		return err
+	}},
+	{"country", func(d *goquery.Document, o *options, s *Site) error {
+		country, err := getString(d, o.selector(leCountry), "country")
+		if err != nil {
+			return err
+		}
+		s.MainCountry = country
+		s.MainCountryCode = o.countryCode(d.FindMatcher(sel(o.selector(leCountry))).First(), country)
+		return nil
+	}},
+	{"linking total", func(d *goquery.Document, o *options, s *Site) error {
+		lt, err := getUint(d, o.selector(leLinkingTotal), "linking total")
+		s.LinkingTotal = uint(lt)
+		return err
+	}},
+	{"site title", func(d *goquery.Document, o *options, s *Site) (err error) {
+		s.Title, err = getString(d, o.selector(leTitle), "site title")
+		return err
+	}},
+	{"site description", func(d *goquery.Document, o *options, s *Site) error {
+		// sites without a listing had no description at all
+		s.Description = strings.TrimSpace(d.FindMatcher(sel(leDescription)).Text())
+		return nil
+	}},
+	{"visitors", func(d *goquery.Document, o *options, s *Site) error {
+		rows, err := rows(d, o.selector(leVisitors), "tr", "visitors")
+		if err != nil {
+			return err
+		}
+		var v []Visitor
+		for _, tr := range rows {
+			a := tr.FindMatcher(sel("td a")).First()
+			country := strings.TrimSpace(a.Text())
+			p := newPercent(tr.FindMatcher(sel("td:last-child")).Text())
+			v = append(v, Visitor{Country: country, CountryCode: o.countryCode(a, country), Percent: p})
+		}
+		s.Visitors = o.topVisitors(v)
+		return nil
+	}},
+	{"keywords", func(d *goquery.Document, o *options, s *Site) error {
+		rows, err := rows(d, o.selector(leKeywords), "tr", "keywords")
+		if err != nil {
+			return err
+		}
+		for _, tr := range rows {
+			s.Keywords = append(s.Keywords, Keyword{
+				Word:    strings.TrimSpace(tr.FindMatcher(sel("td:first-child")).Text()),
+				Percent: newPercent(tr.FindMatcher(sel("td:last-child")).Text()),
+			})
+		}
+		if o.normalizeKeywords {
+			s.Keywords = s.Keywords.Normalize().Dedupe()
+		}
+		return nil
+	}},
+	{"upstream servers", func(d *goquery.Document, o *options, s *Site) error {
+		rows, err := rows(d, o.selector(leUpstreams), "tr", "upstream servers")
+		if err != nil {
+			return err
+		}
+		for _, tr := range rows {
+			a := tr.FindMatcher(sel("td a")).First()
+			href, _ := a.Attr("href")
+			s.Upstreams = append(s.Upstreams, Upstream{
+				Site:    strings.TrimSpace(a.Text()),
+				URL:     absURL(href),
+				Percent: newPercent(tr.FindMatcher(sel("td:last-child")).Text()),
+			})
+		}
+		return nil
+	}},
+	{"linking sites", func(d *goquery.Document, o *options, s *Site) error {
+		rows, err := rows(d, o.selector(leLinks), "tr", "linking sites")
+		if err != nil {
+			return err
+		}
+		for _, tr := range rows {
+			a := tr.FindMatcher(sel("td:last-child a")).First()
+			page, _ := a.Attr("href")
+			rel, _ := a.Attr("rel")
+			s.LinksFrom = append(s.LinksFrom, Link{
+				Site: strings.TrimSpace(tr.FindMatcher(sel("td:first-child")).Text()),
+				Page: page,
+				Text: strings.TrimSpace(a.Text()),
+				Rel:  strings.Fields(rel),
+			})
+		}
+		return nil
+	}},
+	{"related sites", relatedSection(leRelated)},
+	{"categories", categoriesSection(leCategories)},
+}
@@ -0,0 +1,45 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLegacyLayout(t *testing.T) {
+	body, err := testDoc("testdata/legacy.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Parse(body, WithLayoutThreshold(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Site{
+		Title:           "Сбербанк России",
+		Description:     "Сведения об истории создания, руководстве, филиалах и подразделениях.",
+		MainCountry:     "Russia",
+		MainCountryCode: "RU",
+		GlobalRank:      1204,
+		LocalRank:       31,
+		LinkingTotal:    6370,
+		Visitors: []Visitor{
+			{Country: "Russia", CountryCode: "RU", Percent: Percent{"88.6%", 88.6}},
+			{Country: "Ukraine", CountryCode: "UA", Percent: Percent{"4.1%", 4.1}},
+		},
+		Keywords: Keywords{
+			{Word: "сбербанк", Percent: Percent{"31.20%", 31.2}},
+			{Word: "сбербанк онлайн", Percent: Percent{"12.05%", 12.05}},
+		},
+		Upstreams:     []Upstream{{Site: "yandex.ru", URL: "https://www.alexa.com/siteinfo/yandex.ru", Percent: Percent{"18.3%", 18.3}}},
+		LinksFrom:     []Link{{Site: "cbr.ru", Page: "http://cbr.ru/banks/", Text: "Banks", Rel: []string{"nofollow"}}},
+		Related:       []string{"vtb.ru"},
+		CategoryPaths: []CategoryPath{{"World", "Russian"}},
+		Categories:    []string{"World", "Russian"},
+		CategoryURLs:  []string{"https://www.alexa.com/topsites/category/Top/World", "https://www.alexa.com/topsites/category/Top/World/Russian"},
+		Meta:          Meta{Layout: LayoutLegacy},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Fatalf("want %+v, got %+v", want, s)
+	}
+}
@@ -0,0 +1,51 @@
+package parse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// groupSeparators are thousand separators of locales the pages are rendered
+// in, besides commas and dots.
+var groupSeparators = strings.NewReplacer(
+	" ", "",
+	"\u00a0", "", // no-break space
+	"\u202f", "", // narrow no-break space
+	"'", "",
+	"\u2019", "", // right single quotation mark
+)
+
+// decimalSeparators are stripped off whole numbers.
+var decimalSeparators = strings.NewReplacer(",", "", ".", "")
+
+// parseInt parses a whole number with any thousand separators like
+// 1,111,111, 1.111.111 or 1 111 111.
+func parseInt(s string) (uint64, error) {
+	s = groupSeparators.Replace(s)
+	s = decimalSeparators.Replace(s)
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// parseDecimal parses a number with either a dot or a comma as a decimal
+// separator, like 12.5, 12,5 or 1.234,5. A separator occurring once is
+// taken as decimal.
+func parseDecimal(s string) (float64, error) {
+	s = groupSeparators.Replace(s)
+
+	dec := strings.LastIndexAny(s, ",.")
+	if dec >= 0 && strings.Count(s, s[dec:dec+1]) > 1 {
+		dec = -1 // 1,234,567 has no decimals
+	}
+
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case i == dec:
+			b.WriteByte('.')
+		case r == ',' || r == '.':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return strconv.ParseFloat(b.String(), 64)
+}
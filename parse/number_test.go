@@ -0,0 +1,47 @@
+package parse
+
+import "testing"
+
+func TestParseInt(t *testing.T) {
+	for _, s := range []string{"1111111", "1,111,111", "1.111.111", "1 111 111", "1\u00a0111\u00a0111", "1'111'111"} {
+		n, err := parseInt(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 1111111 {
+			t.Fatalf("%q: want 1111111, got %d", s, n)
+		}
+	}
+
+	if _, err := parseInt("n/a"); err == nil {
+		t.Fatal("want an error parsing n/a")
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := map[string]float64{
+		"12.5":      12.5,
+		"12,5":      12.5,
+		"45":        45,
+		"1.234,5":   1234.5,
+		"1,234.5":   1234.5,
+		"1 234,5":   1234.5,
+		"1,234,567": 1234567,
+	}
+	for s, want := range tests {
+		got, err := parseDecimal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("%q: want %v, got %v", s, want, got)
+		}
+	}
+}
+
+func TestNewPercentLocale(t *testing.T) {
+	p := newPercent(" 12,5 % ")
+	if p.Value != 12.5 || p.Raw != "12,5 %" {
+		t.Fatalf("unexpected percent %+v", p)
+	}
+}
@@ -0,0 +1,100 @@
+package parse
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DefaultVisitorRows is how many visitors rows are kept by default, as many
+// as the free view shows.
+const DefaultVisitorRows = 5
+
+// Option customizes Parse.
+type Option func(*options)
+
+type options struct {
+	visitorRows       int
+	normalizeKeywords bool
+	layoutThreshold   int
+	countryCodes      map[string]string
+	layout            string
+	fields            map[string]bool
+	overrides         map[string]string // of the layout being parsed
+}
+
+// WithVisitorRows keeps up to n visitors rows when the subscription view
+// exposes more of them, n < 0 keeps all of them.
+func WithVisitorRows(n int) Option {
+	return func(o *options) {
+		o.visitorRows = n
+	}
+}
+
+// WithNormalizedKeywords normalizes keywords and merges the ones equal
+// after that.
+func WithNormalizedKeywords() Option {
+	return func(o *options) {
+		o.normalizeKeywords = true
+	}
+}
+
+// WithLayoutThreshold reports ErrLayoutChanged once more than n sections
+// are missing from a page, n < 0 disables the check.
+func WithLayoutThreshold(n int) Option {
+	return func(o *options) {
+		o.layoutThreshold = n
+	}
+}
+
+// WithCountryCodes overrides ISO codes of countries by their names as shown
+// on the page, matched case-insensitively.
+func WithCountryCodes(overrides map[string]string) Option {
+	return func(o *options) {
+		if o.countryCodes == nil {
+			o.countryCodes = make(map[string]string)
+		}
+		for name, code := range overrides {
+			o.countryCodes[strings.ToLower(name)] = code
+		}
+	}
+}
+
+// WithLayout parses pages as of the layout version v, one of Layouts,
+// instead of detecting it, e.g. for archived pages lacking the markers.
+func WithLayout(v string) Option {
+	return func(o *options) {
+		o.layout = v
+	}
+}
+
+// selector returns the override of a built-in selector of the layout being
+// parsed, or the selector itself.
+func (o *options) selector(s string) string {
+	if r, ok := o.overrides[s]; ok {
+		return r
+	}
+	return s
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{visitorRows: DefaultVisitorRows, layoutThreshold: DefaultLayoutThreshold}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// countryCode returns an override of the country, the code its link points
+// to, like /topsites/countries/RU, or the one known by its name.
+func (o *options) countryCode(a *goquery.Selection, name string) string {
+	if code, ok := o.countryCodes[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return code
+	}
+	if href, ok := a.Attr("href"); ok {
+		if code, ok := strings.CutPrefix(href, "/topsites/countries/"); ok && len(code) == 2 {
+			return strings.ToUpper(code)
+		}
+	}
+	return CountryCode(name)
+}
@@ -0,0 +1,143 @@
+package parse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/andybalholm/cascadia"
+)
+
+// SelectorOverrides replace selectors of fields, as listed by Inspect, by
+// layout version, stored as JSON like
+//
+//	{"2017": {"global rank": "div.rank strong"}}
+//
+// so a fix of a layout change can be deployed without a release.
+type SelectorOverrides map[string]map[string]string
+
+// replacements keeps the overrides in use.
+var replacements atomic.Pointer[SelectorOverrides]
+
+// LoadSelectorOverrides reads overrides.
+func LoadSelectorOverrides(r io.Reader) (SelectorOverrides, error) {
+	var o SelectorOverrides
+	if err := json.NewDecoder(r).Decode(&o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// SetSelectorOverrides swaps in selectors of o for every Parse at once, nil
+// restores the built-in ones. Nothing changes if any of them is invalid.
+func SetSelectorOverrides(o SelectorOverrides) error {
+	r := make(SelectorOverrides)
+	for v, fields := range o {
+		l := findLayout(v)
+		if l == nil {
+			return fmt.Errorf("asip: unknown layout %q", v)
+		}
+	fields:
+		for field, selector := range fields {
+			if _, err := cascadia.Compile(selector); err != nil {
+				return fmt.Errorf("asip: layout %s: %s: %w", v, field, err)
+			}
+			for _, p := range l.probes {
+				if p.field == field {
+					if r[v] == nil {
+						r[v] = make(map[string]string)
+					}
+					r[v][field] = selector
+					continue fields
+				}
+			}
+			return fmt.Errorf("asip: layout %s: unknown field %q", v, field)
+		}
+	}
+
+	replacements.Store(&r)
+	return nil
+}
+
+// effective returns the selector in use for the probe of l.
+func (l *layout) effective(p probe) string {
+	if r := replacements.Load(); r != nil {
+		if s, ok := (*r)[l.version][p.field]; ok {
+			return s
+		}
+	}
+	return p.selector
+}
+
+// overrides maps built-in selectors of probes of l to the overriding ones,
+// so an override of one layout leaves the others alone.
+func (l *layout) overrides() map[string]string {
+	r := replacements.Load()
+	if r == nil || len((*r)[l.version]) == 0 {
+		return nil
+	}
+	m := make(map[string]string)
+	for _, p := range l.probes {
+		if s, ok := (*r)[l.version][p.field]; ok {
+			m[p.selector] = s
+		}
+	}
+	return m
+}
+
+// LoadSelectorOverridesFile sets overrides stored in the file.
+func LoadSelectorOverridesFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	o, err := LoadSelectorOverrides(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return SetSelectorOverrides(o)
+}
+
+// WatchSelectorOverrides sets overrides of the file and sets them anew each
+// time it changes, checking it every interval until ctx is done. Errors of
+// changes are passed to onError, if set, keeping the last valid overrides.
+func WatchSelectorOverrides(ctx context.Context, name string, every time.Duration, onError func(error)) error {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	if err := LoadSelectorOverridesFile(name); err != nil {
+		return err
+	}
+
+	go func() {
+		t := time.NewTicker(every)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+
+			cur, err := os.Stat(name)
+			if err == nil && cur.ModTime().Equal(fi.ModTime()) && cur.Size() == fi.Size() {
+				continue
+			}
+			if err == nil {
+				fi = cur
+				err = LoadSelectorOverridesFile(name)
+			}
+			if err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,107 @@
+package parse
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var rankProbe = probe{"global rank", seGlobalRank}
+
+func TestSetSelectorOverrides(t *testing.T) {
+	defer SetSelectorOverrides(nil)
+
+	o, err := LoadSelectorOverrides(strings.NewReader(`{"2017": {"global rank": "span.countryRank span div strong"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetSelectorOverrides(o); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := testDoc(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Parse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.GlobalRank != 17 {
+		t.Fatalf("want global rank 17 of the overriding selector, got %d", s.GlobalRank)
+	}
+
+	for _, o := range []SelectorOverrides{
+		{"1999": {"global rank": "div"}},
+		{"2017": {"popularity": "div"}},
+		{"2017": {"global rank": "div["}},
+	} {
+		if err := SetSelectorOverrides(o); err == nil {
+			t.Fatalf("%v: want an error", o)
+		}
+	}
+	if got := layout2017.effective(rankProbe); got != "span.countryRank span div strong" {
+		t.Fatalf("want overrides kept after an invalid set, got %q", got)
+	}
+}
+
+func TestSelectorOverridesOfLayout(t *testing.T) {
+	defer SetSelectorOverrides(nil)
+	defer func(ls []*layout) { layouts = ls }(layouts)
+
+	// a layout sharing the global rank selector of the 2017 one
+	layouts = append(layouts[:len(layouts):len(layouts)], &layout{
+		version:  "test",
+		marker:   "div#test",
+		probes:   []probe{rankProbe},
+		sections: sections2017,
+	})
+	if err := SetSelectorOverrides(SelectorOverrides{"test": {"global rank": "span.countryRank span div strong"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for v, want := range map[string]uint{Layout2017: 506, "test": 17} {
+		body, err := testDoc(successTestDocLoc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := Parse(body, WithLayout(v))
+		body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.GlobalRank != want {
+			t.Fatalf("%s: want global rank %d, got %d", v, want, s.GlobalRank)
+		}
+	}
+}
+
+func TestWatchSelectorOverrides(t *testing.T) {
+	defer SetSelectorOverrides(nil)
+
+	name := filepath.Join(t.TempDir(), "selectors.json")
+	if err := os.WriteFile(name, []byte(`{"2017": {"global rank": "div.a"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := WatchSelectorOverrides(ctx, name, 5*time.Millisecond, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := layout2017.effective(rankProbe); got != "div.a" {
+		t.Fatalf("want div.a, got %q", got)
+	}
+
+	if err := os.WriteFile(name, []byte(`{"2017": {"global rank": "div.bb"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(time.Second); layout2017.effective(rankProbe) != "div.bb"; time.Sleep(5 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("want div.bb once the file changed, got %q", layout2017.effective(rankProbe))
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package parse
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Layout2021 is the redesigned "Site Overview" page served since 2021.
+const Layout2021 = "2021"
+
+const (
+	ovGlobalRank   = "div#card_rank div.rankmini-global div.rankmini-rank"
+	ovLocalRank    = "div#card_rank div.rankmini-local div.rankmini-rank"
+	ovCountry      = "div#card_rank div.rankmini-local a"
+	ovLinkingTotal = "div#card_backlink div.data"
+	ovLinkingDelta = "div#card_backlink div.delta"
+	ovLinkingChart = "div#card_backlink table.history tbody"
+	ovTitle        = "div#card_overview h2"
+	ovDescription  = "div#card_overview p.description"
+	ovVisitors     = "div#card_geography div.Body"
+	ovKeywords     = "div#card_mini_topkw div.Body"
+	ovUpstreams    = "div#card_referralsites div.Body"
+	ovLinks        = "div#card_backlink div.Body"
+	ovRelated      = "div#card_mini_audience div.Body"
+	ovRankSummary  = "div#card_rank p.summary"
+	ovNoData       = "div#card_rank div.nodata"
+	ovRow          = "div.Row"
+)
+
+var layout2021 = &layout{
+	version: Layout2021,
+	marker:  "div#card_rank",
+	noData:  ovNoData,
+	probes: []probe{
+		{"global rank", ovGlobalRank},
+		{"local rank", ovLocalRank},
+		{"country", ovCountry},
+		{"linking total", ovLinkingTotal},
+		{"site title", ovTitle},
+		{"visitors", ovVisitors},
+		{"keywords", ovKeywords},
+		{"upstream servers", ovUpstreams},
+		{"linking sites", ovLinks},
+		{"related sites", ovRelated},
+	},
+	sections: sections2021,
+}
+
+// sections2021 fill a Site from a page of the 2021 layout, which dropped
+// categories and subdomains. Ranks are printed like #1,234.
+var sections2021 = []section{
+	{"global rank", func(d *goquery.Document, o *options, s *Site) error {
+		gr, err := cardRank(d, o.selector(ovGlobalRank), "global rank")
+		s.GlobalRank = uint(gr)
+		return err
+	}},
+	{"local rank", func(d *goquery.Document, o *options, s *Site) error {
+		lr, err := cardRank(d, o.selector(ovLocalRank), "local rank")
+		s.LocalRank = uint(lr)
+		return err
+	}},
+	{"country", func(d *goquery.Document, o *options, s *Site) error {
+		country, err := getString(d, o.selector(ovCountry), "country")
+		if err != nil {
+			return err
+		}
+		s.MainCountry = country
+		s.MainCountryCode = o.countryCode(d.FindMatcher(sel(o.selector(ovCountry))).First(), country)
+		return nil
+	}},
+	{"rank summary", func(d *goquery.Document, o *options, s *Site) error {
+		// shown for ranked sites only since mid 2021
+		s.RankSummary = strings.Join(strings.Fields(d.FindMatcher(sel(ovRankSummary)).Text()), " ")
+		s.RankPercentile = rankPercentile(s.RankSummary)
+		return nil
+	}},
+	{"linking total", func(d *goquery.Document, o *options, s *Site) error {
+		lt, err := getUint(d, o.selector(ovLinkingTotal), "linking total")
+		s.LinkingTotal = uint(lt)
+		return err
+	}},
+	{"linking history", func(d *goquery.Document, o *options, s *Site) error {
+		// the card shows a 90-day delta like +312 and the data of its chart
+		if delta := strings.TrimSpace(d.FindMatcher(sel(ovLinkingDelta)).Text()); delta != "" {
+			v, err := parseDecimal(strings.TrimPrefix(delta, "+"))
+			if err == nil {
+				s.Trends.LinkingTotal = v
+			}
+		}
+		rs, _ := rows(d, ovLinkingChart, "tr", "linking history")
+		for _, r := range rs {
+			date, err := time.Parse(time.DateOnly, strings.TrimSpace(r.FindMatcher(sel("td:first-child")).Text()))
+			if err != nil {
+				continue
+			}
+			n, err := parseInt(strings.TrimSpace(r.FindMatcher(sel("td:last-child")).Text()))
+			if err != nil {
+				continue
+			}
+			s.LinkingHistory = append(s.LinkingHistory, LinkingCount{date, uint(n)})
+		}
+		return nil
+	}},
+	{"site title", func(d *goquery.Document, o *options, s *Site) (err error) {
+		s.Title, err = getString(d, o.selector(ovTitle), "site title")
+		return err
+	}},
+	{"site description", func(d *goquery.Document, o *options, s *Site) error {
+		s.Description = strings.TrimSpace(d.FindMatcher(sel(ovDescription)).Text())
+		return nil
+	}},
+	{"visitors", func(d *goquery.Document, o *options, s *Site) error {
+		rs, err := rows(d, o.selector(ovVisitors), ovRow, "visitors")
+		if err != nil {
+			return err
+		}
+		var v []Visitor
+		for _, r := range rs {
+			a := r.FindMatcher(sel("div.country a")).First()
+			country := strings.TrimSpace(a.Text())
+			p := newPercent(r.FindMatcher(sel("div.metric_one")).Text())
+			v = append(v, Visitor{Country: country, CountryCode: o.countryCode(a, country), Percent: p})
+		}
+		s.Visitors = o.topVisitors(v)
+		return nil
+	}},
+	{"keywords", func(d *goquery.Document, o *options, s *Site) error {
+		rs, err := rows(d, o.selector(ovKeywords), ovRow, "keywords")
+		if err != nil {
+			return err
+		}
+		for _, r := range rs {
+			s.Keywords = append(s.Keywords, Keyword{
+				Word:    strings.TrimSpace(r.FindMatcher(sel("div.keyword span.truncation")).Text()),
+				Percent: newPercent(r.FindMatcher(sel("div.metric_one")).Text()),
+			})
+		}
+		if o.normalizeKeywords {
+			s.Keywords = s.Keywords.Normalize().Dedupe()
+		}
+		return nil
+	}},
+	{"upstream servers", func(d *goquery.Document, o *options, s *Site) error {
+		rs, err := rows(d, o.selector(ovUpstreams), ovRow, "upstream servers")
+		if err != nil {
+			return err
+		}
+		for _, r := range rs {
+			a := r.FindMatcher(sel("div.site a")).First()
+			href, _ := a.Attr("href")
+			s.Upstreams = append(s.Upstreams, Upstream{
+				Site:    strings.TrimSpace(a.Text()),
+				URL:     absURL(href),
+				Percent: newPercent(r.FindMatcher(sel("div.metric_one")).Text()),
+			})
+		}
+		return nil
+	}},
+	{"linking sites", func(d *goquery.Document, o *options, s *Site) error {
+		rs, err := rows(d, o.selector(ovLinks), ovRow, "linking sites")
+		if err != nil {
+			return err
+		}
+		for _, r := range rs {
+			a := r.FindMatcher(sel("div.page a")).First()
+			page, _ := a.Attr("href")
+			rel, _ := a.Attr("rel")
+			s.LinksFrom = append(s.LinksFrom, Link{
+				Site: strings.TrimSpace(r.FindMatcher(sel("div.site")).Text()),
+				Page: page,
+				Text: strings.TrimSpace(a.Text()),
+				Rel:  strings.Fields(rel),
+			})
+		}
+		return nil
+	}},
+	{"related sites", func(d *goquery.Document, o *options, s *Site) error {
+		rs, err := rows(d, o.selector(ovRelated), ovRow, "related sites")
+		if err != nil {
+			return err
+		}
+		for _, r := range rs {
+			s.Related = append(s.Related, strings.TrimSpace(r.FindMatcher(sel("div.site a")).Text()))
+		}
+		return nil
+	}},
+}
+
+// rankBelow matches the percentile statement of the rank card like "99.8%
+// of sites are ranked below this site".
+var rankBelow = regexp.MustCompile(`([0-9]+(?:[.,][0-9]+)?)\s*%\s*of (?:all )?sites (?:are )?ranked below`)
+
+// rankPercentile extracts the share of sites ranked below from summary, 0
+// if it has no such statement.
+func rankPercentile(summary string) float64 {
+	m := rankBelow.FindStringSubmatch(strings.ToLower(summary))
+	if m == nil {
+		return 0
+	}
+	v, _ := parseDecimal(m[1])
+	return v
+}
+
+// cardRank reads a rank of the rank card.
+func cardRank(d *goquery.Document, selector, kind string) (uint64, error) {
+	s := strings.TrimSpace(d.FindMatcher(sel(selector)).Text())
+	return toUint(strings.TrimSpace(strings.TrimPrefix(s, "#")), kind)
+}
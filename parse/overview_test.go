@@ -0,0 +1,60 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOverviewLayout(t *testing.T) {
+	body, err := testDoc("testdata/overview.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Parse(body, WithLayoutThreshold(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Site{
+		Title:           "Сбербанк России",
+		Description:     "Сбербанк — крупнейший банк в России.",
+		MainCountry:     "Russia",
+		MainCountryCode: "RU",
+		GlobalRank:      412,
+		LocalRank:       15,
+		RankSummary:     "sberbank.ru is among the most popular sites: 99.98% of sites are ranked below this site.",
+		RankPercentile:  99.98,
+		LinkingTotal:    9832,
+		LinkingHistory: []LinkingCount{
+			{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 9520},
+			{time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), 9677},
+			{time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), 9832},
+		},
+		Trends: Trends{LinkingTotal: 312},
+		Visitors: []Visitor{
+			{Country: "Russia", CountryCode: "RU", Percent: Percent{"91.7%", 91.7}},
+			{Country: "Ukraine", CountryCode: "UA", Percent: Percent{"2.3%", 2.3}},
+		},
+		Keywords:  Keywords{{Word: "сбербанк онлайн", Percent: Percent{"18.04%", 18.04}}},
+		Upstreams: []Upstream{{Site: "yandex.ru", URL: "https://www.alexa.com/siteinfo/yandex.ru", Percent: Percent{"14.2%", 14.2}}},
+		LinksFrom: []Link{{Site: "cbr.ru", Page: "https://cbr.ru/banks/", Text: "Banks", Rel: []string{"nofollow", "ugc"}}},
+		Related:   []string{"vtb.ru", "tinkoff.ru"},
+		Meta:      Meta{Layout: Layout2021},
+	}
+	if !reflect.DeepEqual(s, want) {
+		t.Fatalf("want %+v, got %+v", want, s)
+	}
+}
+
+func TestRankPercentile(t *testing.T) {
+	for summary, want := range map[string]float64{
+		"99.98% of sites are ranked below this site.":  99.98,
+		"42,5 % of all sites ranked below example.org": 42.5,
+		"Rank is estimated from traffic":               0,
+	} {
+		if got := rankPercentile(summary); got != want {
+			t.Fatalf("%q: want %v, got %v", summary, want, got)
+		}
+	}
+}
@@ -0,0 +1,504 @@
+// Package parse turns an Alexa Website Info page into a Site.
+package parse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	seGlobalRank   = "span.globleRank span div strong"
+	seLocalRank    = "span.countryRank span div strong"
+	seCountry      = "span.countryRank span h4 a"
+	seVisitors     = "table#demographics_div_country_table tbody, table#demographics_div_country_table_full tbody"
+	seKeywords     = "table#keywords_top_keywords_table tbody"
+	seUpstreams    = "table#keywords_upstream_site_table tbody"
+	seLinks        = "table#linksin_table tbody"
+	seLinkingTotal = "section#linksin-panel-content div span div span.font-4.box1-r"
+	seRelated      = "table#audience_overlap_table tbody"
+	seCategories   = "table#category_link_table tbody"
+	seSubdomains   = "table#subdomain_table tbody"
+	seTitle        = "div.row-fluid.siteinfo-site-summary span div p"
+	seDescription  = "section#contact-panel-content div.row-fluid span.span8 p.color-s3"
+	seNoData       = "section#no-enough-data"
+)
+
+var alexaURL = &url.URL{Scheme: "https", Host: "www.alexa.com", Path: "/"}
+
+// ErrNoEnoughData is returned when a domain is not in top 1M.
+var ErrNoEnoughData = errors.New("asip: no enough data")
+
+// FieldError is returned when a section of the page is missing.
+type FieldError struct {
+	Field string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("no %s found", e.Field)
+}
+
+// Site is Website Traffic Statistics from alexa.com.
+type Site struct {
+	Domain          string
+	Title           string
+	Description     string
+	MainCountry     string
+	MainCountryCode string // ISO 3166-1 alpha-2
+	GlobalRank      uint
+	LocalRank       uint
+	RankSummary     string  // text of the rank card
+	RankPercentile  float64 // share of all sites ranked below, 0 if not shown
+	LinkingTotal    uint
+	LinkingHistory  []LinkingCount // where the page charts it, oldest first
+	Trends          Trends         // 90-day changes
+	Visitors        []Visitor
+	Keywords        Keywords
+	Upstreams       []Upstream
+	Related         []string
+	Subdomains      []Subdomain
+	Categories      []string // flattened CategoryPaths
+	CategoryPaths   []CategoryPath
+	CategoryURLs    []string // browse URLs of Categories by index
+	LinksFrom       []Link
+	Custom          map[string]string // filled by registered SectionParsers
+	BatchPercentile float64           // by global rank within a batch, see asip.Percentiles
+	DNS             []DNSRecords
+	Enrichment      Enrichment
+	Meta            Meta
+
+	// Engagement figures along with their trends.
+	PageviewsPerVisitor Trend // daily
+	BounceRate          Trend // percent of single pageview visits
+	TrafficSources      TrafficSources
+}
+
+// Meta describes where and when a Site was fetched, filled in by the fetch
+// layer rather than parsed from the page.
+type Meta struct {
+	FetchedAt       time.Time
+	SourceURL       string
+	FinalURL        string   // where redirects ended up
+	Redirects       []string // URLs redirected from, in order
+	CanonicalDomain string   // set if the provider redirected to another domain
+	HTTPStatus      int
+	Duration        time.Duration // from request until the body was read
+	Retries         int
+	Bytes           int64 // body size as downloaded
+	FromCache       bool
+	Provider        string
+	Headers         map[string]string // captured response headers
+	Layout          string            // page version detected by Parse, e.g. Layout2017
+}
+
+// Link is a site and page that links to the website.
+type Link struct {
+	Site string
+	Page string
+	Text string   // anchor text of the link
+	Rel  []string // rel attribute values of the link, e.g. nofollow
+
+	// Title of the page, resolved by enrich.Titles.
+	Title string
+}
+
+// Visitor represents a variety of visitors from a single country.
+type Visitor struct {
+	Country     string
+	CountryCode string // ISO 3166-1 alpha-2
+	Percent     Percent
+	LocalRank   uint
+}
+
+// Keyword is a one of the top keywords from search engines.
+type Keyword struct {
+	Word    string
+	Percent Percent
+}
+
+// Upstream sites people visited immediately before this site.
+type Upstream struct {
+	Site    string
+	URL     string // Website Info of the site
+	Rank    uint   // global rank of the site where shown
+	Percent Percent
+}
+
+// Subdomain represent subdomains where visitors go from the site.
+type Subdomain struct {
+	Domain    string
+	Percent   Percent
+	InTraffic bool // listed on the page
+	InCT      bool // found in certificate transparency logs
+}
+
+// CategoryPath is a DMOZ-style breadcrumb from the top category down.
+type CategoryPath []string
+
+func (p CategoryPath) String() string {
+	return strings.Join(p, " > ")
+}
+
+type findable interface {
+	FindMatcher(goquery.Matcher) *goquery.Selection
+	Text() string
+}
+
+// Parse reads an Alexa Website Info page from body.
+func Parse(body io.Reader, opts ...Option) (*Site, error) {
+	return ParseContext(context.Background(), body, opts...)
+}
+
+// ParseContext is Parse that stops between sections once ctx is done, so
+// cancelled lookups don't keep parsing pages nobody waits for.
+func ParseContext(ctx context.Context, body io.Reader, opts ...Option) (*Site, error) {
+	o := newOptions(opts)
+	d, err := goquery.NewDocumentFromReader(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	l := detect(d)
+	if o.layout != "" {
+		if l = findLayout(o.layout); l == nil {
+			return nil, fmt.Errorf("asip: unknown layout %q", o.layout)
+		}
+	}
+
+	if d.FindMatcher(sel(l.noData)).Length() > 0 {
+		return nil, ErrNoEnoughData
+	}
+	o.overrides = l.overrides()
+
+	if m := missing(d, l); o.layoutThreshold >= 0 && len(m) > o.layoutThreshold {
+		return nil, &LayoutError{m}
+	}
+
+	s := Site{Meta: Meta{Layout: l.version}}
+	for i, sec := range registeredSections(l) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if !o.wanted(l, sec.name) {
+			continue
+		}
+		if err := sec.parse(d, o, &s); err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			return &s, err
+		}
+	}
+	return &s, nil
+}
+
+func getUint(d findable, selector string, kind string) (uint64, error) {
+	var s string
+	if selector != "" {
+		s = strings.TrimSpace(d.FindMatcher(sel(selector)).Text())
+	} else {
+		s = strings.TrimSpace(d.Text())
+	}
+
+	return toUint(s, kind)
+}
+
+func toUint(s string, kind string) (uint64, error) {
+	if s == "" {
+		return 0, &FieldError{kind}
+	}
+
+	value, err := parseInt(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+func getString(d findable, selector string, kind string) (string, error) {
+	var s string
+	if selector != "" {
+		s = strings.TrimSpace(d.FindMatcher(sel(selector)).Text())
+	} else {
+		s = strings.TrimSpace(d.Text())
+	}
+	if s == "" {
+		return "", &FieldError{kind}
+	}
+	return s, nil
+}
+
+func globalRank(d *goquery.Document, o *options) (uint64, error) {
+	return getUint(d, o.selector(seGlobalRank), "global rank")
+}
+
+func localRank(d *goquery.Document, o *options) (uint64, error) {
+	return getUint(d, o.selector(seLocalRank), "local rank")
+}
+
+func country(d *goquery.Document, o *options) (string, error) {
+	return getString(d, o.selector(seCountry), "country")
+}
+
+func linkingTotal(d *goquery.Document, o *options) (uint64, error) {
+	return getUint(d, o.selector(seLinkingTotal), "linking total")
+}
+
+func title(d *goquery.Document, o *options) (string, error) {
+	return getString(d, o.selector(seTitle), "site title")
+}
+
+func description(d *goquery.Document, o *options) (string, error) {
+	return getString(d, o.selector(seDescription), "site description")
+}
+
+// visitors reads rows of the free view and the full table of the
+// subscription view, sorted by percent of visitors and capped to max.
+func visitors(d *goquery.Document, o *options) ([]Visitor, error) {
+	tbody := d.FindMatcher(sel(o.selector(seVisitors)))
+	if tbody.Length() == 0 {
+		return nil, &FieldError{"visitors"}
+	}
+
+	var (
+		v           []Visitor
+		country     string
+		percent     Percent
+		countryRank uint64
+		seen        = make(map[string]bool)
+	)
+	tbody.FindMatcher(sel("tr")).Each(func(i int, tr *goquery.Selection) {
+		country = strings.TrimSpace(tr.FindMatcher(sel("td a")).Text())
+		if seen[country] {
+			return
+		}
+		seen[country] = true
+
+		percent = newPercent(tr.FindMatcher(sel("td span")).First().Text())
+		countryRank, _ = getUint(
+			tr.FindMatcher(sel("td span")).Last(),
+			"",
+			fmt.Sprintf("%d country rank", i),
+		)
+
+		v = append(v, Visitor{
+			Country:     country,
+			CountryCode: o.countryCode(tr.FindMatcher(sel("td a")).First(), country),
+			Percent:     percent,
+			LocalRank:   uint(countryRank),
+		})
+	})
+	return o.topVisitors(v), nil
+}
+
+// topVisitors sorts v by percent of visitors and caps it to visitorRows.
+func (o *options) topVisitors(v []Visitor) []Visitor {
+	sort.SliceStable(v, func(i, j int) bool {
+		return v[i].Percent.Value > v[j].Percent.Value
+	})
+	if o.visitorRows >= 0 && len(v) > o.visitorRows {
+		v = v[:o.visitorRows]
+	}
+	return v
+}
+
+// keywordShareHeaders are headers of the share column across page versions
+// in order of preference.
+var keywordShareHeaders = []string{
+	"percent of search traffic",
+	"% of search traffic",
+	"share of voice",
+	"search traffic",
+}
+
+func keywords(d *goquery.Document, o *options) (Keywords, error) {
+	tbody := d.FindMatcher(sel(o.selector(seKeywords)))
+	if tbody.Length() == 0 {
+		return nil, &FieldError{"keywords"}
+	}
+
+	col := shareColumn(tbody.Parent().FindMatcher(sel("thead th")))
+
+	var ks Keywords
+	tbody.FindMatcher(sel("tr")).Each(func(_ int, tr *goquery.Selection) {
+		tds := tr.FindMatcher(sel("td"))
+		td := tds.Last()
+		if col >= 0 && col < tds.Length() {
+			td = tds.Eq(col)
+		}
+
+		raw := td.FindMatcher(sel("span")).Text()
+		if raw == "" {
+			raw = td.Text()
+		}
+		ks = append(ks, Keyword{
+			Word:    strings.TrimSpace(tr.FindMatcher(sel("td:first-child span:last-child")).Text()),
+			Percent: newPercent(raw),
+		})
+	})
+
+	return ks, nil
+}
+
+// shareColumn finds the index of the share of search traffic among headers,
+// -1 if there is none.
+func shareColumn(ths *goquery.Selection) int {
+	var headers []string
+	ths.Each(func(_ int, th *goquery.Selection) {
+		headers = append(headers, strings.ToLower(strings.Join(strings.Fields(th.Text()), " ")))
+	})
+
+	for _, v := range keywordShareHeaders {
+		for i, h := range headers {
+			if strings.Contains(h, v) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func upstreams(d *goquery.Document, o *options) ([]Upstream, error) {
+	tbody := d.FindMatcher(sel(o.selector(seUpstreams)))
+	if tbody.Length() == 0 {
+		return nil, &FieldError{"upstream servers"}
+	}
+
+	var us []Upstream
+	tbody.FindMatcher(sel("tr")).Each(func(_ int, tr *goquery.Selection) {
+		a := tr.FindMatcher(sel("td a")).First()
+		href, _ := a.Attr("href")
+		u := Upstream{
+			Site:    strings.TrimSpace(a.Text()),
+			URL:     absURL(href),
+			Percent: newPercent(tr.FindMatcher(sel("td:last-child span")).Text()),
+		}
+
+		// some versions show a rank column between the site and percent
+		if tds := tr.FindMatcher(sel("td")); tds.Length() > 2 {
+			if rank, err := parseInt(strings.TrimSpace(tds.Eq(1).Text())); err == nil {
+				u.Rank = uint(rank)
+			}
+		}
+		us = append(us, u)
+	})
+
+	return us, nil
+}
+
+func linksFrom(d *goquery.Document, o *options) ([]Link, error) {
+	tbody := d.FindMatcher(sel(o.selector(seLinks)))
+	if tbody.Length() == 0 {
+		return nil, &FieldError{"linking sites"}
+	}
+
+	var ls []Link
+	tbody.FindMatcher(sel("tr")).Each(func(_ int, tr *goquery.Selection) {
+		a := tr.FindMatcher(sel("a.word-wrap"))
+		page, _ := a.Attr("href")
+		rel, _ := a.Attr("rel")
+
+		// the visible text is truncated, the title holds it in full
+		text, ok := a.FindMatcher(sel("span")).Attr("title")
+		if !ok {
+			text = a.Text()
+		}
+
+		ls = append(ls, Link{
+			Site: strings.TrimSpace(tr.FindMatcher(sel("span.word-wrap a")).Text()),
+			Page: page,
+			Text: strings.TrimSpace(text),
+			Rel:  strings.Fields(rel),
+		})
+	})
+
+	return ls, nil
+}
+
+func related(d *goquery.Document, selector string) ([]string, error) {
+	tbody := d.FindMatcher(sel(selector))
+	if tbody.Length() == 0 {
+		return nil, &FieldError{"related sites"}
+	}
+
+	var rs []string
+	tbody.FindMatcher(sel("tr")).Each(func(_ int, tr *goquery.Selection) {
+		rs = append(rs, tr.FindMatcher(sel("a")).Text())
+	})
+
+	return rs, nil
+}
+
+// categoryPaths reads a breadcrumb per row of the categories table of
+// selector along with browse URLs of all the categories.
+func categoryPaths(d *goquery.Document, selector string) ([]CategoryPath, []string, error) {
+	tbody := d.FindMatcher(sel(selector))
+	if tbody.Length() == 0 {
+		return nil, nil, &FieldError{"categories"}
+	}
+
+	var (
+		cps  []CategoryPath
+		urls []string
+	)
+	tbody.FindMatcher(sel("tr")).Each(func(_ int, tr *goquery.Selection) {
+		var p CategoryPath
+		tr.FindMatcher(sel("a")).Each(func(_ int, a *goquery.Selection) {
+			href, _ := a.Attr("href")
+			p = append(p, a.Text())
+			urls = append(urls, absURL(href))
+		})
+		if len(p) > 0 {
+			cps = append(cps, p)
+		}
+	})
+
+	return cps, urls, nil
+}
+
+// absURL resolves a link of the page against alexa.com.
+func absURL(href string) string {
+	if href == "" {
+		return ""
+	}
+	u, err := alexaURL.Parse(href)
+	if err != nil {
+		return href
+	}
+	return u.String()
+}
+
+func subdomains(d *goquery.Document, o *options) ([]Subdomain, error) {
+	tbody := d.FindMatcher(sel(o.selector(seSubdomains)))
+	if tbody.Length() == 0 {
+		return nil, &FieldError{"subdomains"}
+	}
+
+	var (
+		ss      []Subdomain
+		domain  string
+		percent Percent
+	)
+	tbody.FindMatcher(sel("tr")).Each(func(_ int, tr *goquery.Selection) {
+		domain = tr.FindMatcher(sel("td:first-child span")).Text()
+		percent = newPercent(tr.FindMatcher(sel("td:last-child span")).Text())
+		ss = append(ss, Subdomain{
+			Domain:    domain,
+			Percent:   percent,
+			InTraffic: true,
+		})
+	})
+
+	return ss, nil
+}
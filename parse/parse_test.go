@@ -0,0 +1,427 @@
+package parse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	successTestDocLoc = "testdata/body.html"
+	nodataTestDocLoc  = "testdata/nodata.html"
+)
+
+var successTestSite = &Site{
+	Title:               "Сбербанк России",
+	Description:         "Сведения об истории создания, руководстве, филиалах и подразделениях. Перечень услуг. Тарифы.",
+	MainCountry:         "Russia",
+	MainCountryCode:     "RU",
+	GlobalRank:          506,
+	LocalRank:           17,
+	LinkingTotal:        8491,
+	Trends:              Trends{GlobalRank: 79, BounceRate: 4, Pageviews: -2.82, TimeOnSite: -4},
+	PageviewsPerVisitor: Trend{Value: 5.52, Change: -2.82, Arrow: "down"},
+	BounceRate:          Trend{Value: 25.1, Change: 4, Arrow: "up"},
+	TrafficSources:      TrafficSources{Search: Trend{Value: 9.1, Change: -10, Arrow: "down"}},
+	Visitors: []Visitor{
+		Visitor{
+			Country:     "Russia",
+			CountryCode: "RU",
+			Percent:     Percent{"83.8%", 83.8},
+			LocalRank:   17,
+		},
+		Visitor{
+			Country:     "Netherlands",
+			CountryCode: "NL",
+			Percent:     Percent{"2.0%", 2.0},
+			LocalRank:   182,
+		},
+		Visitor{
+			Country:     "Germany",
+			CountryCode: "DE",
+			Percent:     Percent{"1.7%", 1.7},
+			LocalRank:   1366,
+		},
+		Visitor{
+			Country:     "United Kingdom",
+			CountryCode: "GB",
+			Percent:     Percent{"1.4%", 1.4},
+			LocalRank:   1234,
+		},
+		Visitor{
+			Country:     "United States",
+			CountryCode: "US",
+			Percent:     Percent{"1.3%", 1.3},
+			LocalRank:   7997,
+		},
+	},
+	Keywords: []Keyword{
+		Keyword{
+			Word:    "сбербанк онлайн",
+			Percent: Percent{"49.69%", 49.69},
+		},
+		Keyword{
+			Word:    "сбербанк",
+			Percent: Percent{"7.87%", 7.87},
+		},
+		Keyword{
+			Word:    "сбербанк бизнес онлайн",
+			Percent: Percent{"7.74%", 7.74},
+		},
+		Keyword{
+			Word:    "sberbank online",
+			Percent: Percent{"3.63%", 3.63},
+		},
+		Keyword{
+			Word:    "sberbank",
+			Percent: Percent{"2.65%", 2.65},
+		},
+	},
+	Upstreams: []Upstream{
+		Upstream{
+			Site:    "yandex.ru",
+			URL:     "https://www.alexa.com/siteinfo/yandex.ru",
+			Percent: Percent{"21.4%", 21.4},
+		},
+		Upstream{
+			Site:    "google.com",
+			URL:     "https://www.alexa.com/siteinfo/google.com",
+			Percent: Percent{"10.1%", 10.1},
+		},
+		Upstream{
+			Site:    "vk.com",
+			URL:     "https://www.alexa.com/siteinfo/vk.com",
+			Percent: Percent{"5.6%", 5.6},
+		},
+		Upstream{
+			Site:    "mail.ru",
+			URL:     "https://www.alexa.com/siteinfo/mail.ru",
+			Percent: Percent{"4.3%", 4.3},
+		},
+		Upstream{
+			Site:    "youtube.com",
+			URL:     "https://www.alexa.com/siteinfo/youtube.com",
+			Percent: Percent{"2.3%", 2.3},
+		},
+	},
+	LinksFrom: []Link{
+		Link{
+			Site: "yandex.ru",
+			Page: "http://money.yandex.ru/doc.xml?id=242350",
+			Text: "money.yandex.ru/doc.xml?id=242350",
+			Rel:  []string{"nofollow"},
+		},
+		Link{
+			Site: "mail.ru",
+			Page: "http://card.krugdoveriya.mail.ru/articles.html?id=19376",
+			Text: "card.krugdoveriya.mail.ru/articles.html?id=19376",
+			Rel:  []string{"nofollow"},
+		},
+		Link{
+			Site: "fc2.com",
+			Page: "http://10rank.blog.fc2.com/blog-entry-264.html",
+			Text: "10rank.blog.fc2.com/blog-entry-264.html",
+			Rel:  []string{"nofollow"},
+		},
+		Link{
+			Site: "mit.edu",
+			Page: "http://misti.mit.edu/hosts-partners/featured-hosts",
+			Text: "misti.mit.edu/hosts-partners/featured-hosts",
+			Rel:  []string{"nofollow"},
+		},
+		Link{
+			Site: "wixsite.com",
+			Page: "http://belov-72.wixsite.com/ocenka72",
+			Text: "belov-72.wixsite.com/ocenka72",
+			Rel:  []string{"nofollow"},
+		},
+	},
+	Related: []string{
+		"sbrf.ru",
+		"sravni.ru",
+		"gosuslugi.ru",
+		"banki.ru",
+		"avito.ru",
+	},
+	Categories: []string{
+		"World",
+		"Russian",
+		"Страны и регионы",
+		"Европа",
+		"Россия",
+		"Бизнес и экономика",
+		"Финансовые услуги",
+		"Банки",
+	},
+	CategoryPaths: []CategoryPath{
+		{
+			"World",
+			"Russian",
+			"Страны и регионы",
+			"Европа",
+			"Россия",
+			"Бизнес и экономика",
+			"Финансовые услуги",
+			"Банки",
+		},
+	},
+	CategoryURLs: []string{
+		"https://www.alexa.com/topsites/category/World/",
+		"https://www.alexa.com/topsites/category/World/Russian/",
+		"https://www.alexa.com/topsites/category/World/Russian/%D0%A1%D1%82%D1%80%D0%B0%D0%BD%D1%8B_%D0%B8_%D1%80%D0%B5%D0%B3%D0%B8%D0%BE%D0%BD%D1%8B/",
+		"https://www.alexa.com/topsites/category/World/Russian/%D0%A1%D1%82%D1%80%D0%B0%D0%BD%D1%8B_%D0%B8_%D1%80%D0%B5%D0%B3%D0%B8%D0%BE%D0%BD%D1%8B/%D0%95%D0%B2%D1%80%D0%BE%D0%BF%D0%B0/",
+		"https://www.alexa.com/topsites/category/World/Russian/%D0%A1%D1%82%D1%80%D0%B0%D0%BD%D1%8B_%D0%B8_%D1%80%D0%B5%D0%B3%D0%B8%D0%BE%D0%BD%D1%8B/%D0%95%D0%B2%D1%80%D0%BE%D0%BF%D0%B0/%D0%A0%D0%BE%D1%81%D1%81%D0%B8%D1%8F/",
+		"https://www.alexa.com/topsites/category/World/Russian/%D0%A1%D1%82%D1%80%D0%B0%D0%BD%D1%8B_%D0%B8_%D1%80%D0%B5%D0%B3%D0%B8%D0%BE%D0%BD%D1%8B/%D0%95%D0%B2%D1%80%D0%BE%D0%BF%D0%B0/%D0%A0%D0%BE%D1%81%D1%81%D0%B8%D1%8F/%D0%91%D0%B8%D0%B7%D0%BD%D0%B5%D1%81_%D0%B8_%D1%8D%D0%BA%D0%BE%D0%BD%D0%BE%D0%BC%D0%B8%D0%BA%D0%B0/",
+		"https://www.alexa.com/topsites/category/World/Russian/%D0%A1%D1%82%D1%80%D0%B0%D0%BD%D1%8B_%D0%B8_%D1%80%D0%B5%D0%B3%D0%B8%D0%BE%D0%BD%D1%8B/%D0%95%D0%B2%D1%80%D0%BE%D0%BF%D0%B0/%D0%A0%D0%BE%D1%81%D1%81%D0%B8%D1%8F/%D0%91%D0%B8%D0%B7%D0%BD%D0%B5%D1%81_%D0%B8_%D1%8D%D0%BA%D0%BE%D0%BD%D0%BE%D0%BC%D0%B8%D0%BA%D0%B0/%D0%A4%D0%B8%D0%BD%D0%B0%D0%BD%D1%81%D0%BE%D0%B2%D1%8B%D0%B5_%D1%83%D1%81%D0%BB%D1%83%D0%B3%D0%B8/",
+		"https://www.alexa.com/topsites/category/World/Russian/%D0%A1%D1%82%D1%80%D0%B0%D0%BD%D1%8B_%D0%B8_%D1%80%D0%B5%D0%B3%D0%B8%D0%BE%D0%BD%D1%8B/%D0%95%D0%B2%D1%80%D0%BE%D0%BF%D0%B0/%D0%A0%D0%BE%D1%81%D1%81%D0%B8%D1%8F/%D0%91%D0%B8%D0%B7%D0%BD%D0%B5%D1%81_%D0%B8_%D1%8D%D0%BA%D0%BE%D0%BD%D0%BE%D0%BC%D0%B8%D0%BA%D0%B0/%D0%A4%D0%B8%D0%BD%D0%B0%D0%BD%D1%81%D0%BE%D0%B2%D1%8B%D0%B5_%D1%83%D1%81%D0%BB%D1%83%D0%B3%D0%B8/%D0%91%D0%B0%D0%BD%D0%BA%D0%B8/",
+	},
+	Subdomains: []Subdomain{
+		Subdomain{
+			Domain:    "online.sberbank.ru",
+			Percent:   Percent{"69.69%", 69.69},
+			InTraffic: true,
+		},
+		Subdomain{
+			Domain:    "sberbank.ru",
+			Percent:   Percent{"28.30%", 28.30},
+			InTraffic: true,
+		},
+		Subdomain{
+			Domain:    "securepayments.sberbank.ru",
+			Percent:   Percent{"6.72%", 6.72},
+			InTraffic: true,
+		},
+		Subdomain{
+			Domain:    "sbi.sberbank.ru",
+			Percent:   Percent{"4.53%", 4.53},
+			InTraffic: true,
+		},
+		Subdomain{
+			Domain:    "info.sberbank.ru",
+			Percent:   Percent{"0.58%", 0.58},
+			InTraffic: true,
+		},
+	},
+	Meta: Meta{Layout: Layout2017},
+}
+
+func testDoc(filename string) (body io.ReadCloser, err error) {
+	return os.Open(filename)
+}
+
+func TestSiteInfo(t *testing.T) {
+	body, err := testDoc(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	si, err := Parse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(si, successTestSite) {
+		t.Fatalf("want %v, got %v", successTestSite, si)
+	}
+}
+
+func TestParseContext(t *testing.T) {
+	body, err := testDoc(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ParseContext(ctx, body); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestNoData(t *testing.T) {
+	body, err := testDoc(nodataTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Parse(body)
+	if err != ErrNoEnoughData {
+		t.Fatal("want error, but got no error")
+	}
+}
+
+func TestFieldError(t *testing.T) {
+	_, err := Parse(strings.NewReader("<html><body></body></html>"), WithLayoutThreshold(-1))
+
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("want *FieldError, got %v", err)
+	}
+	if fe.Field != "global rank" {
+		t.Fatalf("want global rank field, got %s", fe.Field)
+	}
+}
+
+func TestCountryCodes(t *testing.T) {
+	page := `<span class="countryRank"><span><h4><a>Côte d'Ivoire</a></h4></span></span>` +
+		`<table id="demographics_div_country_table"><tbody>` +
+		`<tr><td><a href="/topsites/countries/RU">Russia</a></td><td><span>83.8%</span></td><td><span>17</span></td></tr>` +
+		`<tr><td><a>Viet Nam</a></td><td><span>1.8%</span></td><td><span>42</span></td></tr>` +
+		`</tbody></table>`
+
+	d, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := newOptions([]Option{WithCountryCodes(map[string]string{"Côte d'Ivoire": "CI"})})
+
+	if code := o.countryCode(d.FindMatcher(sel(seCountry)).First(), "Côte d'Ivoire"); code != "CI" {
+		t.Fatalf("want overridden code CI, got %q", code)
+	}
+	vs, err := visitors(d, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := []string{vs[0].CountryCode, vs[1].CountryCode}; !reflect.DeepEqual(got, []string{"RU", "VN"}) {
+		t.Fatalf("want codes [RU VN], got %v", got)
+	}
+}
+
+func TestImplausiblePercentKeepsRows(t *testing.T) {
+	page := `<table id="demographics_div_country_table"><tbody>` +
+		`<tr><td><a>Russia</a></td><td><span>120%</span></td><td><span>17</span></td></tr>` +
+		`<tr><td><a>Ukraine</a></td><td><span>4.5%</span></td><td><span>40</span></td></tr>` +
+		`</tbody></table>` +
+		`<table id="subdomain_table"><tbody>` +
+		`<tr><td><span>mail.example.org</span></td><td><span>-3%</span></td></tr>` +
+		`<tr><td><span>example.org</span></td><td><span>80%</span></td></tr>` +
+		`</tbody></table>`
+
+	d, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs, err := visitors(d, newOptions(nil))
+	if err != nil || len(vs) != 2 {
+		t.Fatalf("want both visitors, got %v, %v", vs, err)
+	}
+	ss, err := subdomains(d, newOptions(nil))
+	if err != nil || len(ss) != 2 {
+		t.Fatalf("want both subdomains, got %v, %v", ss, err)
+	}
+
+	s := Site{GlobalRank: 1, Visitors: vs, Subdomains: ss}
+	var fields []string
+	for _, w := range s.Validate() {
+		fields = append(fields, w.Field)
+	}
+	if !reflect.DeepEqual(fields, []string{"visitors", "subdomains"}) {
+		t.Fatalf("want warnings of visitors and subdomains, got %v", s.Validate())
+	}
+}
+
+func TestUpstreamRank(t *testing.T) {
+	page := `<table id="keywords_upstream_site_table"><tbody><tr>` +
+		`<td><a href="/siteinfo/yandex.ru">yandex.ru</a></td><td>30</td><td><span>21.4%</span></td>` +
+		`</tr></tbody></table>`
+
+	d, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	us, err := upstreams(d, newOptions(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Upstream{{"yandex.ru", "https://www.alexa.com/siteinfo/yandex.ru", 30, Percent{"21.4%", 21.4}}}
+	if !reflect.DeepEqual(us, want) {
+		t.Fatalf("want %v, got %v", want, us)
+	}
+}
+
+func TestLayoutChanged(t *testing.T) {
+	_, err := Parse(strings.NewReader("<html><body></body></html>"))
+	if !errors.Is(err, ErrLayoutChanged) {
+		t.Fatalf("want %v, got %v", ErrLayoutChanged, err)
+	}
+
+	var le *LayoutError
+	if !errors.As(err, &le) || len(le.Missing) != len(layout2017.probes) {
+		t.Fatalf("want all sections missing, got %v", err)
+	}
+}
+
+func TestSelectorResults(t *testing.T) {
+	empty := selectorResults.With(Layout2017, "keywords", "empty").Value()
+	matched := selectorResults.With(Layout2017, "keywords", "matched").Value()
+
+	Parse(strings.NewReader("<html><body></body></html>"))
+	body, err := testDoc(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(body); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := selectorResults.With(Layout2017, "keywords", "empty").Value() - empty; got != 1 {
+		t.Fatalf("want keywords empty once, got %v", got)
+	}
+	if got := selectorResults.With(Layout2017, "keywords", "matched").Value() - matched; got != 1 {
+		t.Fatalf("want keywords matched once, got %v", got)
+	}
+}
+
+func TestVisitorRows(t *testing.T) {
+	row := func(country, percent, rank string) string {
+		return "<tr><td><a>" + country + "</a></td><td><span>" + percent + "</span></td><td><span>" + rank + "</span></td></tr>"
+	}
+	page := `<table id="demographics_div_country_table"><tbody>` +
+		row("Germany", "1.7%", "1,366") + row("Russia", "83.8%", "17") +
+		`</tbody></table><table id="demographics_div_country_table_full"><tbody>` +
+		row("Russia", "83.8%", "17") + row("Netherlands", "2.0%", "182") + row("France", "0.9%", "9,001") +
+		`</tbody></table>`
+
+	d, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vs, err := visitors(d, newOptions([]Option{WithVisitorRows(-1)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, v := range vs {
+		got = append(got, v.Country)
+	}
+	want := []string{"Russia", "Netherlands", "Germany", "France"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	if vs, _ := visitors(d, newOptions([]Option{WithVisitorRows(2)})); len(vs) != 2 {
+		t.Fatalf("want 2 visitors, got %d", len(vs))
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	page, err := os.ReadFile(successTestDocLoc)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(bytes.NewReader(page)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
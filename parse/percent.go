@@ -0,0 +1,99 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Percent is a share as printed on the page along with its numeric value.
+type Percent struct {
+	Raw   string
+	Value float64
+}
+
+func newPercent(raw string) Percent {
+	raw = strings.TrimSpace(raw)
+	v, _ := parseDecimal(strings.TrimSpace(strings.TrimSuffix(raw, "%")))
+	return Percent{
+		Raw:   raw,
+		Value: v,
+	}
+}
+
+// String formats the value like 12.5%.
+func (p Percent) String() string {
+	return strconv.FormatFloat(p.Value, 'f', -1, 64) + "%"
+}
+
+// MarshalJSON encodes the value as a number.
+func (p Percent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Value)
+}
+
+// UnmarshalJSON decodes a number or a {"Raw", "Value"} object as it was
+// encoded before.
+func (p *Percent) UnmarshalJSON(b []byte) error {
+	if strings.HasPrefix(string(b), "{") {
+		type percent Percent
+		return json.Unmarshal(b, (*percent)(p))
+	}
+
+	if err := json.Unmarshal(b, &p.Value); err != nil {
+		return err
+	}
+	p.Raw = p.String()
+	return nil
+}
+
+// Validate checks the value is within [0, 100].
+func (p Percent) Validate() error {
+	if p.Value < 0 || p.Value > 100 {
+		return fmt.Errorf("percent %s out of [0, 100]", p)
+	}
+	return nil
+}
+
+// Sum adds percents up.
+func Sum(ps ...Percent) Percent {
+	var v float64
+	for _, p := range ps {
+		v += p.Value
+	}
+	return Percent{Raw: Percent{Value: v}.String(), Value: v}
+}
+
+// Normalize scales percents proportionally to add up to 100, e.g. to
+// compare shares of the top rows only.
+func Normalize(ps []Percent) []Percent {
+	total := Sum(ps...).Value
+	if total == 0 {
+		return ps
+	}
+
+	n := make([]Percent, len(ps))
+	for i, p := range ps {
+		v := p.Value * 100 / total
+		n[i] = Percent{Raw: Percent{Value: v}.String(), Value: v}
+	}
+	return n
+}
+
+// VisitorPercents returns shares of visitors.
+func VisitorPercents(vs []Visitor) []Percent {
+	ps := make([]Percent, len(vs))
+	for i, v := range vs {
+		ps[i] = v.Percent
+	}
+	return ps
+}
+
+// SubdomainPercents returns shares of subdomains.
+func SubdomainPercents(ss []Subdomain) []Percent {
+	ps := make([]Percent, len(ss))
+	for i, s := range ss {
+		ps[i] = s.Percent
+	}
+	return ps
+}
@@ -0,0 +1,53 @@
+package parse
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPercentJSON(t *testing.T) {
+	b, err := json.Marshal(Keyword{Word: "sber", Percent: Percent{"12.50%", 12.5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"Word":"sber","Percent":12.5}` {
+		t.Fatalf("unexpected json %s", b)
+	}
+
+	var k Keyword
+	if err := json.Unmarshal(b, &k); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Percent{"12.5%", 12.5}); k.Percent != want {
+		t.Fatalf("want %v, got %v", want, k.Percent)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Word":"sber","Percent":{"Raw":"12.50%","Value":12.5}}`), &k); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Percent{"12.50%", 12.5}); k.Percent != want {
+		t.Fatalf("want %v, got %v", want, k.Percent)
+	}
+}
+
+func TestPercentValidate(t *testing.T) {
+	if err := newPercent("100%").Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := newPercent("120.5%").Validate(); err == nil {
+		t.Fatal("want an error for 120.5%")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	ps := []Percent{newPercent("30%"), newPercent("10%")}
+	if s := Sum(ps...); s.Value != 40 || s.String() != "40%" {
+		t.Fatalf("unexpected sum %v", s)
+	}
+
+	want := []Percent{{"75%", 75}, {"25%", 25}}
+	if got := Normalize(ps); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
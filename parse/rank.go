@@ -0,0 +1,198 @@
+package parse
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// ParseRank streams body only as far as needed to find global rank, local
+// rank and its country, which makes it much cheaper than Parse. Pages of
+// layouts other than 2017 are parsed in full once their marker shows up.
+func ParseRank(body io.Reader) (global, local uint, country string, err error) {
+	const (
+		none = iota
+		inGlobal
+		inCountry
+	)
+
+	var (
+		read    bytes.Buffer
+		z       = html.NewTokenizer(io.TeeReader(body, &read))
+		section = none
+		capture *string
+		text    strings.Builder
+
+		globalText, localText string
+		haveCountry           bool
+	)
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if z.Err() != io.EOF {
+				return 0, 0, "", z.Err()
+			}
+			return rankResult(globalText, localText, country)
+
+		case html.StartTagToken:
+			tn, hasAttr := z.TagName()
+			attrs := tagAttrs(z, hasAttr)
+			if otherLayout(tn, attrs) {
+				return parseRankFull(io.MultiReader(&read, body))
+			}
+
+			switch {
+			case string(tn) == "section" && attrs["id"] == "no-enough-data":
+				return 0, 0, "", ErrNoEnoughData
+			case string(tn) == "span" && hasClass(attrs["class"], "globleRank"):
+				section = inGlobal
+			case string(tn) == "span" && hasClass(attrs["class"], "countryRank"):
+				section = inCountry
+			case string(tn) == "strong" && hasClass(attrs["class"], "metrics-data"):
+				switch {
+				case section == inGlobal && globalText == "":
+					capture = &globalText
+				case section == inCountry && localText == "":
+					capture = &localText
+				}
+			case string(tn) == "a" && section == inCountry && !haveCountry:
+				capture = &country
+				haveCountry = true
+			}
+			text.Reset()
+
+		case html.TextToken:
+			if capture != nil {
+				text.Write(z.Text())
+			}
+
+		case html.EndTagToken:
+			if capture == nil {
+				continue
+			}
+			*capture = strings.TrimSpace(text.String())
+			capture = nil
+
+			if globalText != "" && localText != "" && haveCountry {
+				return rankResult(globalText, localText, country)
+			}
+		}
+	}
+}
+
+// otherLayout tells whether the tag is the marker of a layout other than
+// 2017, like div#card_rank.
+func otherLayout(tn []byte, attrs map[string]string) bool {
+	for _, l := range layouts {
+		if l != layout2017 && l.marker == string(tn)+"#"+attrs["id"] {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRankFull is ParseRank parsing the page like Parse does.
+func parseRankFull(body io.Reader) (uint, uint, string, error) {
+	s, err := Parse(body, WithFields("GlobalRank", "LocalRank", "MainCountry"), WithLayoutThreshold(-1))
+	if s == nil {
+		return 0, 0, "", err
+	}
+	if err != nil {
+		return s.GlobalRank, s.LocalRank, "", err
+	}
+	return s.GlobalRank, s.LocalRank, s.MainCountry, nil
+}
+
+func rankResult(globalText, localText, country string) (uint, uint, string, error) {
+	global, err := toUint(globalText, "global rank")
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	local, err := toUint(localText, "local rank")
+	if err != nil {
+		return uint(global), 0, "", err
+	}
+
+	if country == "" {
+		return uint(global), uint(local), "", &FieldError{"country"}
+	}
+
+	return uint(global), uint(local), country, nil
+}
+
+func tagAttrs(z *html.Tokenizer, more bool) map[string]string {
+	attrs := make(map[string]string)
+	for more {
+		var k, v []byte
+		k, v, more = z.TagAttr()
+		attrs[string(k)] = string(v)
+	}
+	return attrs
+}
+
+func hasClass(classes, class string) bool {
+	for _, c := range strings.Fields(classes) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRanked streams body until it can tell whether the page holds ranking
+// data or the no enough data notice. Pages of layouts other than 2017 are
+// parsed in full once their marker shows up.
+func IsRanked(body io.Reader) (bool, error) {
+	var read bytes.Buffer
+	z := html.NewTokenizer(io.TeeReader(body, &read))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if z.Err() != io.EOF {
+				return false, z.Err()
+			}
+			return false, &FieldError{"global rank"}
+
+		case html.StartTagToken:
+			tn, hasAttr := z.TagName()
+			if string(tn) != "section" && string(tn) != "span" && string(tn) != "div" {
+				continue
+			}
+
+			attrs := tagAttrs(z, hasAttr)
+			switch {
+			case otherLayout(tn, attrs):
+				return isRankedFull(io.MultiReader(&read, body))
+			case string(tn) == "section" && attrs["id"] == "no-enough-data":
+				return false, nil
+			case string(tn) == "span" && hasClass(attrs["class"], "globleRank"):
+				return true, nil
+			}
+		}
+	}
+}
+
+// isRankedFull is IsRanked looking for markers of the detected layout in the
+// whole page.
+func isRankedFull(body io.Reader) (bool, error) {
+	d, err := goquery.NewDocumentFromReader(body)
+	if err != nil {
+		return false, err
+	}
+
+	l := detect(d)
+	if d.FindMatcher(sel(l.noData)).Length() > 0 {
+		return false, nil
+	}
+	for _, p := range l.probes {
+		if p.field == "global rank" && d.FindMatcher(sel(l.effective(p))).Length() > 0 {
+			return true, nil
+		}
+	}
+	return false, &FieldError{"global rank"}
+}
@@ -0,0 +1,108 @@
+package parse
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("read past the rank section")
+}
+
+func TestParseRank(t *testing.T) {
+	b, err := os.ReadFile(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// anything after the rank section must not be read
+	cut := bytes.Index(b, []byte("WEB-2174"))
+	body := io.MultiReader(bytes.NewReader(b[:cut]), failingReader{})
+
+	global, local, country, err := ParseRank(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if global != 506 || local != 17 || country != "Russia" {
+		t.Fatalf("want 506, 17, Russia, got %d, %d, %s", global, local, country)
+	}
+}
+
+func TestParseRankNoData(t *testing.T) {
+	body, err := testDoc(nodataTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	if _, _, _, err = ParseRank(body); err != ErrNoEnoughData {
+		t.Fatalf("want %v, got %v", ErrNoEnoughData, err)
+	}
+}
+
+func TestParseRankLayouts(t *testing.T) {
+	tests := map[string]struct {
+		global, local uint
+		country       string
+	}{
+		"testdata/legacy.html":   {1204, 31, "Russia"},
+		"testdata/overview.html": {412, 15, "Russia"},
+	}
+	for loc, want := range tests {
+		body, err := testDoc(loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		global, local, country, err := ParseRank(body)
+		body.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", loc, err)
+		}
+		if global != want.global || local != want.local || country != want.country {
+			t.Fatalf("%s: want %v, got %d, %d, %s", loc, want, global, local, country)
+		}
+	}
+
+	nodata := `<html><body><div id="card_rank"><div class="nodata">No data</div></div></body></html>`
+	if _, _, _, err := ParseRank(strings.NewReader(nodata)); err != ErrNoEnoughData {
+		t.Fatalf("want %v, got %v", ErrNoEnoughData, err)
+	}
+}
+
+func TestIsRanked(t *testing.T) {
+	for loc, want := range map[string]bool{
+		successTestDocLoc:        true,
+		nodataTestDocLoc:         false,
+		"testdata/legacy.html":   true,
+		"testdata/overview.html": true,
+	} {
+		body, err := testDoc(loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ranked, err := IsRanked(body)
+		body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ranked != want {
+			t.Fatalf("%s: want %t, got %t", loc, want, ranked)
+		}
+	}
+}
+
+func TestIsRankedOverviewNoData(t *testing.T) {
+	nodata := `<html><body><div id="card_rank"><div class="nodata">No data</div></div></body></html>`
+	ranked, err := IsRanked(strings.NewReader(nodata))
+	if err != nil || ranked {
+		t.Fatalf("want not ranked, got %t, %v", ranked, err)
+	}
+}
@@ -0,0 +1,110 @@
+package parse
+
+import "bytes"
+
+var (
+	markNoData      = []byte(`id="no-enough-data"`)
+	markGlobalRank  = []byte(`class="globleRank"`)
+	markCountryRank = []byte(`class="countryRank"`)
+	markMetricsData = []byte(`<strong class="metrics-data`)
+	markStrongEnd   = []byte(`</strong>`)
+	markCommentOpen = []byte(`<!--`)
+	markCommentEnd  = []byte(`-->`)
+	markAnchor      = []byte(`<a `)
+)
+
+// ScanRank is like ParseRank for a page held in memory, but finds the
+// markers by scanning bytes without building a DOM or allocating, for rank
+// checks over large saved corpora. The country is a slice of page as it is
+// written there, HTML entities are not decoded.
+func ScanRank(page []byte) (global, local uint, country []byte, err error) {
+	g := bytes.Index(page, markGlobalRank)
+	if nd := bytes.Index(page, markNoData); nd >= 0 && (g < 0 || nd < g) {
+		return 0, 0, nil, ErrNoEnoughData
+	}
+	if g < 0 {
+		return 0, 0, nil, &FieldError{"global rank"}
+	}
+
+	global, ok := scanMetric(page[g:])
+	if !ok {
+		return 0, 0, nil, &FieldError{"global rank"}
+	}
+
+	c := bytes.Index(page[g:], markCountryRank)
+	if c < 0 {
+		return global, 0, nil, &FieldError{"local rank"}
+	}
+	section := page[g+c:]
+
+	local, ok = scanMetric(section)
+	if !ok {
+		return global, 0, nil, &FieldError{"local rank"}
+	}
+
+	country = scanAnchorText(section)
+	if len(country) == 0 {
+		return global, local, nil, &FieldError{"country"}
+	}
+	return global, local, country, nil
+}
+
+// scanMetric reads the number of the first metrics-data element of b.
+func scanMetric(b []byte) (uint, bool) {
+	i := bytes.Index(b, markMetricsData)
+	if i < 0 {
+		return 0, false
+	}
+	b = b[i+len(markMetricsData):]
+	if i = bytes.IndexByte(b, '>'); i < 0 {
+		return 0, false
+	}
+	b = b[i+1:]
+	if i = bytes.Index(b, markStrongEnd); i < 0 {
+		return 0, false
+	}
+	b = b[:i]
+
+	var (
+		n      uint
+		digits bool
+	)
+	for len(b) > 0 {
+		if bytes.HasPrefix(b, markCommentOpen) {
+			end := bytes.Index(b, markCommentEnd)
+			if end < 0 {
+				return 0, false
+			}
+			b = b[end+len(markCommentEnd):]
+			continue
+		}
+
+		switch c := b[0]; {
+		case c >= '0' && c <= '9':
+			n = n*10 + uint(c-'0')
+			digits = true
+		case c == ',' || c == '.' || c == ' ' || c == '\n' || c == '\t' || c == '\r':
+		default:
+			return 0, false
+		}
+		b = b[1:]
+	}
+	return n, digits
+}
+
+// scanAnchorText returns the trimmed text of the first anchor of b.
+func scanAnchorText(b []byte) []byte {
+	i := bytes.Index(b, markAnchor)
+	if i < 0 {
+		return nil
+	}
+	b = b[i:]
+	if i = bytes.IndexByte(b, '>'); i < 0 {
+		return nil
+	}
+	b = b[i+1:]
+	if i = bytes.IndexByte(b, '<'); i < 0 {
+		return nil
+	}
+	return bytes.TrimSpace(b[:i])
+}
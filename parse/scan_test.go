@@ -0,0 +1,69 @@
+package parse
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestScanRank(t *testing.T) {
+	page, err := os.ReadFile(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	global, local, country, err := ScanRank(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantGlobal, wantLocal, wantCountry, err := ParseRank(bytes.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if global != wantGlobal || local != wantLocal || string(country) != wantCountry {
+		t.Fatalf("want %d, %d, %s, got %d, %d, %s", wantGlobal, wantLocal, wantCountry, global, local, country)
+	}
+
+	if n := testing.AllocsPerRun(100, func() { ScanRank(page) }); n != 0 {
+		t.Fatalf("want no allocations, got %v", n)
+	}
+}
+
+func TestScanRankNoData(t *testing.T) {
+	page, err := os.ReadFile(nodataTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, _, err := ScanRank(page); err != ErrNoEnoughData {
+		t.Fatalf("want %v, got %v", ErrNoEnoughData, err)
+	}
+}
+
+func BenchmarkScanRank(b *testing.B) {
+	page, err := os.ReadFile(successTestDocLoc)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, err := ScanRank(page); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseRank(b *testing.B) {
+	page, err := os.ReadFile(successTestDocLoc)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, _, _, err := ParseRank(bytes.NewReader(page)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package parse
+
+// SchemaVersion is the version of serialized sites, bumped whenever a change
+// of Site breaks stored records:
+//
+//	1: percents as {"Raw", "Value"} objects
+//	2: percents as numbers
+const SchemaVersion = 2
@@ -0,0 +1,186 @@
+package parse
+
+import (
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// SectionParser fills a section of a Site from the page, e.g. a panel the
+// built-in sections don't know of, storing it into Site.Custom.
+type SectionParser interface {
+	ParseSection(d *goquery.Document, s *Site) error
+}
+
+// SectionParserFunc is a function used as a SectionParser.
+type SectionParserFunc func(d *goquery.Document, s *Site) error
+
+// ParseSection calls f.
+func (f SectionParserFunc) ParseSection(d *goquery.Document, s *Site) error {
+	return f(d, s)
+}
+
+type section struct {
+	name  string
+	parse func(d *goquery.Document, o *options, s *Site) error
+}
+
+// registry keeps sections registered on top of the ones of every layout.
+var registry struct {
+	sync.RWMutex
+	sections []section
+}
+
+// RegisterSection replaces the section of the same name, one of
+// SectionNames, or adds a new one parsed after the others, whatever the
+// layout of a page. Parsing stops at the first section returning an error.
+func RegisterSection(name string, p SectionParser) {
+	sec := section{name, func(d *goquery.Document, o *options, s *Site) error {
+		return p.ParseSection(d, s)
+	}}
+
+	registry.Lock()
+	defer registry.Unlock()
+	sections := make([]section, 0, len(registry.sections)+1)
+	for _, r := range registry.sections {
+		if r.name != name {
+			sections = append(sections, r)
+		}
+	}
+	registry.sections = append(sections, sec)
+}
+
+// ResetSections restores the built-in sections.
+func ResetSections() {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.sections = nil
+}
+
+// SectionNames lists sections of the 2017 layout, assumed when none is
+// detected, in the order they are parsed.
+func SectionNames() []string {
+	sections := registeredSections(fallback)
+	names := make([]string, len(sections))
+	for i, sec := range sections {
+		names[i] = sec.name
+	}
+	return names
+}
+
+// registeredSections returns sections of l with the registered ones
+// replacing or following them.
+func registeredSections(l *layout) []section {
+	registry.RLock()
+	defer registry.RUnlock()
+	if len(registry.sections) == 0 {
+		return l.sections
+	}
+
+	sections := make([]section, len(l.sections), len(l.sections)+len(registry.sections))
+	copy(sections, l.sections)
+registered:
+	for _, r := range registry.sections {
+		for i := range sections {
+			if sections[i].name == r.name {
+				sections[i] = r
+				continue registered
+			}
+		}
+		sections = append(sections, r)
+	}
+	return sections
+}
+
+// sections2017 fill a Site from a page of the 2017 layout, the global rank
+// one is required for a Site to be returned at all.
+var sections2017 = []section{
+	{"global rank", func(d *goquery.Document, o *options, s *Site) error {
+		gr, err := globalRank(d, o)
+		s.GlobalRank = uint(gr)
+		return err
+	}},
+	{"local rank", func(d *goquery.Document, o *options, s *Site) error {
+		lr, err := localRank(d, o)
+		s.LocalRank = uint(lr)
+		return err
+	}},
+	{"country", func(d *goquery.Document, o *options, s *Site) error {
+		country, err := country(d, o)
+		if err != nil {
+			return err
+		}
+		s.MainCountry = country
+		s.MainCountryCode = o.countryCode(d.FindMatcher(sel(o.selector(seCountry))).First(), country)
+		return nil
+	}},
+	{"linking total", func(d *goquery.Document, o *options, s *Site) error {
+		lt, err := linkingTotal(d, o)
+		s.LinkingTotal = uint(lt)
+		return err
+	}},
+	{"site title", func(d *goquery.Document, o *options, s *Site) (err error) {
+		s.Title, err = title(d, o)
+		return err
+	}},
+	{"site description", func(d *goquery.Document, o *options, s *Site) (err error) {
+		s.Description, err = description(d, o)
+		return err
+	}},
+	{"visitors", func(d *goquery.Document, o *options, s *Site) (err error) {
+		s.Visitors, err = visitors(d, o)
+		return err
+	}},
+	{"keywords", func(d *goquery.Document, o *options, s *Site) (err error) {
+		s.Keywords, err = keywords(d, o)
+		if err == nil && o.normalizeKeywords {
+			s.Keywords = s.Keywords.Normalize().Dedupe()
+		}
+		return err
+	}},
+	{"upstream servers", func(d *goquery.Document, o *options, s *Site) (err error) {
+		s.Upstreams, err = upstreams(d, o)
+		return err
+	}},
+	{"linking sites", func(d *goquery.Document, o *options, s *Site) (err error) {
+		s.LinksFrom, err = linksFrom(d, o)
+		return err
+	}},
+	{"related sites", relatedSection(seRelated)},
+	{"categories", categoriesSection(seCategories)},
+	{"subdomains", func(d *goquery.Document, o *options, s *Site) (err error) {
+		s.Subdomains, err = subdomains(d, o)
+		return err
+	}},
+	{"trends", func(d *goquery.Document, o *options, s *Site) error {
+		s.Trends = trends(d)
+		s.PageviewsPerVisitor = trend(metricsCat(d, "pageviews_per_visitor"))
+		s.BounceRate = trend(metricsCat(d, "bounce_percent"))
+		s.TrafficSources.Search = trend(metricsCat(d, "search_percent"))
+		return nil
+	}},
+}
+
+// relatedSection reads related sites from the table of selector.
+func relatedSection(selector string) func(d *goquery.Document, o *options, s *Site) error {
+	return func(d *goquery.Document, o *options, s *Site) (err error) {
+		s.Related, err = related(d, o.selector(selector))
+		return err
+	}
+}
+
+// categoriesSection reads categories from the table of selector.
+func categoriesSection(selector string) func(d *goquery.Document, o *options, s *Site) error {
+	return func(d *goquery.Document, o *options, s *Site) error {
+		cps, urls, err := categoryPaths(d, o.selector(selector))
+		if err != nil {
+			return err
+		}
+		s.CategoryPaths = cps
+		s.CategoryURLs = urls
+		for _, p := range cps {
+			s.Categories = append(s.Categories, p...)
+		}
+		return nil
+	}
+}
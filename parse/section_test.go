@@ -0,0 +1,47 @@
+package parse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestRegisterSection(t *testing.T) {
+	defer ResetSections()
+
+	RegisterSection("keywords", SectionParserFunc(func(d *goquery.Document, s *Site) error {
+		s.Keywords = Keywords{{Word: "replaced"}}
+		return nil
+	}))
+	RegisterSection("contact email", SectionParserFunc(func(d *goquery.Document, s *Site) error {
+		s.Custom = map[string]string{"contact email": "info@sberbank.ru"}
+		return nil
+	}))
+
+	names := SectionNames()
+	if len(names) != len(sections2017)+1 || names[len(names)-1] != "contact email" {
+		t.Fatalf("want contact email added last, got %v", names)
+	}
+
+	body, err := testDoc(successTestDocLoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := Parse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.Keywords, Keywords{{Word: "replaced"}}) {
+		t.Fatalf("want keywords replaced, got %v", s.Keywords)
+	}
+	if s.Custom["contact email"] != "info@sberbank.ru" {
+		t.Fatalf("want a custom section, got %v", s.Custom)
+	}
+
+	ResetSections()
+	if got := strings.Join(SectionNames(), ","); strings.Contains(got, "contact email") {
+		t.Fatalf("want built-in sections, got %s", got)
+	}
+}
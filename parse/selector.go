@@ -0,0 +1,35 @@
+package parse
+
+import (
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
+)
+
+// selectors memoizes compiled selectors, as compiling them anew on every
+// Find takes a noticeable share of parsing.
+var selectors sync.Map
+
+// sel returns the compiled selector, an invalid one matches nothing like
+// it does with Find.
+func sel(selector string) goquery.Matcher {
+	if m, ok := selectors.Load(selector); ok {
+		return m.(goquery.Matcher)
+	}
+
+	var m goquery.Matcher = nothing{}
+	if c, err := cascadia.Compile(selector); err == nil {
+		m = c
+	}
+	selectors.Store(selector, m)
+	return m
+}
+
+// nothing is a Matcher matching no nodes.
+type nothing struct{}
+
+func (nothing) Match(*html.Node) bool            { return false }
+func (nothing) MatchAll(*html.Node) []*html.Node { return nil }
+func (nothing) Filter([]*html.Node) []*html.Node { return nil }
@@ -0,0 +1,85 @@
+package parse
+
+import "strings"
+
+// RankIn returns the rank of the site in country, matched case-insensitively
+// against the main country and visitors.
+func (s *Site) RankIn(country string) (uint, bool) {
+	country = strings.TrimSpace(country)
+	if s.LocalRank != 0 && strings.EqualFold(s.MainCountry, country) {
+		return s.LocalRank, true
+	}
+	for _, v := range s.Visitors {
+		if v.LocalRank != 0 && strings.EqualFold(v.Country, country) {
+			return v.LocalRank, true
+		}
+	}
+	return 0, false
+}
+
+// maxPercentSum is how much percents of a section may add up to, shares of
+// subdomains overlap as visitors go to several of them.
+const maxPercentSum = 150
+
+// Warning is an implausible value found by Validate.
+type Warning struct {
+	Field  string
+	Reason string
+}
+
+func (w Warning) String() string {
+	return w.Field + ": " + w.Reason
+}
+
+// Validate flags implausible data, often a sign of selectors silently
+// matching the wrong elements after a layout change.
+func (s *Site) Validate() []Warning {
+	var ws []Warning
+
+	sections := []struct {
+		field string
+		ps    []Percent
+	}{
+		{"visitors", VisitorPercents(s.Visitors)},
+		{"keywords", keywordPercents(s.Keywords)},
+		{"upstreams", upstreamPercents(s.Upstreams)},
+		{"subdomains", SubdomainPercents(s.Subdomains)},
+	}
+	populated := false
+	for _, sec := range sections {
+		populated = populated || len(sec.ps) > 0
+		for _, p := range sec.ps {
+			if err := p.Validate(); err != nil {
+				ws = append(ws, Warning{sec.field, err.Error()})
+			}
+		}
+		if sum := Sum(sec.ps...); sum.Value > maxPercentSum {
+			ws = append(ws, Warning{sec.field, "percents add up to " + sum.String()})
+		}
+	}
+
+	if s.GlobalRank == 0 && populated {
+		ws = append(ws, Warning{"global rank", "zero with populated tables"})
+	}
+	if s.MainCountry == "" && s.LocalRank != 0 {
+		ws = append(ws, Warning{"country", "empty with non-zero local rank"})
+	}
+
+	return ws
+}
+
+func keywordPercents(ks []Keyword) []Percent {
+	ps := make([]Percent, len(ks))
+	for i, k := range ks {
+		ps[i] = k.Percent
+	}
+	return ps
+}
+
+func upstreamPercents(us []Upstream) []Percent {
+	ps := make([]Percent, len(us))
+	for i, u := range us {
+		ps[i] = u.Percent
+	}
+	return ps
+}
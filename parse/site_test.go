@@ -0,0 +1,47 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRankIn(t *testing.T) {
+	tests := []struct {
+		country string
+		rank    uint
+		ok      bool
+	}{
+		{"Russia", 17, true},
+		{"germany", 1366, true},
+		{" United States ", 7997, true},
+		{"France", 0, false},
+	}
+	for _, tt := range tests {
+		rank, ok := successTestSite.RankIn(tt.country)
+		if rank != tt.rank || ok != tt.ok {
+			t.Fatalf("%s: want %d, %t, got %d, %t", tt.country, tt.rank, tt.ok, rank, ok)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if ws := successTestSite.Validate(); len(ws) != 0 {
+		t.Fatalf("want no warnings, got %v", ws)
+	}
+
+	s := &Site{
+		LocalRank: 17,
+		Subdomains: []Subdomain{
+			{Domain: "sberbank.ru", Percent: Percent{"90%", 90}},
+			{Domain: "online.sberbank.ru", Percent: Percent{"80%", 80}},
+		},
+	}
+	want := []Warning{
+		{"subdomains", "percents add up to 170%"},
+		{"global rank", "zero with populated tables"},
+		{"country", "empty with non-zero local rank"},
+	}
+	if ws := s.Validate(); !reflect.DeepEqual(ws, want) {
+		t.Fatalf("want %v, got %v", want, ws)
+	}
+}
@@ -0,0 +1,82 @@
+package parse
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Trends are changes of the figures versus the previous 3 months as shown
+// on the page, positive when a figure grew. A growing rank number means the
+// site became less popular.
+type Trends struct {
+	GlobalRank   float64 // positions
+	LinkingTotal float64 // sites
+	BounceRate   float64 // percent
+	Pageviews    float64 // percent of daily pageviews per visitor
+	TimeOnSite   float64 // percent of daily time on site
+}
+
+// Trend is the current value of a figure along with its change versus the
+// previous 3 months.
+type Trend struct {
+	Value  float64
+	Change float64 // percent, negative when the figure went down
+	Arrow  string  // up, down or empty when unchanged
+}
+
+// LinkingCount is a number of sites linking in as of a date.
+type LinkingCount struct {
+	Date  time.Time
+	Total uint
+}
+
+// TrafficSources are shares of visits by where they come from.
+type TrafficSources struct {
+	Search Trend // percent of visits from search engines
+}
+
+// trend reads a figure of s like 5.52 or 25.10% with its change.
+func trend(s *goquery.Selection) Trend {
+	raw := strings.TrimSpace(s.FindMatcher(sel("strong.metrics-data")).First().Text())
+	v, _ := parseDecimal(strings.TrimSpace(strings.TrimSuffix(raw, "%")))
+
+	t := Trend{Value: v, Change: change(s)}
+	switch {
+	case t.Change > 0:
+		t.Arrow = "up"
+	case t.Change < 0:
+		t.Arrow = "down"
+	}
+	return t
+}
+
+// metricsCat is the container of a figure of the 2017 layout by its name.
+func metricsCat(d *goquery.Document, cat string) *goquery.Selection {
+	return d.FindMatcher(sel(`span[data-cat="` + cat + `"]`))
+}
+
+// change reads the change arrow of a figure like 4.00% with the class
+// telling the direction, 0 if the figure is unchanged or not shown.
+func change(s *goquery.Selection) float64 {
+	w := s.FindMatcher(sel("span.change-wrapper")).First()
+	v, err := parseDecimal(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(w.Text()), "%")))
+	if err != nil {
+		return 0
+	}
+	if w.HasClass("change-down") {
+		return -v
+	}
+	return v
+}
+
+// trends reads 90-day changes of the 2017 layout, missing ones are left 0.
+func trends(d *goquery.Document) Trends {
+	return Trends{
+		GlobalRank: change(metricsCat(d, "globalRank")),
+		BounceRate: change(metricsCat(d, "bounce_percent")),
+		Pageviews:  change(metricsCat(d, "pageviews_per_visitor")),
+		TimeOnSite: change(metricsCat(d, "time_on_site")),
+	}
+}
@@ -0,0 +1,1946 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: asip.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Site is Website Traffic Statistics from alexa.com.
+type Site struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Domain              string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Title               string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description         string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	MainCountry         string                 `protobuf:"bytes,4,opt,name=main_country,json=mainCountry,proto3" json:"main_country,omitempty"`
+	GlobalRank          uint64                 `protobuf:"varint,5,opt,name=global_rank,json=globalRank,proto3" json:"global_rank,omitempty"`
+	LocalRank           uint64                 `protobuf:"varint,6,opt,name=local_rank,json=localRank,proto3" json:"local_rank,omitempty"`
+	LinkingTotal        uint64                 `protobuf:"varint,7,opt,name=linking_total,json=linkingTotal,proto3" json:"linking_total,omitempty"`
+	Visitors            []*Visitor             `protobuf:"bytes,8,rep,name=visitors,proto3" json:"visitors,omitempty"`
+	Keywords            []*Keyword             `protobuf:"bytes,9,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	Upstreams           []*Upstream            `protobuf:"bytes,10,rep,name=upstreams,proto3" json:"upstreams,omitempty"`
+	Related             []string               `protobuf:"bytes,11,rep,name=related,proto3" json:"related,omitempty"`
+	Subdomains          []*Subdomain           `protobuf:"bytes,12,rep,name=subdomains,proto3" json:"subdomains,omitempty"`
+	Categories          []string               `protobuf:"bytes,13,rep,name=categories,proto3" json:"categories,omitempty"`
+	LinksFrom           []*Link                `protobuf:"bytes,14,rep,name=links_from,json=linksFrom,proto3" json:"links_from,omitempty"`
+	MainCountryCode     string                 `protobuf:"bytes,15,opt,name=main_country_code,json=mainCountryCode,proto3" json:"main_country_code,omitempty"`
+	CategoryPaths       []*CategoryPath        `protobuf:"bytes,16,rep,name=category_paths,json=categoryPaths,proto3" json:"category_paths,omitempty"`
+	CategoryUrls        []string               `protobuf:"bytes,17,rep,name=category_urls,json=categoryUrls,proto3" json:"category_urls,omitempty"`
+	Custom              map[string]string      `protobuf:"bytes,18,rep,name=custom,proto3" json:"custom,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Layout              string                 `protobuf:"bytes,19,opt,name=layout,proto3" json:"layout,omitempty"`
+	RankSummary         string                 `protobuf:"bytes,20,opt,name=rank_summary,json=rankSummary,proto3" json:"rank_summary,omitempty"`
+	RankPercentile      float64                `protobuf:"fixed64,21,opt,name=rank_percentile,json=rankPercentile,proto3" json:"rank_percentile,omitempty"`
+	Trends              *Trends                `protobuf:"bytes,22,opt,name=trends,proto3" json:"trends,omitempty"`
+	PageviewsPerVisitor *Trend                 `protobuf:"bytes,23,opt,name=pageviews_per_visitor,json=pageviewsPerVisitor,proto3" json:"pageviews_per_visitor,omitempty"`
+	BounceRate          *Trend                 `protobuf:"bytes,24,opt,name=bounce_rate,json=bounceRate,proto3" json:"bounce_rate,omitempty"`
+	TrafficSources      *TrafficSources        `protobuf:"bytes,25,opt,name=traffic_sources,json=trafficSources,proto3" json:"traffic_sources,omitempty"`
+	LinkingHistory      []*LinkingCount        `protobuf:"bytes,26,rep,name=linking_history,json=linkingHistory,proto3" json:"linking_history,omitempty"`
+	Meta                *Meta                  `protobuf:"bytes,27,opt,name=meta,proto3" json:"meta,omitempty"` // but the layout, kept in layout
+	BatchPercentile     float64                `protobuf:"fixed64,28,opt,name=batch_percentile,json=batchPercentile,proto3" json:"batch_percentile,omitempty"`
+	Dns                 []*DNSRecords          `protobuf:"bytes,29,rep,name=dns,proto3" json:"dns,omitempty"`
+	Enrichment          *Enrichment            `protobuf:"bytes,30,opt,name=enrichment,proto3" json:"enrichment,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Site) Reset() {
+	*x = Site{}
+	mi := &file_asip_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Site) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Site) ProtoMessage() {}
+
+func (x *Site) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Site.ProtoReflect.Descriptor instead.
+func (*Site) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Site) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *Site) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Site) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Site) GetMainCountry() string {
+	if x != nil {
+		return x.MainCountry
+	}
+	return ""
+}
+
+func (x *Site) GetGlobalRank() uint64 {
+	if x != nil {
+		return x.GlobalRank
+	}
+	return 0
+}
+
+func (x *Site) GetLocalRank() uint64 {
+	if x != nil {
+		return x.LocalRank
+	}
+	return 0
+}
+
+func (x *Site) GetLinkingTotal() uint64 {
+	if x != nil {
+		return x.LinkingTotal
+	}
+	return 0
+}
+
+func (x *Site) GetVisitors() []*Visitor {
+	if x != nil {
+		return x.Visitors
+	}
+	return nil
+}
+
+func (x *Site) GetKeywords() []*Keyword {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+func (x *Site) GetUpstreams() []*Upstream {
+	if x != nil {
+		return x.Upstreams
+	}
+	return nil
+}
+
+func (x *Site) GetRelated() []string {
+	if x != nil {
+		return x.Related
+	}
+	return nil
+}
+
+func (x *Site) GetSubdomains() []*Subdomain {
+	if x != nil {
+		return x.Subdomains
+	}
+	return nil
+}
+
+func (x *Site) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Site) GetLinksFrom() []*Link {
+	if x != nil {
+		return x.LinksFrom
+	}
+	return nil
+}
+
+func (x *Site) GetMainCountryCode() string {
+	if x != nil {
+		return x.MainCountryCode
+	}
+	return ""
+}
+
+func (x *Site) GetCategoryPaths() []*CategoryPath {
+	if x != nil {
+		return x.CategoryPaths
+	}
+	return nil
+}
+
+func (x *Site) GetCategoryUrls() []string {
+	if x != nil {
+		return x.CategoryUrls
+	}
+	return nil
+}
+
+func (x *Site) GetCustom() map[string]string {
+	if x != nil {
+		return x.Custom
+	}
+	return nil
+}
+
+func (x *Site) GetLayout() string {
+	if x != nil {
+		return x.Layout
+	}
+	return ""
+}
+
+func (x *Site) GetRankSummary() string {
+	if x != nil {
+		return x.RankSummary
+	}
+	return ""
+}
+
+func (x *Site) GetRankPercentile() float64 {
+	if x != nil {
+		return x.RankPercentile
+	}
+	return 0
+}
+
+func (x *Site) GetTrends() *Trends {
+	if x != nil {
+		return x.Trends
+	}
+	return nil
+}
+
+func (x *Site) GetPageviewsPerVisitor() *Trend {
+	if x != nil {
+		return x.PageviewsPerVisitor
+	}
+	return nil
+}
+
+func (x *Site) GetBounceRate() *Trend {
+	if x != nil {
+		return x.BounceRate
+	}
+	return nil
+}
+
+func (x *Site) GetTrafficSources() *TrafficSources {
+	if x != nil {
+		return x.TrafficSources
+	}
+	return nil
+}
+
+func (x *Site) GetLinkingHistory() []*LinkingCount {
+	if x != nil {
+		return x.LinkingHistory
+	}
+	return nil
+}
+
+func (x *Site) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *Site) GetBatchPercentile() float64 {
+	if x != nil {
+		return x.BatchPercentile
+	}
+	return 0
+}
+
+func (x *Site) GetDns() []*DNSRecords {
+	if x != nil {
+		return x.Dns
+	}
+	return nil
+}
+
+func (x *Site) GetEnrichment() *Enrichment {
+	if x != nil {
+		return x.Enrichment
+	}
+	return nil
+}
+
+// Meta describes where and when a Site was fetched.
+type Meta struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FetchedAt       int64                  `protobuf:"varint,1,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"` // unix nanoseconds, 0 if unknown
+	SourceUrl       string                 `protobuf:"bytes,2,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	FinalUrl        string                 `protobuf:"bytes,3,opt,name=final_url,json=finalUrl,proto3" json:"final_url,omitempty"`
+	Redirects       []string               `protobuf:"bytes,4,rep,name=redirects,proto3" json:"redirects,omitempty"`
+	CanonicalDomain string                 `protobuf:"bytes,5,opt,name=canonical_domain,json=canonicalDomain,proto3" json:"canonical_domain,omitempty"`
+	HttpStatus      int32                  `protobuf:"varint,6,opt,name=http_status,json=httpStatus,proto3" json:"http_status,omitempty"`
+	Duration        int64                  `protobuf:"varint,7,opt,name=duration,proto3" json:"duration,omitempty"` // nanoseconds
+	Retries         int32                  `protobuf:"varint,8,opt,name=retries,proto3" json:"retries,omitempty"`
+	Bytes           int64                  `protobuf:"varint,9,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	FromCache       bool                   `protobuf:"varint,10,opt,name=from_cache,json=fromCache,proto3" json:"from_cache,omitempty"`
+	Provider        string                 `protobuf:"bytes,11,opt,name=provider,proto3" json:"provider,omitempty"`
+	Headers         map[string]string      `protobuf:"bytes,12,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Meta) Reset() {
+	*x = Meta{}
+	mi := &file_asip_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Meta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Meta) ProtoMessage() {}
+
+func (x *Meta) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Meta.ProtoReflect.Descriptor instead.
+func (*Meta) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Meta) GetFetchedAt() int64 {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return 0
+}
+
+func (x *Meta) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *Meta) GetFinalUrl() string {
+	if x != nil {
+		return x.FinalUrl
+	}
+	return ""
+}
+
+func (x *Meta) GetRedirects() []string {
+	if x != nil {
+		return x.Redirects
+	}
+	return nil
+}
+
+func (x *Meta) GetCanonicalDomain() string {
+	if x != nil {
+		return x.CanonicalDomain
+	}
+	return ""
+}
+
+func (x *Meta) GetHttpStatus() int32 {
+	if x != nil {
+		return x.HttpStatus
+	}
+	return 0
+}
+
+func (x *Meta) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Meta) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *Meta) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *Meta) GetFromCache() bool {
+	if x != nil {
+		return x.FromCache
+	}
+	return false
+}
+
+func (x *Meta) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Meta) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+// DNSRecords are resolved records of a single host.
+type DNSRecords struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	A             []string               `protobuf:"bytes,2,rep,name=a,proto3" json:"a,omitempty"`
+	Aaaa          []string               `protobuf:"bytes,3,rep,name=aaaa,proto3" json:"aaaa,omitempty"`
+	Ns            []string               `protobuf:"bytes,4,rep,name=ns,proto3" json:"ns,omitempty"`
+	Mx            []string               `protobuf:"bytes,5,rep,name=mx,proto3" json:"mx,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DNSRecords) Reset() {
+	*x = DNSRecords{}
+	mi := &file_asip_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DNSRecords) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSRecords) ProtoMessage() {}
+
+func (x *DNSRecords) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSRecords.ProtoReflect.Descriptor instead.
+func (*DNSRecords) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DNSRecords) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *DNSRecords) GetA() []string {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *DNSRecords) GetAaaa() []string {
+	if x != nil {
+		return x.Aaaa
+	}
+	return nil
+}
+
+func (x *DNSRecords) GetNs() []string {
+	if x != nil {
+		return x.Ns
+	}
+	return nil
+}
+
+func (x *DNSRecords) GetMx() []string {
+	if x != nil {
+		return x.Mx
+	}
+	return nil
+}
+
+// Enrichment holds data gathered from third-party services, a missing
+// message stands for a service not asked.
+type Enrichment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SecurityTrails *SecurityTrails        `protobuf:"bytes,1,opt,name=security_trails,json=securityTrails,proto3" json:"security_trails,omitempty"`
+	Urlscan        *URLScan               `protobuf:"bytes,2,opt,name=urlscan,proto3" json:"urlscan,omitempty"`
+	Virustotal     *VirusTotal            `protobuf:"bytes,3,opt,name=virustotal,proto3" json:"virustotal,omitempty"`
+	Geoip          *GeoIP                 `protobuf:"bytes,4,opt,name=geoip,proto3" json:"geoip,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Enrichment) Reset() {
+	*x = Enrichment{}
+	mi := &file_asip_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Enrichment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Enrichment) ProtoMessage() {}
+
+func (x *Enrichment) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Enrichment.ProtoReflect.Descriptor instead.
+func (*Enrichment) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Enrichment) GetSecurityTrails() *SecurityTrails {
+	if x != nil {
+		return x.SecurityTrails
+	}
+	return nil
+}
+
+func (x *Enrichment) GetUrlscan() *URLScan {
+	if x != nil {
+		return x.Urlscan
+	}
+	return nil
+}
+
+func (x *Enrichment) GetVirustotal() *VirusTotal {
+	if x != nil {
+		return x.Virustotal
+	}
+	return nil
+}
+
+func (x *Enrichment) GetGeoip() *GeoIP {
+	if x != nil {
+		return x.Geoip
+	}
+	return nil
+}
+
+type SecurityTrails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subdomains    []string               `protobuf:"bytes,1,rep,name=subdomains,proto3" json:"subdomains,omitempty"`
+	HistoricalA   []*HistoricalRecord    `protobuf:"bytes,2,rep,name=historical_a,json=historicalA,proto3" json:"historical_a,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SecurityTrails) Reset() {
+	*x = SecurityTrails{}
+	mi := &file_asip_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecurityTrails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecurityTrails) ProtoMessage() {}
+
+func (x *SecurityTrails) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecurityTrails.ProtoReflect.Descriptor instead.
+func (*SecurityTrails) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SecurityTrails) GetSubdomains() []string {
+	if x != nil {
+		return x.Subdomains
+	}
+	return nil
+}
+
+func (x *SecurityTrails) GetHistoricalA() []*HistoricalRecord {
+	if x != nil {
+		return x.HistoricalA
+	}
+	return nil
+}
+
+type HistoricalRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	Organizations []string               `protobuf:"bytes,2,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	FirstSeen     string                 `protobuf:"bytes,3,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen      string                 `protobuf:"bytes,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoricalRecord) Reset() {
+	*x = HistoricalRecord{}
+	mi := &file_asip_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoricalRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoricalRecord) ProtoMessage() {}
+
+func (x *HistoricalRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoricalRecord.ProtoReflect.Descriptor instead.
+func (*HistoricalRecord) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HistoricalRecord) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *HistoricalRecord) GetOrganizations() []string {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+func (x *HistoricalRecord) GetFirstSeen() string {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return ""
+}
+
+func (x *HistoricalRecord) GetLastSeen() string {
+	if x != nil {
+		return x.LastSeen
+	}
+	return ""
+}
+
+type URLScan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	ResultUrl     string                 `protobuf:"bytes,2,opt,name=result_url,json=resultUrl,proto3" json:"result_url,omitempty"`
+	Screenshot    string                 `protobuf:"bytes,3,opt,name=screenshot,proto3" json:"screenshot,omitempty"`
+	Pending       bool                   `protobuf:"varint,4,opt,name=pending,proto3" json:"pending,omitempty"`
+	Malicious     bool                   `protobuf:"varint,5,opt,name=malicious,proto3" json:"malicious,omitempty"`
+	Score         int64                  `protobuf:"varint,6,opt,name=score,proto3" json:"score,omitempty"`
+	Categories    []string               `protobuf:"bytes,7,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *URLScan) Reset() {
+	*x = URLScan{}
+	mi := &file_asip_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *URLScan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*URLScan) ProtoMessage() {}
+
+func (x *URLScan) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use URLScan.ProtoReflect.Descriptor instead.
+func (*URLScan) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *URLScan) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *URLScan) GetResultUrl() string {
+	if x != nil {
+		return x.ResultUrl
+	}
+	return ""
+}
+
+func (x *URLScan) GetScreenshot() string {
+	if x != nil {
+		return x.Screenshot
+	}
+	return ""
+}
+
+func (x *URLScan) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *URLScan) GetMalicious() bool {
+	if x != nil {
+		return x.Malicious
+	}
+	return false
+}
+
+func (x *URLScan) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *URLScan) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type VirusTotal struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Malicious       int64                  `protobuf:"varint,1,opt,name=malicious,proto3" json:"malicious,omitempty"`
+	Suspicious      int64                  `protobuf:"varint,2,opt,name=suspicious,proto3" json:"suspicious,omitempty"`
+	Harmless        int64                  `protobuf:"varint,3,opt,name=harmless,proto3" json:"harmless,omitempty"`
+	Undetected      int64                  `protobuf:"varint,4,opt,name=undetected,proto3" json:"undetected,omitempty"`
+	Reputation      int64                  `protobuf:"varint,5,opt,name=reputation,proto3" json:"reputation,omitempty"`
+	Categories      map[string]string      `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	PopularityRanks map[string]uint64      `protobuf:"bytes,7,rep,name=popularity_ranks,json=popularityRanks,proto3" json:"popularity_ranks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VirusTotal) Reset() {
+	*x = VirusTotal{}
+	mi := &file_asip_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VirusTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VirusTotal) ProtoMessage() {}
+
+func (x *VirusTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VirusTotal.ProtoReflect.Descriptor instead.
+func (*VirusTotal) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VirusTotal) GetMalicious() int64 {
+	if x != nil {
+		return x.Malicious
+	}
+	return 0
+}
+
+func (x *VirusTotal) GetSuspicious() int64 {
+	if x != nil {
+		return x.Suspicious
+	}
+	return 0
+}
+
+func (x *VirusTotal) GetHarmless() int64 {
+	if x != nil {
+		return x.Harmless
+	}
+	return 0
+}
+
+func (x *VirusTotal) GetUndetected() int64 {
+	if x != nil {
+		return x.Undetected
+	}
+	return 0
+}
+
+func (x *VirusTotal) GetReputation() int64 {
+	if x != nil {
+		return x.Reputation
+	}
+	return 0
+}
+
+func (x *VirusTotal) GetCategories() map[string]string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *VirusTotal) GetPopularityRanks() map[string]uint64 {
+	if x != nil {
+		return x.PopularityRanks
+	}
+	return nil
+}
+
+type GeoIP struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hosts         []*GeoIPHost           `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Mismatch      bool                   `protobuf:"varint,2,opt,name=mismatch,proto3" json:"mismatch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoIP) Reset() {
+	*x = GeoIP{}
+	mi := &file_asip_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoIP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoIP) ProtoMessage() {}
+
+func (x *GeoIP) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoIP.ProtoReflect.Descriptor instead.
+func (*GeoIP) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GeoIP) GetHosts() []*GeoIPHost {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *GeoIP) GetMismatch() bool {
+	if x != nil {
+		return x.Mismatch
+	}
+	return false
+}
+
+type GeoIPHost struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ip            string                 `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	CountryCode   string                 `protobuf:"bytes,2,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoIPHost) Reset() {
+	*x = GeoIPHost{}
+	mi := &file_asip_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoIPHost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoIPHost) ProtoMessage() {}
+
+func (x *GeoIPHost) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoIPHost.ProtoReflect.Descriptor instead.
+func (*GeoIPHost) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GeoIPHost) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *GeoIPHost) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+type LinkingCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          int64                  `protobuf:"varint,1,opt,name=date,proto3" json:"date,omitempty"` // unix seconds
+	Total         uint64                 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkingCount) Reset() {
+	*x = LinkingCount{}
+	mi := &file_asip_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkingCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkingCount) ProtoMessage() {}
+
+func (x *LinkingCount) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkingCount.ProtoReflect.Descriptor instead.
+func (*LinkingCount) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LinkingCount) GetDate() int64 {
+	if x != nil {
+		return x.Date
+	}
+	return 0
+}
+
+func (x *LinkingCount) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type TrafficSources struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Search        *Trend                 `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrafficSources) Reset() {
+	*x = TrafficSources{}
+	mi := &file_asip_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrafficSources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrafficSources) ProtoMessage() {}
+
+func (x *TrafficSources) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrafficSources.ProtoReflect.Descriptor instead.
+func (*TrafficSources) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TrafficSources) GetSearch() *Trend {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+type Trend struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         float64                `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Change        float64                `protobuf:"fixed64,2,opt,name=change,proto3" json:"change,omitempty"`
+	Arrow         string                 `protobuf:"bytes,3,opt,name=arrow,proto3" json:"arrow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trend) Reset() {
+	*x = Trend{}
+	mi := &file_asip_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trend) ProtoMessage() {}
+
+func (x *Trend) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trend.ProtoReflect.Descriptor instead.
+func (*Trend) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Trend) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Trend) GetChange() float64 {
+	if x != nil {
+		return x.Change
+	}
+	return 0
+}
+
+func (x *Trend) GetArrow() string {
+	if x != nil {
+		return x.Arrow
+	}
+	return ""
+}
+
+type Trends struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GlobalRank    float64                `protobuf:"fixed64,1,opt,name=global_rank,json=globalRank,proto3" json:"global_rank,omitempty"`
+	BounceRate    float64                `protobuf:"fixed64,2,opt,name=bounce_rate,json=bounceRate,proto3" json:"bounce_rate,omitempty"`
+	Pageviews     float64                `protobuf:"fixed64,3,opt,name=pageviews,proto3" json:"pageviews,omitempty"`
+	TimeOnSite    float64                `protobuf:"fixed64,4,opt,name=time_on_site,json=timeOnSite,proto3" json:"time_on_site,omitempty"`
+	LinkingTotal  float64                `protobuf:"fixed64,5,opt,name=linking_total,json=linkingTotal,proto3" json:"linking_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trends) Reset() {
+	*x = Trends{}
+	mi := &file_asip_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trends) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trends) ProtoMessage() {}
+
+func (x *Trends) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trends.ProtoReflect.Descriptor instead.
+func (*Trends) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Trends) GetGlobalRank() float64 {
+	if x != nil {
+		return x.GlobalRank
+	}
+	return 0
+}
+
+func (x *Trends) GetBounceRate() float64 {
+	if x != nil {
+		return x.BounceRate
+	}
+	return 0
+}
+
+func (x *Trends) GetPageviews() float64 {
+	if x != nil {
+		return x.Pageviews
+	}
+	return 0
+}
+
+func (x *Trends) GetTimeOnSite() float64 {
+	if x != nil {
+		return x.TimeOnSite
+	}
+	return 0
+}
+
+func (x *Trends) GetLinkingTotal() float64 {
+	if x != nil {
+		return x.LinkingTotal
+	}
+	return 0
+}
+
+// CategoryPath is a DMOZ-style breadcrumb from the top category down.
+type CategoryPath struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryPath) Reset() {
+	*x = CategoryPath{}
+	mi := &file_asip_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryPath) ProtoMessage() {}
+
+func (x *CategoryPath) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryPath.ProtoReflect.Descriptor instead.
+func (*CategoryPath) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CategoryPath) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+// Percent is a share as printed on the page along with its numeric value.
+type Percent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Raw           string                 `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Percent) Reset() {
+	*x = Percent{}
+	mi := &file_asip_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Percent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Percent) ProtoMessage() {}
+
+func (x *Percent) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Percent.ProtoReflect.Descriptor instead.
+func (*Percent) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Percent) GetRaw() string {
+	if x != nil {
+		return x.Raw
+	}
+	return ""
+}
+
+func (x *Percent) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// Link is a site and page that links to the website.
+type Link struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Site          string                 `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	Page          string                 `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Rel           []string               `protobuf:"bytes,4,rep,name=rel,proto3" json:"rel,omitempty"`
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Link) Reset() {
+	*x = Link{}
+	mi := &file_asip_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Link) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Link) ProtoMessage() {}
+
+func (x *Link) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Link.ProtoReflect.Descriptor instead.
+func (*Link) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Link) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *Link) GetPage() string {
+	if x != nil {
+		return x.Page
+	}
+	return ""
+}
+
+func (x *Link) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Link) GetRel() []string {
+	if x != nil {
+		return x.Rel
+	}
+	return nil
+}
+
+func (x *Link) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+// Visitor represents a variety of visitors from a single country.
+type Visitor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Country       string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	Percent       *Percent               `protobuf:"bytes,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	LocalRank     uint64                 `protobuf:"varint,3,opt,name=local_rank,json=localRank,proto3" json:"local_rank,omitempty"`
+	CountryCode   string                 `protobuf:"bytes,4,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Visitor) Reset() {
+	*x = Visitor{}
+	mi := &file_asip_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Visitor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Visitor) ProtoMessage() {}
+
+func (x *Visitor) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Visitor.ProtoReflect.Descriptor instead.
+func (*Visitor) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Visitor) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Visitor) GetPercent() *Percent {
+	if x != nil {
+		return x.Percent
+	}
+	return nil
+}
+
+func (x *Visitor) GetLocalRank() uint64 {
+	if x != nil {
+		return x.LocalRank
+	}
+	return 0
+}
+
+func (x *Visitor) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+// Keyword is a one of the top keywords from search engines.
+type Keyword struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Percent       *Percent               `protobuf:"bytes,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Keyword) Reset() {
+	*x = Keyword{}
+	mi := &file_asip_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Keyword) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Keyword) ProtoMessage() {}
+
+func (x *Keyword) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Keyword.ProtoReflect.Descriptor instead.
+func (*Keyword) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Keyword) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Keyword) GetPercent() *Percent {
+	if x != nil {
+		return x.Percent
+	}
+	return nil
+}
+
+// Upstream sites people visited immediately before this site.
+type Upstream struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Site          string                 `protobuf:"bytes,1,opt,name=site,proto3" json:"site,omitempty"`
+	Percent       *Percent               `protobuf:"bytes,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Rank          uint64                 `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Upstream) Reset() {
+	*x = Upstream{}
+	mi := &file_asip_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Upstream) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream) ProtoMessage() {}
+
+func (x *Upstream) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream.ProtoReflect.Descriptor instead.
+func (*Upstream) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Upstream) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *Upstream) GetPercent() *Percent {
+	if x != nil {
+		return x.Percent
+	}
+	return nil
+}
+
+func (x *Upstream) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Upstream) GetRank() uint64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+// Subdomain represent subdomains where visitors go from the site.
+type Subdomain struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Percent       *Percent               `protobuf:"bytes,2,opt,name=percent,proto3" json:"percent,omitempty"`
+	InTraffic     bool                   `protobuf:"varint,3,opt,name=in_traffic,json=inTraffic,proto3" json:"in_traffic,omitempty"`
+	InCt          bool                   `protobuf:"varint,4,opt,name=in_ct,json=inCt,proto3" json:"in_ct,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subdomain) Reset() {
+	*x = Subdomain{}
+	mi := &file_asip_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subdomain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subdomain) ProtoMessage() {}
+
+func (x *Subdomain) ProtoReflect() protoreflect.Message {
+	mi := &file_asip_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subdomain.ProtoReflect.Descriptor instead.
+func (*Subdomain) Descriptor() ([]byte, []int) {
+	return file_asip_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Subdomain) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *Subdomain) GetPercent() *Percent {
+	if x != nil {
+		return x.Percent
+	}
+	return nil
+}
+
+func (x *Subdomain) GetInTraffic() bool {
+	if x != nil {
+		return x.InTraffic
+	}
+	return false
+}
+
+func (x *Subdomain) GetInCt() bool {
+	if x != nil {
+		return x.InCt
+	}
+	return false
+}
+
+var File_asip_proto protoreflect.FileDescriptor
+
+const file_asip_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"asip.proto\x12\aasip.v2\"\xb2\n" +
+	"\n" +
+	"\x04Site\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12!\n" +
+	"\fmain_country\x18\x04 \x01(\tR\vmainCountry\x12\x1f\n" +
+	"\vglobal_rank\x18\x05 \x01(\x04R\n" +
+	"globalRank\x12\x1d\n" +
+	"\n" +
+	"local_rank\x18\x06 \x01(\x04R\tlocalRank\x12#\n" +
+	"\rlinking_total\x18\a \x01(\x04R\flinkingTotal\x12,\n" +
+	"\bvisitors\x18\b \x03(\v2\x10.asip.v2.VisitorR\bvisitors\x12,\n" +
+	"\bkeywords\x18\t \x03(\v2\x10.asip.v2.KeywordR\bkeywords\x12/\n" +
+	"\tupstreams\x18\n" +
+	" \x03(\v2\x11.asip.v2.UpstreamR\tupstreams\x12\x18\n" +
+	"\arelated\x18\v \x03(\tR\arelated\x122\n" +
+	"\n" +
+	"subdomains\x18\f \x03(\v2\x12.asip.v2.SubdomainR\n" +
+	"subdomains\x12\x1e\n" +
+	"\n" +
+	"categories\x18\r \x03(\tR\n" +
+	"categories\x12,\n" +
+	"\n" +
+	"links_from\x18\x0e \x03(\v2\r.asip.v2.LinkR\tlinksFrom\x12*\n" +
+	"\x11main_country_code\x18\x0f \x01(\tR\x0fmainCountryCode\x12<\n" +
+	"\x0ecategory_paths\x18\x10 \x03(\v2\x15.asip.v2.CategoryPathR\rcategoryPaths\x12#\n" +
+	"\rcategory_urls\x18\x11 \x03(\tR\fcategoryUrls\x121\n" +
+	"\x06custom\x18\x12 \x03(\v2\x19.asip.v2.Site.CustomEntryR\x06custom\x12\x16\n" +
+	"\x06layout\x18\x13 \x01(\tR\x06layout\x12!\n" +
+	"\frank_summary\x18\x14 \x01(\tR\vrankSummary\x12'\n" +
+	"\x0frank_percentile\x18\x15 \x01(\x01R\x0erankPercentile\x12'\n" +
+	"\x06trends\x18\x16 \x01(\v2\x0f.asip.v2.TrendsR\x06trends\x12B\n" +
+	"\x15pageviews_per_visitor\x18\x17 \x01(\v2\x0e.asip.v2.TrendR\x13pageviewsPerVisitor\x12/\n" +
+	"\vbounce_rate\x18\x18 \x01(\v2\x0e.asip.v2.TrendR\n" +
+	"bounceRate\x12@\n" +
+	"\x0ftraffic_sources\x18\x19 \x01(\v2\x17.asip.v2.TrafficSourcesR\x0etrafficSources\x12>\n" +
+	"\x0flinking_history\x18\x1a \x03(\v2\x15.asip.v2.LinkingCountR\x0elinkingHistory\x12!\n" +
+	"\x04meta\x18\x1b \x01(\v2\r.asip.v2.MetaR\x04meta\x12)\n" +
+	"\x10batch_percentile\x18\x1c \x01(\x01R\x0fbatchPercentile\x12%\n" +
+	"\x03dns\x18\x1d \x03(\v2\x13.asip.v2.DNSRecordsR\x03dns\x123\n" +
+	"\n" +
+	"enrichment\x18\x1e \x01(\v2\x13.asip.v2.EnrichmentR\n" +
+	"enrichment\x1a9\n" +
+	"\vCustomEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc4\x03\n" +
+	"\x04Meta\x12\x1d\n" +
+	"\n" +
+	"fetched_at\x18\x01 \x01(\x03R\tfetchedAt\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x02 \x01(\tR\tsourceUrl\x12\x1b\n" +
+	"\tfinal_url\x18\x03 \x01(\tR\bfinalUrl\x12\x1c\n" +
+	"\tredirects\x18\x04 \x03(\tR\tredirects\x12)\n" +
+	"\x10canonical_domain\x18\x05 \x01(\tR\x0fcanonicalDomain\x12\x1f\n" +
+	"\vhttp_status\x18\x06 \x01(\x05R\n" +
+	"httpStatus\x12\x1a\n" +
+	"\bduration\x18\a \x01(\x03R\bduration\x12\x18\n" +
+	"\aretries\x18\b \x01(\x05R\aretries\x12\x14\n" +
+	"\x05bytes\x18\t \x01(\x03R\x05bytes\x12\x1d\n" +
+	"\n" +
+	"from_cache\x18\n" +
+	" \x01(\bR\tfromCache\x12\x1a\n" +
+	"\bprovider\x18\v \x01(\tR\bprovider\x124\n" +
+	"\aheaders\x18\f \x03(\v2\x1a.asip.v2.Meta.HeadersEntryR\aheaders\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"b\n" +
+	"\n" +
+	"DNSRecords\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\f\n" +
+	"\x01a\x18\x02 \x03(\tR\x01a\x12\x12\n" +
+	"\x04aaaa\x18\x03 \x03(\tR\x04aaaa\x12\x0e\n" +
+	"\x02ns\x18\x04 \x03(\tR\x02ns\x12\x0e\n" +
+	"\x02mx\x18\x05 \x03(\tR\x02mx\"\xd5\x01\n" +
+	"\n" +
+	"Enrichment\x12@\n" +
+	"\x0fsecurity_trails\x18\x01 \x01(\v2\x17.asip.v2.SecurityTrailsR\x0esecurityTrails\x12*\n" +
+	"\aurlscan\x18\x02 \x01(\v2\x10.asip.v2.URLScanR\aurlscan\x123\n" +
+	"\n" +
+	"virustotal\x18\x03 \x01(\v2\x13.asip.v2.VirusTotalR\n" +
+	"virustotal\x12$\n" +
+	"\x05geoip\x18\x04 \x01(\v2\x0e.asip.v2.GeoIPR\x05geoip\"n\n" +
+	"\x0eSecurityTrails\x12\x1e\n" +
+	"\n" +
+	"subdomains\x18\x01 \x03(\tR\n" +
+	"subdomains\x12<\n" +
+	"\fhistorical_a\x18\x02 \x03(\v2\x19.asip.v2.HistoricalRecordR\vhistoricalA\"\x8c\x01\n" +
+	"\x10HistoricalRecord\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\x12$\n" +
+	"\rorganizations\x18\x02 \x03(\tR\rorganizations\x12\x1d\n" +
+	"\n" +
+	"first_seen\x18\x03 \x01(\tR\tfirstSeen\x12\x1b\n" +
+	"\tlast_seen\x18\x04 \x01(\tR\blastSeen\"\xca\x01\n" +
+	"\aURLScan\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1d\n" +
+	"\n" +
+	"result_url\x18\x02 \x01(\tR\tresultUrl\x12\x1e\n" +
+	"\n" +
+	"screenshot\x18\x03 \x01(\tR\n" +
+	"screenshot\x12\x18\n" +
+	"\apending\x18\x04 \x01(\bR\apending\x12\x1c\n" +
+	"\tmalicious\x18\x05 \x01(\bR\tmalicious\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x03R\x05score\x12\x1e\n" +
+	"\n" +
+	"categories\x18\a \x03(\tR\n" +
+	"categories\"\xc3\x03\n" +
+	"\n" +
+	"VirusTotal\x12\x1c\n" +
+	"\tmalicious\x18\x01 \x01(\x03R\tmalicious\x12\x1e\n" +
+	"\n" +
+	"suspicious\x18\x02 \x01(\x03R\n" +
+	"suspicious\x12\x1a\n" +
+	"\bharmless\x18\x03 \x01(\x03R\bharmless\x12\x1e\n" +
+	"\n" +
+	"undetected\x18\x04 \x01(\x03R\n" +
+	"undetected\x12\x1e\n" +
+	"\n" +
+	"reputation\x18\x05 \x01(\x03R\n" +
+	"reputation\x12C\n" +
+	"\n" +
+	"categories\x18\x06 \x03(\v2#.asip.v2.VirusTotal.CategoriesEntryR\n" +
+	"categories\x12S\n" +
+	"\x10popularity_ranks\x18\a \x03(\v2(.asip.v2.VirusTotal.PopularityRanksEntryR\x0fpopularityRanks\x1a=\n" +
+	"\x0fCategoriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aB\n" +
+	"\x14PopularityRanksEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x04R\x05value:\x028\x01\"M\n" +
+	"\x05GeoIP\x12(\n" +
+	"\x05hosts\x18\x01 \x03(\v2\x12.asip.v2.GeoIPHostR\x05hosts\x12\x1a\n" +
+	"\bmismatch\x18\x02 \x01(\bR\bmismatch\">\n" +
+	"\tGeoIPHost\x12\x0e\n" +
+	"\x02ip\x18\x01 \x01(\tR\x02ip\x12!\n" +
+	"\fcountry_code\x18\x02 \x01(\tR\vcountryCode\"8\n" +
+	"\fLinkingCount\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\x03R\x04date\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x04R\x05total\"8\n" +
+	"\x0eTrafficSources\x12&\n" +
+	"\x06search\x18\x01 \x01(\v2\x0e.asip.v2.TrendR\x06search\"K\n" +
+	"\x05Trend\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x01R\x05value\x12\x16\n" +
+	"\x06change\x18\x02 \x01(\x01R\x06change\x12\x14\n" +
+	"\x05arrow\x18\x03 \x01(\tR\x05arrow\"\xaf\x01\n" +
+	"\x06Trends\x12\x1f\n" +
+	"\vglobal_rank\x18\x01 \x01(\x01R\n" +
+	"globalRank\x12\x1f\n" +
+	"\vbounce_rate\x18\x02 \x01(\x01R\n" +
+	"bounceRate\x12\x1c\n" +
+	"\tpageviews\x18\x03 \x01(\x01R\tpageviews\x12 \n" +
+	"\ftime_on_site\x18\x04 \x01(\x01R\n" +
+	"timeOnSite\x12#\n" +
+	"\rlinking_total\x18\x05 \x01(\x01R\flinkingTotal\"$\n" +
+	"\fCategoryPath\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\"1\n" +
+	"\aPercent\x12\x10\n" +
+	"\x03raw\x18\x01 \x01(\tR\x03raw\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\"j\n" +
+	"\x04Link\x12\x12\n" +
+	"\x04site\x18\x01 \x01(\tR\x04site\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\tR\x04page\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x10\n" +
+	"\x03rel\x18\x04 \x03(\tR\x03rel\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\"\x91\x01\n" +
+	"\aVisitor\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12*\n" +
+	"\apercent\x18\x02 \x01(\v2\x10.asip.v2.PercentR\apercent\x12\x1d\n" +
+	"\n" +
+	"local_rank\x18\x03 \x01(\x04R\tlocalRank\x12!\n" +
+	"\fcountry_code\x18\x04 \x01(\tR\vcountryCode\"I\n" +
+	"\aKeyword\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12*\n" +
+	"\apercent\x18\x02 \x01(\v2\x10.asip.v2.PercentR\apercent\"p\n" +
+	"\bUpstream\x12\x12\n" +
+	"\x04site\x18\x01 \x01(\tR\x04site\x12*\n" +
+	"\apercent\x18\x02 \x01(\v2\x10.asip.v2.PercentR\apercent\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x12\n" +
+	"\x04rank\x18\x04 \x01(\x04R\x04rank\"\x83\x01\n" +
+	"\tSubdomain\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12*\n" +
+	"\apercent\x18\x02 \x01(\v2\x10.asip.v2.PercentR\apercent\x12\x1d\n" +
+	"\n" +
+	"in_traffic\x18\x03 \x01(\bR\tinTraffic\x12\x13\n" +
+	"\x05in_ct\x18\x04 \x01(\bR\x04inCtB1Z/github.com/ilyaglow/alexa-siteinfo-parser/v2/pbb\x06proto3"
+
+var (
+	file_asip_proto_rawDescOnce sync.Once
+	file_asip_proto_rawDescData []byte
+)
+
+func file_asip_proto_rawDescGZIP() []byte {
+	file_asip_proto_rawDescOnce.Do(func() {
+		file_asip_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_asip_proto_rawDesc), len(file_asip_proto_rawDesc)))
+	})
+	return file_asip_proto_rawDescData
+}
+
+var file_asip_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_asip_proto_goTypes = []any{
+	(*Site)(nil),             // 0: asip.v2.Site
+	(*Meta)(nil),             // 1: asip.v2.Meta
+	(*DNSRecords)(nil),       // 2: asip.v2.DNSRecords
+	(*Enrichment)(nil),       // 3: asip.v2.Enrichment
+	(*SecurityTrails)(nil),   // 4: asip.v2.SecurityTrails
+	(*HistoricalRecord)(nil), // 5: asip.v2.HistoricalRecord
+	(*URLScan)(nil),          // 6: asip.v2.URLScan
+	(*VirusTotal)(nil),       // 7: asip.v2.VirusTotal
+	(*GeoIP)(nil),            // 8: asip.v2.GeoIP
+	(*GeoIPHost)(nil),        // 9: asip.v2.GeoIPHost
+	(*LinkingCount)(nil),     // 10: asip.v2.LinkingCount
+	(*TrafficSources)(nil),   // 11: asip.v2.TrafficSources
+	(*Trend)(nil),            // 12: asip.v2.Trend
+	(*Trends)(nil),           // 13: asip.v2.Trends
+	(*CategoryPath)(nil),     // 14: asip.v2.CategoryPath
+	(*Percent)(nil),          // 15: asip.v2.Percent
+	(*Link)(nil),             // 16: asip.v2.Link
+	(*Visitor)(nil),          // 17: asip.v2.Visitor
+	(*Keyword)(nil),          // 18: asip.v2.Keyword
+	(*Upstream)(nil),         // 19: asip.v2.Upstream
+	(*Subdomain)(nil),        // 20: asip.v2.Subdomain
+	nil,                      // 21: asip.v2.Site.CustomEntry
+	nil,                      // 22: asip.v2.Meta.HeadersEntry
+	nil,                      // 23: asip.v2.VirusTotal.CategoriesEntry
+	nil,                      // 24: asip.v2.VirusTotal.PopularityRanksEntry
+}
+var file_asip_proto_depIdxs = []int32{
+	17, // 0: asip.v2.Site.visitors:type_name -> asip.v2.Visitor
+	18, // 1: asip.v2.Site.keywords:type_name -> asip.v2.Keyword
+	19, // 2: asip.v2.Site.upstreams:type_name -> asip.v2.Upstream
+	20, // 3: asip.v2.Site.subdomains:type_name -> asip.v2.Subdomain
+	16, // 4: asip.v2.Site.links_from:type_name -> asip.v2.Link
+	14, // 5: asip.v2.Site.category_paths:type_name -> asip.v2.CategoryPath
+	21, // 6: asip.v2.Site.custom:type_name -> asip.v2.Site.CustomEntry
+	13, // 7: asip.v2.Site.trends:type_name -> asip.v2.Trends
+	12, // 8: asip.v2.Site.pageviews_per_visitor:type_name -> asip.v2.Trend
+	12, // 9: asip.v2.Site.bounce_rate:type_name -> asip.v2.Trend
+	11, // 10: asip.v2.Site.traffic_sources:type_name -> asip.v2.TrafficSources
+	10, // 11: asip.v2.Site.linking_history:type_name -> asip.v2.LinkingCount
+	1,  // 12: asip.v2.Site.meta:type_name -> asip.v2.Meta
+	2,  // 13: asip.v2.Site.dns:type_name -> asip.v2.DNSRecords
+	3,  // 14: asip.v2.Site.enrichment:type_name -> asip.v2.Enrichment
+	22, // 15: asip.v2.Meta.headers:type_name -> asip.v2.Meta.HeadersEntry
+	4,  // 16: asip.v2.Enrichment.security_trails:type_name -> asip.v2.SecurityTrails
+	6,  // 17: asip.v2.Enrichment.urlscan:type_name -> asip.v2.URLScan
+	7,  // 18: asip.v2.Enrichment.virustotal:type_name -> asip.v2.VirusTotal
+	8,  // 19: asip.v2.Enrichment.geoip:type_name -> asip.v2.GeoIP
+	5,  // 20: asip.v2.SecurityTrails.historical_a:type_name -> asip.v2.HistoricalRecord
+	23, // 21: asip.v2.VirusTotal.categories:type_name -> asip.v2.VirusTotal.CategoriesEntry
+	24, // 22: asip.v2.VirusTotal.popularity_ranks:type_name -> asip.v2.VirusTotal.PopularityRanksEntry
+	9,  // 23: asip.v2.GeoIP.hosts:type_name -> asip.v2.GeoIPHost
+	12, // 24: asip.v2.TrafficSources.search:type_name -> asip.v2.Trend
+	15, // 25: asip.v2.Visitor.percent:type_name -> asip.v2.Percent
+	15, // 26: asip.v2.Keyword.percent:type_name -> asip.v2.Percent
+	15, // 27: asip.v2.Upstream.percent:type_name -> asip.v2.Percent
+	15, // 28: asip.v2.Subdomain.percent:type_name -> asip.v2.Percent
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_asip_proto_init() }
+func file_asip_proto_init() {
+	if File_asip_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_asip_proto_rawDesc), len(file_asip_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_asip_proto_goTypes,
+		DependencyIndexes: file_asip_proto_depIdxs,
+		MessageInfos:      file_asip_proto_msgTypes,
+	}.Build()
+	File_asip_proto = out.File
+	file_asip_proto_goTypes = nil
+	file_asip_proto_depIdxs = nil
+}
@@ -0,0 +1,361 @@
+// Package pb holds Protocol Buffers bindings for Site along with converters
+// from and to the parse package types.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative asip.proto
+
+import (
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+// FromSite converts a parsed Site into its message.
+func FromSite(s *parse.Site) *Site {
+	if s == nil {
+		return nil
+	}
+
+	m := &Site{
+		Domain:          s.Domain,
+		Title:           s.Title,
+		Description:     s.Description,
+		MainCountry:     s.MainCountry,
+		MainCountryCode: s.MainCountryCode,
+		GlobalRank:      uint64(s.GlobalRank),
+		LocalRank:       uint64(s.LocalRank),
+		RankSummary:     s.RankSummary,
+		RankPercentile:  s.RankPercentile,
+		LinkingTotal:    uint64(s.LinkingTotal),
+		Related:         s.Related,
+		Categories:      s.Categories,
+		CategoryUrls:    s.CategoryURLs,
+		Custom:          s.Custom,
+		Layout:          s.Meta.Layout,
+		Trends: &Trends{
+			GlobalRank:   s.Trends.GlobalRank,
+			LinkingTotal: s.Trends.LinkingTotal,
+			BounceRate:   s.Trends.BounceRate,
+			Pageviews:    s.Trends.Pageviews,
+			TimeOnSite:   s.Trends.TimeOnSite,
+		},
+		PageviewsPerVisitor: fromTrend(s.PageviewsPerVisitor),
+		BounceRate:          fromTrend(s.BounceRate),
+		TrafficSources:      &TrafficSources{Search: fromTrend(s.TrafficSources.Search)},
+		Meta:                fromMeta(s.Meta),
+		BatchPercentile:     s.BatchPercentile,
+		Enrichment:          fromEnrichment(s.Enrichment),
+	}
+	for _, r := range s.DNS {
+		m.Dns = append(m.Dns, &DNSRecords{Host: r.Host, A: r.A, Aaaa: r.AAAA, Ns: r.NS, Mx: r.MX})
+	}
+	for _, v := range s.Visitors {
+		m.Visitors = append(m.Visitors, &Visitor{
+			Country:     v.Country,
+			CountryCode: v.CountryCode,
+			Percent:     fromPercent(v.Percent),
+			LocalRank:   uint64(v.LocalRank),
+		})
+	}
+	for _, k := range s.Keywords {
+		m.Keywords = append(m.Keywords, &Keyword{
+			Word:    k.Word,
+			Percent: fromPercent(k.Percent),
+		})
+	}
+	for _, u := range s.Upstreams {
+		m.Upstreams = append(m.Upstreams, &Upstream{
+			Site:    u.Site,
+			Url:     u.URL,
+			Rank:    uint64(u.Rank),
+			Percent: fromPercent(u.Percent),
+		})
+	}
+	for _, sd := range s.Subdomains {
+		m.Subdomains = append(m.Subdomains, &Subdomain{
+			Domain:    sd.Domain,
+			Percent:   fromPercent(sd.Percent),
+			InTraffic: sd.InTraffic,
+			InCt:      sd.InCT,
+		})
+	}
+	for _, p := range s.CategoryPaths {
+		m.CategoryPaths = append(m.CategoryPaths, &CategoryPath{Names: p})
+	}
+	for _, c := range s.LinkingHistory {
+		m.LinkingHistory = append(m.LinkingHistory, &LinkingCount{
+			Date:  c.Date.Unix(),
+			Total: uint64(c.Total),
+		})
+	}
+	for _, l := range s.LinksFrom {
+		m.LinksFrom = append(m.LinksFrom, &Link{
+			Site:  l.Site,
+			Page:  l.Page,
+			Text:  l.Text,
+			Rel:   l.Rel,
+			Title: l.Title,
+		})
+	}
+
+	return m
+}
+
+// ToSite converts a message back into a Site.
+func ToSite(m *Site) *parse.Site {
+	if m == nil {
+		return nil
+	}
+
+	s := &parse.Site{
+		Domain:          m.GetDomain(),
+		Title:           m.GetTitle(),
+		Description:     m.GetDescription(),
+		MainCountry:     m.GetMainCountry(),
+		MainCountryCode: m.GetMainCountryCode(),
+		GlobalRank:      uint(m.GetGlobalRank()),
+		LocalRank:       uint(m.GetLocalRank()),
+		RankSummary:     m.GetRankSummary(),
+		RankPercentile:  m.GetRankPercentile(),
+		LinkingTotal:    uint(m.GetLinkingTotal()),
+		Related:         m.GetRelated(),
+		Categories:      m.GetCategories(),
+		CategoryURLs:    m.GetCategoryUrls(),
+		Custom:          m.GetCustom(),
+		Meta:            toMeta(m.GetMeta(), m.GetLayout()),
+		BatchPercentile: m.GetBatchPercentile(),
+		Enrichment:      toEnrichment(m.GetEnrichment()),
+		Trends: parse.Trends{
+			GlobalRank:   m.GetTrends().GetGlobalRank(),
+			LinkingTotal: m.GetTrends().GetLinkingTotal(),
+			BounceRate:   m.GetTrends().GetBounceRate(),
+			Pageviews:    m.GetTrends().GetPageviews(),
+			TimeOnSite:   m.GetTrends().GetTimeOnSite(),
+		},
+		PageviewsPerVisitor: toTrend(m.GetPageviewsPerVisitor()),
+		BounceRate:          toTrend(m.GetBounceRate()),
+		TrafficSources:      parse.TrafficSources{Search: toTrend(m.GetTrafficSources().GetSearch())},
+	}
+	for _, r := range m.GetDns() {
+		s.DNS = append(s.DNS, parse.DNSRecords{Host: r.GetHost(), A: r.GetA(), AAAA: r.GetAaaa(), NS: r.GetNs(), MX: r.GetMx()})
+	}
+	for _, v := range m.GetVisitors() {
+		s.Visitors = append(s.Visitors, parse.Visitor{
+			Country:     v.GetCountry(),
+			CountryCode: v.GetCountryCode(),
+			Percent:     toPercent(v.GetPercent()),
+			LocalRank:   uint(v.GetLocalRank()),
+		})
+	}
+	for _, k := range m.GetKeywords() {
+		s.Keywords = append(s.Keywords, parse.Keyword{
+			Word:    k.GetWord(),
+			Percent: toPercent(k.GetPercent()),
+		})
+	}
+	for _, u := range m.GetUpstreams() {
+		s.Upstreams = append(s.Upstreams, parse.Upstream{
+			Site:    u.GetSite(),
+			URL:     u.GetUrl(),
+			Rank:    uint(u.GetRank()),
+			Percent: toPercent(u.GetPercent()),
+		})
+	}
+	for _, sd := range m.GetSubdomains() {
+		s.Subdomains = append(s.Subdomains, parse.Subdomain{
+			Domain:    sd.GetDomain(),
+			Percent:   toPercent(sd.GetPercent()),
+			InTraffic: sd.GetInTraffic(),
+			InCT:      sd.GetInCt(),
+		})
+	}
+	for _, p := range m.GetCategoryPaths() {
+		s.CategoryPaths = append(s.CategoryPaths, p.GetNames())
+	}
+	for _, c := range m.GetLinkingHistory() {
+		s.LinkingHistory = append(s.LinkingHistory, parse.LinkingCount{
+			Date:  time.Unix(c.GetDate(), 0).UTC(),
+			Total: uint(c.GetTotal()),
+		})
+	}
+	for _, l := range m.GetLinksFrom() {
+		s.LinksFrom = append(s.LinksFrom, parse.Link{
+			Site:  l.GetSite(),
+			Page:  l.GetPage(),
+			Text:  l.GetText(),
+			Rel:   l.GetRel(),
+			Title: l.GetTitle(),
+		})
+	}
+
+	return s
+}
+
+func fromPercent(p parse.Percent) *Percent {
+	return &Percent{
+		Raw:   p.Raw,
+		Value: p.Value,
+	}
+}
+
+func toPercent(m *Percent) parse.Percent {
+	return parse.Percent{
+		Raw:   m.GetRaw(),
+		Value: m.GetValue(),
+	}
+}
+
+func fromTrend(t parse.Trend) *Trend {
+	return &Trend{
+		Value:  t.Value,
+		Change: t.Change,
+		Arrow:  t.Arrow,
+	}
+}
+
+func toTrend(m *Trend) parse.Trend {
+	return parse.Trend{
+		Value:  m.GetValue(),
+		Change: m.GetChange(),
+		Arrow:  m.GetArrow(),
+	}
+}
+
+func fromMeta(meta parse.Meta) *Meta {
+	m := &Meta{
+		SourceUrl:       meta.SourceURL,
+		FinalUrl:        meta.FinalURL,
+		Redirects:       meta.Redirects,
+		CanonicalDomain: meta.CanonicalDomain,
+		HttpStatus:      int32(meta.HTTPStatus),
+		Duration:        int64(meta.Duration),
+		Retries:         int32(meta.Retries),
+		Bytes:           meta.Bytes,
+		FromCache:       meta.FromCache,
+		Provider:        meta.Provider,
+		Headers:         meta.Headers,
+	}
+	if !meta.FetchedAt.IsZero() {
+		m.FetchedAt = meta.FetchedAt.UnixNano()
+	}
+	return m
+}
+
+func toMeta(m *Meta, layout string) parse.Meta {
+	meta := parse.Meta{
+		SourceURL:       m.GetSourceUrl(),
+		FinalURL:        m.GetFinalUrl(),
+		Redirects:       m.GetRedirects(),
+		CanonicalDomain: m.GetCanonicalDomain(),
+		HTTPStatus:      int(m.GetHttpStatus()),
+		Duration:        time.Duration(m.GetDuration()),
+		Retries:         int(m.GetRetries()),
+		Bytes:           m.GetBytes(),
+		FromCache:       m.GetFromCache(),
+		Provider:        m.GetProvider(),
+		Headers:         m.GetHeaders(),
+		Layout:          layout,
+	}
+	if m.GetFetchedAt() != 0 {
+		meta.FetchedAt = time.Unix(0, m.GetFetchedAt()).UTC()
+	}
+	return meta
+}
+
+func fromEnrichment(e parse.Enrichment) *Enrichment {
+	m := &Enrichment{}
+	if st := e.SecurityTrails; st != nil {
+		m.SecurityTrails = &SecurityTrails{Subdomains: st.Subdomains}
+		for _, r := range st.HistoricalA {
+			m.SecurityTrails.HistoricalA = append(m.SecurityTrails.HistoricalA, &HistoricalRecord{
+				Values:        r.Values,
+				Organizations: r.Organizations,
+				FirstSeen:     r.FirstSeen,
+				LastSeen:      r.LastSeen,
+			})
+		}
+	}
+	if us := e.URLScan; us != nil {
+		m.Urlscan = &URLScan{
+			Uuid:       us.UUID,
+			ResultUrl:  us.ResultURL,
+			Screenshot: us.Screenshot,
+			Pending:    us.Pending,
+			Malicious:  us.Malicious,
+			Score:      int64(us.Score),
+			Categories: us.Categories,
+		}
+	}
+	if vt := e.VirusTotal; vt != nil {
+		m.Virustotal = &VirusTotal{
+			Malicious:  int64(vt.Malicious),
+			Suspicious: int64(vt.Suspicious),
+			Harmless:   int64(vt.Harmless),
+			Undetected: int64(vt.Undetected),
+			Reputation: int64(vt.Reputation),
+			Categories: vt.Categories,
+		}
+		if vt.PopularityRanks != nil {
+			m.Virustotal.PopularityRanks = make(map[string]uint64, len(vt.PopularityRanks))
+			for k, v := range vt.PopularityRanks {
+				m.Virustotal.PopularityRanks[k] = uint64(v)
+			}
+		}
+	}
+	if g := e.GeoIP; g != nil {
+		m.Geoip = &GeoIP{Mismatch: g.Mismatch}
+		for _, h := range g.Hosts {
+			m.Geoip.Hosts = append(m.Geoip.Hosts, &GeoIPHost{Ip: h.IP, CountryCode: h.CountryCode})
+		}
+	}
+	return m
+}
+
+func toEnrichment(m *Enrichment) parse.Enrichment {
+	var e parse.Enrichment
+	if st := m.GetSecurityTrails(); st != nil {
+		e.SecurityTrails = &parse.SecurityTrails{Subdomains: st.GetSubdomains()}
+		for _, r := range st.GetHistoricalA() {
+			e.SecurityTrails.HistoricalA = append(e.SecurityTrails.HistoricalA, parse.HistoricalRecord{
+				Values:        r.GetValues(),
+				Organizations: r.GetOrganizations(),
+				FirstSeen:     r.GetFirstSeen(),
+				LastSeen:      r.GetLastSeen(),
+			})
+		}
+	}
+	if us := m.GetUrlscan(); us != nil {
+		e.URLScan = &parse.URLScan{
+			UUID:       us.GetUuid(),
+			ResultURL:  us.GetResultUrl(),
+			Screenshot: us.GetScreenshot(),
+			Pending:    us.GetPending(),
+			Malicious:  us.GetMalicious(),
+			Score:      int(us.GetScore()),
+			Categories: us.GetCategories(),
+		}
+	}
+	if vt := m.GetVirustotal(); vt != nil {
+		e.VirusTotal = &parse.VirusTotal{
+			Malicious:  int(vt.GetMalicious()),
+			Suspicious: int(vt.GetSuspicious()),
+			Harmless:   int(vt.GetHarmless()),
+			Undetected: int(vt.GetUndetected()),
+			Reputation: int(vt.GetReputation()),
+			Categories: vt.GetCategories(),
+		}
+		if ranks := vt.GetPopularityRanks(); ranks != nil {
+			e.VirusTotal.PopularityRanks = make(map[string]uint, len(ranks))
+			for k, v := range ranks {
+				e.VirusTotal.PopularityRanks[k] = uint(v)
+			}
+		}
+	}
+	if g := m.GetGeoip(); g != nil {
+		e.GeoIP = &parse.GeoIP{Mismatch: g.GetMismatch()}
+		for _, h := range g.GetHosts() {
+			e.GeoIP.Hosts = append(e.GeoIP.Hosts, parse.GeoIPHost{IP: h.GetIp(), CountryCode: h.GetCountryCode()})
+		}
+	}
+	return e
+}
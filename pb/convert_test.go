@@ -0,0 +1,89 @@
+package pb
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRoundTrip(t *testing.T) {
+	body, err := os.Open("../parse/testdata/body.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	s, err := parse.Parse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := proto.Marshal(FromSite(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m Site
+	if err := proto.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ToSite(&m); !reflect.DeepEqual(got, s) {
+		t.Fatalf("want %v, got %v", s, got)
+	}
+}
+
+func TestRoundTripMetaAndEnrichment(t *testing.T) {
+	s := &parse.Site{
+		Domain:          "example.org",
+		BatchPercentile: 87.5,
+		DNS: []parse.DNSRecords{
+			{Host: "example.org", A: []string{"93.184.216.34"}, AAAA: []string{"2606:2800::1"}, NS: []string{"a.iana-servers.net."}, MX: []string{"mx.example.org."}},
+		},
+		Enrichment: parse.Enrichment{
+			SecurityTrails: &parse.SecurityTrails{
+				Subdomains:  []string{"www"},
+				HistoricalA: []parse.HistoricalRecord{{Values: []string{"1.2.3.4"}, Organizations: []string{"Edgecast"}, FirstSeen: "2010-01-01", LastSeen: "2020-01-01"}},
+			},
+			URLScan: &parse.URLScan{UUID: "u", ResultURL: "https://urlscan.io/result/u/", Screenshot: "s.png", Malicious: true, Score: 70, Categories: []string{"phishing"}},
+			VirusTotal: &parse.VirusTotal{
+				Malicious: 1, Suspicious: 2, Harmless: 60, Undetected: 10, Reputation: -5,
+				Categories:      map[string]string{"Forcepoint": "reference"},
+				PopularityRanks: map[string]uint{"Alexa": 506},
+			},
+			GeoIP: &parse.GeoIP{Hosts: []parse.GeoIPHost{{IP: "93.184.216.34", CountryCode: "US"}}, Mismatch: true},
+		},
+		Meta: parse.Meta{
+			FetchedAt:       time.Date(2020, 3, 1, 12, 0, 0, 5, time.UTC),
+			SourceURL:       "https://www.alexa.com/siteinfo/example.org",
+			FinalURL:        "https://www.alexa.com/siteinfo/example.org/",
+			Redirects:       []string{"https://www.alexa.com/siteinfo/example.org"},
+			CanonicalDomain: "example.org",
+			HTTPStatus:      200,
+			Duration:        1500 * time.Millisecond,
+			Retries:         1,
+			Bytes:           12345,
+			FromCache:       true,
+			Provider:        "alexa",
+			Headers:         map[string]string{"Content-Type": "text/html"},
+			Layout:          parse.Layout2017,
+		},
+	}
+
+	b, err := proto.Marshal(FromSite(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m Site
+	if err := proto.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ToSite(&m); !reflect.DeepEqual(got, s) {
+		t.Fatalf("want %+v, got %+v", s, got)
+	}
+}
@@ -0,0 +1,55 @@
+package asip
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+// ErrorReporter is told about pages that fail to parse, including layout
+// changes, so breakage of long-running daemons gets noticed.
+type ErrorReporter interface {
+	Report(ctx context.Context, err error, ec ErrorContext)
+}
+
+// ErrorContext describes a page that failed to parse leaving out its body
+// and credentials.
+type ErrorContext struct {
+	Domain     string
+	URL        string
+	HTTPStatus int
+	Missing    []string // sections missing when the layout changed
+}
+
+// WithErrorReporter reports pages that fail to parse to r.
+func WithErrorReporter(r ErrorReporter) Option {
+	return func(conf *Conf) {
+		conf.page.report = r
+	}
+}
+
+// report tells r about a parse failure, a domain out of top 1M is not one.
+func report(ctx context.Context, r ErrorReporter, err error, domain, rawURL string, status int) {
+	if r == nil || errors.Is(err, parse.ErrNoEnoughData) {
+		return
+	}
+
+	ec := ErrorContext{Domain: domain, URL: sanitizeURL(rawURL), HTTPStatus: status}
+	var le *parse.LayoutError
+	if errors.As(err, &le) {
+		ec.Missing = le.Missing
+	}
+	r.Report(ctx, err, ec)
+}
+
+// sanitizeURL strips credentials, e.g. of a mirror.
+func sanitizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	return u.String()
+}
@@ -0,0 +1,119 @@
+// Package report sends parse failures to error tracking services.
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+// Sentry reports errors as events of a Sentry project.
+type Sentry struct {
+	client *http.Client
+	store  string
+	key    string
+}
+
+// NewSentry bootstraps a Sentry reporter from a DSN like
+// https://KEY@o0.ingest.sentry.io/PROJECT, c may be nil to use the default
+// HTTP client.
+func NewSentry(dsn string, c *http.Client) (*Sentry, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, errors.New("sentry: no key in dsn")
+	}
+	dir, project := path.Split(strings.TrimSuffix(u.Path, "/"))
+	if project == "" {
+		return nil, errors.New("sentry: no project in dsn")
+	}
+
+	if c == nil {
+		c = http.DefaultClient
+	}
+	store := url.URL{Scheme: u.Scheme, Host: u.Host, Path: path.Join(dir, "api", project, "store") + "/"}
+	return &Sentry{c, store.String(), u.User.Username()}, nil
+}
+
+type sentryEvent struct {
+	EventID   string            `json:"event_id"`
+	Timestamp string            `json:"timestamp"`
+	Level     string            `json:"level"`
+	Logger    string            `json:"logger"`
+	Platform  string            `json:"platform"`
+	Message   string            `json:"message"`
+	Exception sentryException   `json:"exception"`
+	Tags      map[string]string `json:"tags"`
+	Extra     map[string]any    `json:"extra"`
+}
+
+type sentryException struct {
+	Values []sentryValue `json:"values"`
+}
+
+type sentryValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Report sends err as an event, failing silently as reporting must not
+// break lookups.
+func (s *Sentry) Report(ctx context.Context, err error, ec asip.ErrorContext) {
+	s.Send(ctx, err, ec)
+}
+
+// Send sends reported as an event tagged with the domain.
+func (s *Sentry) Send(ctx context.Context, reported error, ec asip.ErrorContext) error {
+	id := make([]byte, 16)
+	rand.Read(id)
+
+	extra := map[string]any{"url": ec.URL, "http_status": ec.HTTPStatus}
+	if ec.Missing != nil {
+		extra["missing"] = ec.Missing
+	}
+	b, err := json.Marshal(sentryEvent{
+		EventID:   hex.EncodeToString(id),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Level:     "error",
+		Logger:    "asip",
+		Platform:  "go",
+		Message:   reported.Error(),
+		Exception: sentryException{[]sentryValue{{fmt.Sprintf("%T", reported), reported.Error()}}},
+		Tags:      map[string]string{"domain": ec.Domain},
+		Extra:     extra,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.store, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", "Sentry sentry_version=7, sentry_client=asip/2, sentry_key="+s.key)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("sentry status code: %d", resp.StatusCode)
+	}
+	return nil
+}
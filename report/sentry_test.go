@@ -0,0 +1,58 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestSentry(t *testing.T) {
+	var event sentryEvent
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/42/store/" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if !strings.Contains(r.Header.Get("X-Sentry-Auth"), "sentry_key=public") {
+			t.Errorf("unexpected auth %s", r.Header.Get("X-Sentry-Auth"))
+		}
+		json.NewDecoder(r.Body).Decode(&event)
+	}))
+	defer ts.Close()
+
+	s, err := NewSentry(strings.Replace(ts.URL, "//", "//public@", 1)+"/42", ts.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Send(context.Background(), &parse.LayoutError{Missing: []string{"global rank"}}, asip.ErrorContext{
+		Domain:     "example.org",
+		URL:        "https://www.alexa.com/siteinfo/example.org",
+		HTTPStatus: http.StatusOK,
+		Missing:    []string{"global rank"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if event.Tags["domain"] != "example.org" || event.Exception.Values[0].Type != "*parse.LayoutError" {
+		t.Fatalf("unexpected event %+v", event)
+	}
+	if !reflect.DeepEqual(event.Extra["missing"], []any{"global rank"}) {
+		t.Fatalf("want missing sections, got %v", event.Extra)
+	}
+}
+
+func TestNewSentryInvalid(t *testing.T) {
+	for _, dsn := range []string{"https://sentry.io/42", "https://key@sentry.io/"} {
+		if _, err := NewSentry(dsn, nil); err == nil {
+			t.Fatalf("want error for %s, but got no error", dsn)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package asip
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Risk levels of a Verdict.
+const (
+	RiskLow    = "low"
+	RiskMedium = "medium"
+	RiskHigh   = "high"
+)
+
+// Factor is a signal that contributed to a Verdict.
+type Factor struct {
+	Name   string
+	Points int
+	Reason string
+}
+
+// Verdict is a phishing-risk score from 0 to 100 with its breakdown.
+type Verdict struct {
+	Score   int
+	Level   string
+	Factors []Factor
+}
+
+// Risk scores how likely the site is phishing: a very low rank, brand
+// keywords the domain itself does not carry and a young domain add up.
+// Enrichments are taken from e rather than the site so they can come from
+// elsewhere.
+func Risk(s *Site, e Enrichment, brands ...string) *Verdict {
+	return risk(s, e, brands, time.Now())
+}
+
+func risk(s *Site, e Enrichment, brands []string, now time.Time) *Verdict {
+	var v Verdict
+	add := func(name string, points int, format string, args ...interface{}) {
+		v.Factors = append(v.Factors, Factor{name, points, fmt.Sprintf(format, args...)})
+		v.Score += points
+	}
+
+	switch {
+	case s.GlobalRank == 0:
+		add("rank", 30, "not ranked")
+	case s.GlobalRank > 1000000:
+		add("rank", 25, "global rank %d is beyond top 1M", s.GlobalRank)
+	case s.GlobalRank > 100000:
+		add("rank", 15, "global rank %d is beyond top 100K", s.GlobalRank)
+	}
+
+	for _, b := range brands {
+		b = strings.ToLower(b)
+		if strings.Contains(strings.ToLower(s.Domain), b) {
+			continue
+		}
+		for _, k := range s.Keywords {
+			if strings.Contains(strings.ToLower(k.Word), b) {
+				add("brand_keyword", 30, "keyword %q refers to brand %q foreign to the domain", k.Word, b)
+				break
+			}
+		}
+	}
+
+	if first, ok := firstSeen(e); ok {
+		age := now.Sub(first)
+		switch {
+		case age < 30*24*time.Hour:
+			add("age", 30, "first seen %s, less than 30 days ago", first.Format("2006-01-02"))
+		case age < 180*24*time.Hour:
+			add("age", 15, "first seen %s, less than 180 days ago", first.Format("2006-01-02"))
+		}
+	}
+
+	if vt := e.VirusTotal; vt != nil && vt.Malicious > 0 {
+		add("virustotal", 40, "%d engines flag the domain as malicious", vt.Malicious)
+	}
+	if us := e.URLScan; us != nil && us.Malicious {
+		add("urlscan", 40, "urlscan.io verdict is malicious")
+	}
+
+	if v.Score > 100 {
+		v.Score = 100
+	}
+	switch {
+	case v.Score >= 60:
+		v.Level = RiskHigh
+	case v.Score >= 30:
+		v.Level = RiskMedium
+	default:
+		v.Level = RiskLow
+	}
+
+	return &v
+}
+
+// firstSeen returns the earliest time the domain had DNS records.
+func firstSeen(e Enrichment) (time.Time, bool) {
+	if e.SecurityTrails == nil {
+		return time.Time{}, false
+	}
+
+	var first time.Time
+	for _, r := range e.SecurityTrails.HistoricalA {
+		t, err := time.Parse("2006-01-02", r.FirstSeen)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+	}
+	return first, !first.IsZero()
+}
@@ -0,0 +1,43 @@
+package asip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+func TestRisk(t *testing.T) {
+	now := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	s := &Site{
+		Domain:     "secure-login.example",
+		GlobalRank: 2000000,
+		Keywords:   []Keyword{{Word: "sberbank online"}},
+	}
+	e := Enrichment{
+		SecurityTrails: &parse.SecurityTrails{
+			HistoricalA: []parse.HistoricalRecord{{FirstSeen: "2020-02-20"}},
+		},
+	}
+
+	v := risk(s, e, []string{"Sberbank"}, now)
+	if v.Score != 85 || v.Level != RiskHigh {
+		t.Fatalf("want high risk of 85, got %s risk of %d: %v", v.Level, v.Score, v.Factors)
+	}
+	if len(v.Factors) != 3 {
+		t.Fatalf("want 3 factors, got %v", v.Factors)
+	}
+}
+
+func TestRiskLow(t *testing.T) {
+	s := &Site{
+		Domain:     "sberbank.ru",
+		GlobalRank: 506,
+		Keywords:   []Keyword{{Word: "sberbank online"}},
+	}
+
+	v := Risk(s, Enrichment{}, "sberbank")
+	if v.Score != 0 || v.Level != RiskLow || len(v.Factors) != 0 {
+		t.Fatalf("want no risk, got %v", v)
+	}
+}
@@ -0,0 +1,143 @@
+package asip
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// Priority orders lookups waiting for a scheduler of a Conf.
+type Priority int
+
+// Priorities from the lowest.
+const (
+	// PriorityBackground is the default of batches and monitors.
+	PriorityBackground Priority = iota
+	// PriorityInteractive is for someone waiting, e.g. an API request.
+	PriorityInteractive
+)
+
+type priorityKey struct{}
+
+// ContextWithPriority returns a copy of ctx whose lookups have priority p.
+func ContextWithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+func priorityOf(ctx context.Context) Priority {
+	p, _ := ctx.Value(priorityKey{}).(Priority)
+	return p
+}
+
+// WithScheduler lets up to n lookups fetch at once, the waiting ones go by
+// Priority and then in order of arrival, so interactive lookups are not
+// starved by a batch sharing the Conf. Rank and IsRanked take slots too.
+// n below 1 is taken as 1.
+func WithScheduler(n int) Option {
+	return func(conf *Conf) {
+		conf.scheduler = &scheduler{free: max(n, 1)}
+	}
+}
+
+// schedule waits for a slot of the scheduler of c if it has one, release
+// gives it back.
+func (c *Conf) schedule(ctx context.Context) (release func(), err error) {
+	if c.scheduler == nil {
+		return func() {}, nil
+	}
+	if err := c.scheduler.acquire(ctx); err != nil {
+		return nil, err
+	}
+	return c.scheduler.release, nil
+}
+
+// scheduler hands out slots to waiters by priority.
+type scheduler struct {
+	mu    sync.Mutex
+	free  int
+	seq   int
+	queue waiters
+}
+
+type waiter struct {
+	priority Priority
+	seq      int
+	ready    chan struct{}
+	index    int
+}
+
+// acquire blocks until a slot is handed to the caller.
+func (s *scheduler) acquire(ctx context.Context) error {
+	s.mu.Lock()
+	if s.free > 0 && len(s.queue) == 0 {
+		s.free--
+		s.mu.Unlock()
+		return nil
+	}
+	w := &waiter{priority: priorityOf(ctx), seq: s.seq, ready: make(chan struct{})}
+	s.seq++
+	heap.Push(&s.queue, w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		select {
+		case <-w.ready:
+			// handed a slot meanwhile, pass it on
+			s.handOff()
+		default:
+			heap.Remove(&s.queue, w.index)
+		}
+		return ctx.Err()
+	}
+}
+
+// release gives a slot back.
+func (s *scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handOff()
+}
+
+// handOff passes a slot to the first waiter or frees it.
+func (s *scheduler) handOff() {
+	if len(s.queue) == 0 {
+		s.free++
+		return
+	}
+	close(heap.Pop(&s.queue).(*waiter).ready)
+}
+
+// waiters is a heap of the highest priority and earliest waiters first.
+type waiters []*waiter
+
+func (ws waiters) Len() int { return len(ws) }
+
+func (ws waiters) Less(i, j int) bool {
+	if ws[i].priority != ws[j].priority {
+		return ws[i].priority > ws[j].priority
+	}
+	return ws[i].seq < ws[j].seq
+}
+
+func (ws waiters) Swap(i, j int) {
+	ws[i], ws[j] = ws[j], ws[i]
+	ws[i].index, ws[j].index = i, j
+}
+
+func (ws *waiters) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*ws)
+	*ws = append(*ws, w)
+}
+
+func (ws *waiters) Pop() any {
+	old := *ws
+	w := old[len(old)-1]
+	*ws = old[:len(old)-1]
+	return w
+}
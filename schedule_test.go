@@ -0,0 +1,87 @@
+package asip
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	s := &scheduler{free: 1}
+	if err := s.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	order := make(chan string, 3)
+	wait := func(name string, p Priority) {
+		go func() {
+			if err := s.acquire(ContextWithPriority(context.Background(), p)); err != nil {
+				t.Error(err)
+				return
+			}
+			order <- name
+			s.release()
+		}()
+	}
+	// queue up one by one before the slot is released
+	for i, w := range []struct {
+		name string
+		p    Priority
+	}{
+		{"batch 1", PriorityBackground},
+		{"batch 2", PriorityBackground},
+		{"api", PriorityInteractive},
+	} {
+		wait(w.name, w.p)
+		for queued(s) <= i {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	s.release()
+
+	got := []string{<-order, <-order, <-order}
+	want := []string{"api", "batch 1", "batch 2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func queued(s *scheduler) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+func TestSchedulerContext(t *testing.T) {
+	s := &scheduler{free: 0}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := s.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want %v, got %v", context.DeadlineExceeded, err)
+	}
+	if len(s.queue) != 0 {
+		t.Fatalf("want the waiter removed, got %d", len(s.queue))
+	}
+}
+
+func TestSchedulerRank(t *testing.T) {
+	c := New(WithFetcher(fileGet), WithScheduler(0))
+	if _, _, _, err := c.Rank(context.Background(), "sberbank.ru"); err != nil {
+		t.Fatalf("want a slot of WithScheduler(0), got %v", err)
+	}
+
+	if err := c.scheduler.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, _, err := c.Rank(ctx, "sberbank.ru"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want Rank waiting for a slot, got %v", err)
+	}
+	if _, err := c.IsRanked(ctx, "sberbank.ru"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want IsRanked waiting for a slot, got %v", err)
+	}
+}
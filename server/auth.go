@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/metrics"
+)
+
+// requests counts requests by the label of their API key, empty without
+// keys, and response status.
+var requests = metrics.Default.NewCounterVec("asip_server_requests_total", "Requests by label of their API key and response status.", "consumer", "code")
+
+// public paths are served without an API key.
+var public = map[string]bool{
+	"/openapi.json": true,
+	"/metrics":      true,
+}
+
+var (
+	errUnauthorized = errors.New("missing or unknown API key")
+	errRateLimited  = errors.New("rate limit exceeded")
+)
+
+// WithAPIKeys requires requests to carry one of keys, mapped to labels of
+// their consumers, as "Authorization: Bearer KEY" or "X-API-Key: KEY".
+func WithAPIKeys(keys map[string]string) Option {
+	return func(s *Server) {
+		s.keys = keys
+	}
+}
+
+type consumerKey struct{}
+
+// Consumer returns the label of the API key a request was made with, e.g.
+// to attribute lookups.
+func Consumer(ctx context.Context) string {
+	c, _ := ctx.Value(consumerKey{}).(string)
+	return c
+}
+
+// authenticate passes requests with a known key within its limits on to
+// next.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w, code: http.StatusOK}
+		var consumer string
+		defer func() {
+			requests.With(consumer, strconv.Itoa(sw.code)).Inc()
+		}()
+
+		if public[r.URL.Path] {
+			next.ServeHTTP(sw, r)
+			return
+		}
+
+		if s.keys != nil {
+			var ok bool
+			if consumer, ok = s.consumer(apiKey(r)); !ok {
+				writeJSON(sw, http.StatusUnauthorized, errorBody{errUnauthorized.Error()})
+				return
+			}
+		}
+		if s.quotas != nil {
+			if wait, ok := s.quotas.allow(consumer); !ok {
+				sw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				writeJSON(sw, http.StatusTooManyRequests, errorBody{errRateLimited.Error()})
+				return
+			}
+		}
+		next.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), consumerKey{}, consumer)))
+	})
+}
+
+// consumer returns the label of key comparing it to every known one in
+// constant time.
+func (s *Server) consumer(key string) (string, bool) {
+	var (
+		label string
+		found bool
+	)
+	for k, l := range s.keys {
+		if subtle.ConstantTimeCompare([]byte(k), []byte(key)) == 1 {
+			label, found = l, true
+		}
+	}
+	return label, found && key != ""
+}
+
+func apiKey(r *http.Request) string {
+	if k := r.Header.Get("X-API-Key"); k != "" {
+		return k
+	}
+	if k, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(k)
+	}
+	return ""
+}
+
+// statusWriter records the status of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Flush lets streaming handlers flush through the wrapper.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the wrapped writer to http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+func TestAPIKeys(t *testing.T) {
+	var consumer string
+	lookup := func(ctx context.Context, domain string) (*asip.Site, error) {
+		consumer = Consumer(ctx)
+		return testLookup(ctx, domain)
+	}
+	s := New(lookup, WithAPIKeys(map[string]string{"secret": "brand"}))
+
+	for header, want := range map[string]int{
+		"":                      http.StatusUnauthorized,
+		"X-API-Key: wrong":      http.StatusUnauthorized,
+		"X-API-Key: secret":     http.StatusOK,
+		"Authorization: secret": http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/sites/example.org", nil)
+		if header != "" {
+			name, value, _ := strings.Cut(header, ": ")
+			req.Header.Set(name, value)
+		}
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Fatalf("%q: want status %d, got %d", header, want, rec.Code)
+		}
+	}
+
+	before := requests.With("brand", "200").Value()
+	req := httptest.NewRequest(http.MethodGet, "/sites/example.org", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	s.ServeHTTP(httptest.NewRecorder(), req)
+	if consumer != "brand" {
+		t.Fatalf("want the lookup attributed to brand, got %q", consumer)
+	}
+	if n := requests.With("brand", "200").Value(); n != before+1 {
+		t.Fatalf("want a request of brand counted, got %g more", n-before)
+	}
+
+	if rec := get(t, s, "/openapi.json"); rec.Code != http.StatusOK {
+		t.Fatalf("want the API description public, got status %d", rec.Code)
+	}
+}
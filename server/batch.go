@@ -0,0 +1,102 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+const (
+	// DefaultBatchConcurrency is how many domains of a batch are looked up
+	// at once by default.
+	DefaultBatchConcurrency = 4
+
+	// maxBatch caps domains of a batch.
+	maxBatch = 1000
+)
+
+// WithBatchConcurrency looks up to n domains of a batch request at once.
+func WithBatchConcurrency(n int) Option {
+	return func(s *Server) {
+		s.batchConcurrency = n
+	}
+}
+
+// batchResult is a line of a batch response.
+type batchResult struct {
+	Domain string `json:"domain"`
+	Site   any    `json:"site,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// batch looks up a JSON array of domains, writing NDJSON results as they
+// complete. Every domain but the first one counts against rate limits too.
+func (s *Server) batch(w http.ResponseWriter, r *http.Request) {
+	fields, ok := s.requestedFields(w, r)
+	if !ok {
+		return
+	}
+
+	var domains []string
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&domains); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{err.Error()})
+		return
+	}
+	if len(domains) > maxBatch {
+		writeJSON(w, http.StatusBadRequest, errorBody{fmt.Sprintf("more than %d domains", maxBatch)})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+
+	var (
+		mu  sync.Mutex
+		enc = json.NewEncoder(w)
+	)
+	write := func(res batchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		enc.Encode(res)
+		rc.Flush()
+	}
+
+	sem := make(chan struct{}, max(s.batchConcurrency, 1))
+	var wg sync.WaitGroup
+	for i, d := range domains {
+		if i > 0 && s.quotas != nil {
+			if _, ok := s.quotas.allow(Consumer(r.Context())); !ok {
+				write(batchResult{Domain: d, Error: errRateLimited.Error()})
+				continue
+			}
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-r.Context().Done():
+			wg.Wait()
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+
+			res := batchResult{Domain: d}
+			site, err := s.lookup(r.Context(), d)
+			switch {
+			case err != nil:
+				res.Error = err.Error()
+			case fields != nil:
+				res.Site = asip.SelectFields(site, fields)
+			default:
+				res.Site = site
+			}
+			write(res)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+func TestBatch(t *testing.T) {
+	var inflight, peak atomic.Int32
+	lookup := func(ctx context.Context, domain string) (*asip.Site, error) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(10 * time.Millisecond)
+		return testLookup(ctx, domain)
+	}
+	s := New(lookup, WithBatchConcurrency(2))
+
+	rec := httptest.NewRecorder()
+	body := `["example.org", "a.example", "b.example", "c.example"]`
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/siteinfo:batch?fields=global_rank", strings.NewReader(body)))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("want an NDJSON stream, got %d %v", rec.Code, rec.Header())
+	}
+	if !rec.Flushed {
+		t.Fatal("want results flushed as they complete")
+	}
+
+	var lines []string
+	sc := bufio.NewScanner(rec.Body)
+	for sc.Scan() {
+		var res struct {
+			Domain string
+			Site   map[string]any
+			Error  string
+		}
+		if err := json.Unmarshal(sc.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, res.Domain+" "+res.Error)
+		if res.Domain == "example.org" && res.Site["GlobalRank"] != 42.0 {
+			t.Fatalf("want global rank 42, got %v", res.Site)
+		}
+	}
+	sort.Strings(lines)
+	if want := "a.example asip: no enough data"; len(lines) != 4 || lines[0] != want || lines[3] != "example.org " {
+		t.Fatalf("want a line per domain, got %q", lines)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Fatalf("want at most 2 lookups at once, got %d", p)
+	}
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/siteinfo:batch", strings.NewReader(`{"domain": "example.org"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("want status 400, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/history"
+)
+
+type grafanaMetric struct {
+	name  string
+	value func(history.Point) uint
+}
+
+// grafanaMetrics are values of history points, a Grafana target is a domain
+// and a metric like example.org:global_rank.
+var grafanaMetrics = []grafanaMetric{
+	{"global_rank", func(p history.Point) uint { return p.GlobalRank }},
+	{"local_rank", func(p history.Point) uint { return p.LocalRank }},
+	{"linking_total", func(p history.Point) uint { return p.LinkingTotal }},
+}
+
+type grafanaSearchRequest struct {
+	Target string `json:"target"`
+}
+
+type grafanaQueryRequest struct {
+	Range struct {
+		From time.Time `json:"from"`
+		To   time.Time `json:"to"`
+	} `json:"range"`
+	Targets []struct {
+		Target string `json:"target"`
+	} `json:"targets"`
+	MaxDataPoints int `json:"maxDataPoints"`
+}
+
+// grafanaSeries holds datapoints as [value, unix milliseconds] pairs.
+type grafanaSeries struct {
+	Target     string      `json:"target"`
+	Datapoints [][2]uint64 `json:"datapoints"`
+}
+
+// grafanaHealth answers the datasource connection test.
+func (s *Server) grafanaHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// grafanaSearch lists targets containing the requested text.
+func (s *Server) grafanaSearch(w http.ResponseWriter, r *http.Request) {
+	var req grafanaSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{err.Error()})
+		return
+	}
+
+	targets := []string{}
+	for _, d := range s.history.Domains() {
+		for _, m := range grafanaMetrics {
+			if t := d + ":" + m.name; strings.Contains(t, req.Target) {
+				targets = append(targets, t)
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, targets)
+}
+
+// grafanaQuery returns series of the targets within the range.
+func (s *Server) grafanaQuery(w http.ResponseWriter, r *http.Request) {
+	var req grafanaQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{err.Error()})
+		return
+	}
+
+	series := []grafanaSeries{}
+	for _, t := range req.Targets {
+		domain, metric, _ := strings.Cut(t.Target, ":")
+		i := slices.IndexFunc(grafanaMetrics, func(m grafanaMetric) bool { return m.name == metric })
+		if i < 0 {
+			writeJSON(w, http.StatusBadRequest, errorBody{"unknown target " + t.Target})
+			return
+		}
+		value := grafanaMetrics[i].value
+
+		// zero values are not ranked or back-filled points lacking the metric
+		gs := grafanaSeries{Target: t.Target, Datapoints: [][2]uint64{}}
+		for _, p := range s.history.Series(domain, req.Range.From, req.Range.To) {
+			if v := value(p); v != 0 {
+				gs.Datapoints = append(gs.Datapoints, [2]uint64{uint64(v), uint64(p.Time.UnixMilli())})
+			}
+		}
+		if n := req.MaxDataPoints; n > 0 && len(gs.Datapoints) > n {
+			gs.Datapoints = gs.Datapoints[len(gs.Datapoints)-n:]
+		}
+		series = append(series, gs)
+	}
+	writeJSON(w, http.StatusOK, series)
+}
+
+// grafanaAnnotations answers with no annotations.
+func (s *Server) grafanaAnnotations(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, []any{})
+}
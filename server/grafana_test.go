@@ -0,0 +1,64 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/history"
+)
+
+func post(t *testing.T, h http.Handler, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return rec
+}
+
+func testHistory(t *testing.T) *history.Store {
+	h, err := history.Load(strings.NewReader(`{"schema_version": 2, "domain": "example.org", "site": {"GlobalRank": 10, "Meta": {"FetchedAt": "2023-01-01T00:00:00Z"}}}
+{"schema_version": 2, "domain": "example.org", "site": {"GlobalRank": 20, "Meta": {"FetchedAt": "2023-02-01T00:00:00Z"}}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestGrafana(t *testing.T) {
+	s := New(testLookup, WithHistory(testHistory(t)))
+
+	if rec := get(t, s, "/grafana/"); rec.Code != http.StatusOK {
+		t.Fatalf("want status 200, got %d", rec.Code)
+	}
+
+	var targets []string
+	if err := json.NewDecoder(post(t, s, "/grafana/search", `{"target": "rank"}`).Body).Decode(&targets); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"example.org:global_rank", "example.org:local_rank"}; !reflect.DeepEqual(targets, want) {
+		t.Fatalf("want %v, got %v", want, targets)
+	}
+
+	rec := post(t, s, "/grafana/query", `{
+		"range": {"from": "2023-01-15T00:00:00Z", "to": "2023-03-01T00:00:00Z"},
+		"targets": [{"target": "example.org:global_rank"}]
+	}`)
+	want := `[{"target":"example.org:global_rank","datapoints":[[20,1675209600000]]}]` + "\n"
+	if rec.Body.String() != want {
+		t.Fatalf("want %s, got %s", want, rec.Body)
+	}
+
+	if rec := post(t, s, "/grafana/query", `{"targets": [{"target": "example.org:bounce_rate"}]}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("want status 400, got %d", rec.Code)
+	}
+}
+
+func TestGrafanaDisabled(t *testing.T) {
+	if rec := post(t, New(testLookup), "/grafana/search", `{}`); rec.Code != http.StatusNotFound {
+		t.Fatalf("want status 404, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+// WithCacheTTL tells clients sites are fresh for ttl after they are
+// fetched, as long as the lookup caches them, e.g. with asip.WithCache.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.ttl = ttl
+	}
+}
+
+// etag identifies a response of the site with the fields, it changes once
+// the site is fetched anew.
+func etag(site *asip.Site, fields []string) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s\x00%d\x00%s", site.Domain, site.Meta.FetchedAt.UnixNano(), strings.Join(fields, ","))
+	return fmt.Sprintf(`"%x"`, h.Sum64())
+}
+
+// cacheHeaders sets validators and freshness of the site, answering 304 if
+// the client has it already.
+func (s *Server) cacheHeaders(w http.ResponseWriter, r *http.Request, site *asip.Site, fields []string) (notModified bool) {
+	if site.Meta.FetchedAt.IsZero() {
+		return false
+	}
+
+	tag := etag(site, fields)
+	w.Header().Set("ETag", tag)
+	w.Header().Set("Last-Modified", site.Meta.FetchedAt.UTC().Format(http.TimeFormat))
+
+	scope := "public"
+	if s.keys != nil {
+		scope = "private"
+	}
+	age := max(time.Until(site.Meta.FetchedAt.Add(s.ttl)), 0)
+	w.Header().Set("Cache-Control", fmt.Sprintf("%s, max-age=%d", scope, int(age.Seconds())))
+
+	if !matches(r.Header.Get("If-None-Match"), tag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// matches tells whether an If-None-Match header lists the tag.
+func matches(header, tag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+func TestCacheHeaders(t *testing.T) {
+	fetched := time.Now().Add(-time.Hour)
+	lookup := func(ctx context.Context, domain string) (*asip.Site, error) {
+		return &asip.Site{Domain: domain, GlobalRank: 42, Meta: asip.Meta{FetchedAt: fetched}}, nil
+	}
+	s := New(lookup, WithCacheTTL(2*time.Hour))
+
+	rec := get(t, s, "/sites/example.org")
+	tag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || tag == "" {
+		t.Fatalf("want an ETag, got %d %v", rec.Code, rec.Header())
+	}
+	if cc := rec.Header().Get("Cache-Control"); !strings.HasPrefix(cc, "public, max-age=35") {
+		t.Fatalf("want about an hour left, got %q", cc)
+	}
+	if other := get(t, s, "/sites/example.org?fields=global_rank").Header().Get("ETag"); other == tag {
+		t.Fatal("want fields to change the ETag")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/sites/example.org", nil)
+	req.Header.Set("If-None-Match", `"other", W/`+tag)
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Fatalf("want status 304 without a body, got %d %q", rec.Code, rec.Body)
+	}
+
+	fetched = time.Now()
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want status 200 once fetched anew, got %d", rec.Code)
+	}
+}
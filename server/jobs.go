@@ -0,0 +1,342 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+// maxJob caps domains of a job.
+const maxJob = 1000000
+
+// Statuses of a Job.
+const (
+	JobRunning = "running"
+	JobDone    = "done"
+)
+
+// Job is a batch of lookups run in the background.
+type Job struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	Consumer   string     `json:"consumer,omitempty"` // label of the API key submitting it
+	Total      int        `json:"total"`
+	Done       int        `json:"done"`
+	Failed     int        `json:"failed"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// jobFile is the content of a job file.
+type jobFile struct {
+	Job
+	Domains []string `json:"domains"`
+	Fields  []string `json:"fields,omitempty"`
+}
+
+// WithJobs runs jobs submitted to POST /v1/jobs keeping them in a
+// directory, created if needed, so their results survive restarts, see
+// Server.ResumeJobs.
+func WithJobs(dir string) Option {
+	return func(s *Server) {
+		s.jobs = &jobs{dir: dir, running: make(map[string]*Job)}
+	}
+}
+
+// jobs keeps job files as DIR/ID.json and their results as DIR/ID.ndjson.
+type jobs struct {
+	dir string
+
+	mu      sync.Mutex
+	running map[string]*Job
+}
+
+// validJobID tells whether id is shaped like the ones submitJob generates,
+// so it can't reach files outside the directory.
+func validJobID(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+func (js *jobs) file(id, ext string) string {
+	return filepath.Join(js.dir, id+ext)
+}
+
+func (js *jobs) save(f jobFile) error {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(js.dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(js.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), js.file(f.ID, ".json"))
+}
+
+func (js *jobs) load(id string) (jobFile, bool, error) {
+	var f jobFile
+	b, err := os.ReadFile(js.file(id, ".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return f, false, nil
+	}
+	if err != nil {
+		return f, false, err
+	}
+	return f, true, json.Unmarshal(b, &f)
+}
+
+// status returns the job, up to date while it runs.
+func (js *jobs) status(id string) (Job, bool, error) {
+	js.mu.Lock()
+	if j, ok := js.running[id]; ok {
+		defer js.mu.Unlock()
+		return *j, true, nil
+	}
+	js.mu.Unlock()
+
+	f, ok, err := js.load(id)
+	return f.Job, ok, err
+}
+
+// ResumeJobs runs jobs interrupted by a restart again, skipping domains
+// they have results of.
+func (s *Server) ResumeJobs() error {
+	if s.jobs == nil {
+		return nil
+	}
+	names, err := filepath.Glob(filepath.Join(s.jobs.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		id := strings.TrimSuffix(filepath.Base(name), ".json")
+		if !validJobID(id) {
+			continue
+		}
+		f, ok, err := s.jobs.load(id)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if ok && f.Status == JobRunning {
+			go s.runJob(f)
+		}
+	}
+	return nil
+}
+
+// runJob looks domains of the job up appending results as they complete,
+// waiting for rate limits of its consumer.
+func (s *Server) runJob(f jobFile) {
+	js := s.jobs
+	seen := make(map[string]bool)
+	f.Done, f.Failed = 0, 0
+	if out, err := os.Open(js.file(f.ID, ".ndjson")); err == nil {
+		sc := bufio.NewScanner(out)
+		sc.Buffer(nil, 1<<20)
+		for sc.Scan() {
+			var res batchResult
+			if json.Unmarshal(sc.Bytes(), &res) != nil {
+				break
+			}
+			seen[res.Domain] = true
+			f.Done++
+			if res.Error != "" {
+				f.Failed++
+			}
+		}
+		out.Close()
+	}
+
+	out, err := os.OpenFile(js.file(f.ID, ".ndjson"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer out.Close()
+
+	j := f.Job
+	js.mu.Lock()
+	js.running[j.ID] = &j
+	js.mu.Unlock()
+
+	var (
+		mu  sync.Mutex
+		enc = json.NewEncoder(out)
+		sem = make(chan struct{}, max(s.batchConcurrency, 1))
+		wg  sync.WaitGroup
+	)
+	ctx := asip.ContextWithPriority(context.Background(), asip.PriorityBackground)
+	for _, d := range f.Domains {
+		if seen[d] {
+			continue
+		}
+		seen[d] = true
+		s.wait(j.Consumer)
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+
+			res := batchResult{Domain: d}
+			site, err := s.lookup(ctx, d)
+			switch {
+			case err != nil:
+				res.Error = err.Error()
+			case f.Fields != nil:
+				res.Site = asip.SelectFields(site, f.Fields)
+			default:
+				res.Site = site
+			}
+
+			mu.Lock()
+			enc.Encode(res)
+			mu.Unlock()
+			js.mu.Lock()
+			j.Done++
+			if err != nil {
+				j.Failed++
+			}
+			js.mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	js.mu.Lock()
+	now := time.Now()
+	j.Status, j.FinishedAt = JobDone, &now
+	f.Job = j
+	js.mu.Unlock()
+
+	// the job stays running to be resumed if saving fails
+	if js.save(f) == nil {
+		js.mu.Lock()
+		delete(js.running, j.ID)
+		js.mu.Unlock()
+	}
+}
+
+// wait blocks until the consumer is within its rate limits.
+func (s *Server) wait(consumer string) {
+	if s.quotas == nil {
+		return
+	}
+	for {
+		wait, ok := s.quotas.allow(consumer)
+		if ok {
+			return
+		}
+		time.Sleep(wait)
+	}
+}
+
+// submitJob starts a job of a JSON array of domains.
+func (s *Server) submitJob(w http.ResponseWriter, r *http.Request) {
+	fields, ok := s.requestedFields(w, r)
+	if !ok {
+		return
+	}
+
+	var domains []string
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<20)).Decode(&domains); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{err.Error()})
+		return
+	}
+	if len(domains) > maxJob {
+		writeJSON(w, http.StatusBadRequest, errorBody{fmt.Sprintf("more than %d domains", maxJob)})
+		return
+	}
+
+	id := make([]byte, 16)
+	rand.Read(id)
+	f := jobFile{
+		Job: Job{
+			ID:        hex.EncodeToString(id),
+			Status:    JobRunning,
+			Consumer:  Consumer(r.Context()),
+			Total:     len(domains),
+			CreatedAt: time.Now(),
+		},
+		Domains: domains,
+		Fields:  fields,
+	}
+	if err := s.jobs.save(f); err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorBody{err.Error()})
+		return
+	}
+	go s.runJob(f)
+
+	w.Header().Set("Location", "/v1/jobs/"+f.ID)
+	writeJSON(w, http.StatusAccepted, f.Job)
+}
+
+// job returns the job of the request, answering 404 if there is none of
+// the consumer.
+func (s *Server) job(w http.ResponseWriter, r *http.Request) (Job, bool) {
+	id := r.PathValue("id")
+	if !validJobID(id) {
+		writeJSON(w, http.StatusNotFound, errorBody{"no such job"})
+		return Job{}, false
+	}
+	j, ok, err := s.jobs.status(id)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorBody{err.Error()})
+		return j, false
+	}
+	if !ok || j.Consumer != Consumer(r.Context()) {
+		writeJSON(w, http.StatusNotFound, errorBody{"no such job"})
+		return j, false
+	}
+	return j, true
+}
+
+func (s *Server) jobStatus(w http.ResponseWriter, r *http.Request) {
+	if j, ok := s.job(w, r); ok {
+		writeJSON(w, http.StatusOK, j)
+	}
+}
+
+// jobResults serves NDJSON results of a finished job.
+func (s *Server) jobResults(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.job(w, r)
+	if !ok {
+		return
+	}
+	if j.Status != JobDone {
+		writeJSON(w, http.StatusConflict, errorBody{"job is " + j.Status})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	http.ServeFile(w, r, s.jobs.file(j.ID, ".ndjson"))
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+// waitJob polls the job until it is done.
+func waitJob(t *testing.T, h http.Handler, id string) Job {
+	t.Helper()
+	for range 100 {
+		var j Job
+		rec := get(t, h, "/v1/jobs/"+id)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("want status 200, got %d", rec.Code)
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &j); err != nil {
+			t.Fatal(err)
+		}
+		if j.Status == JobDone {
+			return j
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("job is not done")
+	return Job{}
+}
+
+func TestJobs(t *testing.T) {
+	release := make(chan struct{})
+	lookup := func(ctx context.Context, domain string) (*asip.Site, error) {
+		<-release
+		return testLookup(ctx, domain)
+	}
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "secret.json"), []byte(`{"id": "secret", "status": "done"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := New(lookup, WithJobs(filepath.Join(root, "jobs")))
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/jobs?fields=global_rank", strings.NewReader(`["example.org", "a.example"]`)))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("want status 202, got %d", rec.Code)
+	}
+	var j Job
+	if err := json.Unmarshal(rec.Body.Bytes(), &j); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Header().Get("Location") != "/v1/jobs/"+j.ID || j.Status != JobRunning || j.Total != 2 {
+		t.Fatalf("want a running job of 2 domains, got %+v %v", j, rec.Header())
+	}
+
+	if rec := get(t, s, "/v1/jobs/"+j.ID+"/results"); rec.Code != http.StatusConflict {
+		t.Fatalf("want status 409 while running, got %d", rec.Code)
+	}
+	close(release)
+
+	if j = waitJob(t, s, j.ID); j.Done != 2 || j.Failed != 1 || j.FinishedAt == nil {
+		t.Fatalf("want 2 domains done, 1 failed, got %+v", j)
+	}
+	rec = get(t, s, "/v1/jobs/"+j.ID+"/results")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `{"domain":"example.org","site":{"GlobalRank":42}}`) {
+		t.Fatalf("want results, got %d %s", rec.Code, rec.Body)
+	}
+
+	for _, id := range []string{"unknown", "..%2Fsecret", strings.Repeat("0", 32)} {
+		if rec := get(t, s, "/v1/jobs/"+id); rec.Code != http.StatusNotFound {
+			t.Fatalf("want status 404 of %s, got %d", id, rec.Code)
+		}
+	}
+	if rec := get(t, New(testLookup), "/v1/jobs/"+j.ID); rec.Code != http.StatusNotFound {
+		t.Fatalf("want no jobs without WithJobs, got %d", rec.Code)
+	}
+}
+
+func TestJobsOfConsumer(t *testing.T) {
+	s := New(testLookup, WithJobs(t.TempDir()), WithAPIKeys(map[string]string{"k1": "a", "k2": "b"}))
+
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/v1/jobs", strings.NewReader(`["example.org"]`))
+	r.Header.Set("X-API-Key", "k1")
+	s.ServeHTTP(rec, r)
+	var j Job
+	if err := json.Unmarshal(rec.Body.Bytes(), &j); err != nil {
+		t.Fatal(err)
+	}
+
+	rec = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/v1/jobs/"+j.ID, nil)
+	r.Header.Set("X-API-Key", "k2")
+	s.ServeHTTP(rec, r)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("want jobs hidden from other consumers, got %d", rec.Code)
+	}
+}
+
+func TestResumeJobs(t *testing.T) {
+	dir := t.TempDir()
+	const id = "0123456789abcdef0123456789abcdef"
+	f := jobFile{Job: Job{ID: id, Status: JobRunning, Total: 2}, Domains: []string{"a.example", "example.org"}}
+	b, _ := json.Marshal(f)
+	if err := os.WriteFile(filepath.Join(dir, id+".json"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, id+".ndjson"), []byte(`{"domain":"a.example","error":"asip: no enough data"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu     sync.Mutex
+		looked []string
+	)
+	lookup := func(ctx context.Context, domain string) (*asip.Site, error) {
+		mu.Lock()
+		looked = append(looked, domain)
+		mu.Unlock()
+		return testLookup(ctx, domain)
+	}
+	s := New(lookup, WithJobs(dir))
+	if err := s.ResumeJobs(); err != nil {
+		t.Fatal(err)
+	}
+
+	if j := waitJob(t, s, id); j.Done != 2 || j.Failed != 1 {
+		t.Fatalf("want 2 domains done, 1 failed, got %+v", j)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(looked) != 1 || looked[0] != "example.org" {
+		t.Fatalf("want only the domain without a result looked up, got %q", looked)
+	}
+}
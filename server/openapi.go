@@ -0,0 +1,247 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/export"
+)
+
+const schemaPrefix = "#/components/schemas/"
+
+var openAPIDoc = sync.OnceValue(func() []byte {
+	b, err := json.MarshalIndent(OpenAPI(), "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return b
+})
+
+// OpenAPI returns an OpenAPI 3.1 document describing the endpoints, the
+// schemas come from export.JSONSchemas.
+func OpenAPI() map[string]any {
+	schemas := export.JSONSchemas(schemaPrefix)
+	schemas["Job"] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":          map[string]any{"type": "string"},
+			"status":      map[string]any{"type": "string", "enum": []string{JobRunning, JobDone}},
+			"consumer":    map[string]any{"type": "string"},
+			"total":       map[string]any{"type": "integer"},
+			"done":        map[string]any{"type": "integer"},
+			"failed":      map[string]any{"type": "integer"},
+			"created_at":  map[string]any{"type": "string", "format": "date-time"},
+			"finished_at": map[string]any{"type": "string", "format": "date-time"},
+		},
+		"required": []string{"id", "status", "total", "done", "failed", "created_at"},
+	}
+	schemas["Error"] = map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"error": map[string]any{"type": "string"}},
+		"required":   []string{"error"},
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       "asip",
+			"description": "Alexa Website Info of domains.",
+			"version":     "2",
+		},
+		"paths": map[string]any{
+			"/sites/{domain}": map[string]any{
+				"get": map[string]any{
+					"operationId": "getSite",
+					"summary":     "Look up a domain.",
+					"parameters":  []any{domainParam(), fieldsParam(), ifNoneMatchParam()},
+					"security":    apiKeySecurity(),
+					"responses": map[string]any{
+						"200": jsonResponse("Website Info of the domain.", "Site"),
+						"304": map[string]any{"description": "The site has the ETag of If-None-Match."},
+						"400": jsonResponse("Unknown fields are asked.", "Error"),
+						"401": jsonResponse("The server requires an API key.", "Error"),
+						"429": jsonResponse("The rate limit or the daily quota of the API key is exceeded, see Retry-After.", "Error"),
+						"404": jsonResponse("The domain is not ranked.", "Error"),
+						"502": jsonResponse("The provider failed.", "Error"),
+						"504": jsonResponse("The provider timed out.", "Error"),
+					},
+				},
+			},
+			"/v1/siteinfo:batch": map[string]any{
+				"post": map[string]any{
+					"operationId": "batchSites",
+					"summary":     "Look up domains, streaming results as they complete.",
+					"parameters":  []any{fieldsParam()},
+					"security":    apiKeySecurity(),
+					"requestBody": map[string]any{
+						"required": true,
+						"content": map[string]any{
+							"application/json": map[string]any{
+								"schema": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "maxItems": maxBatch},
+							},
+						},
+					},
+					"responses": map[string]any{
+						"200": map[string]any{
+							"description": `A line like {"domain": ..., "site": ...} or {"domain": ..., "error": ...} per domain.`,
+							"content":     map[string]any{"application/x-ndjson": map[string]any{}},
+						},
+						"400": jsonResponse("The body is not an array of domains or unknown fields are asked.", "Error"),
+						"401": jsonResponse("The server requires an API key.", "Error"),
+						"429": jsonResponse("The rate limit or the daily quota of the API key is exceeded, see Retry-After.", "Error"),
+					},
+				},
+			},
+			"/v1/jobs": map[string]any{
+				"post": map[string]any{
+					"operationId": "submitJob",
+					"summary":     "Look up domains in the background, if the server keeps jobs.",
+					"parameters":  []any{fieldsParam()},
+					"security":    apiKeySecurity(),
+					"requestBody": map[string]any{
+						"required": true,
+						"content": map[string]any{
+							"application/json": map[string]any{
+								"schema": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "maxItems": maxJob},
+							},
+						},
+					},
+					"responses": map[string]any{
+						"202": jsonResponse("The job is running, see Location.", "Job"),
+						"400": jsonResponse("The body is not an array of domains or unknown fields are asked.", "Error"),
+						"401": jsonResponse("The server requires an API key.", "Error"),
+						"429": jsonResponse("The rate limit or the daily quota of the API key is exceeded, see Retry-After.", "Error"),
+					},
+				},
+			},
+			"/v1/jobs/{id}": map[string]any{
+				"get": map[string]any{
+					"operationId": "getJob",
+					"summary":     "Progress of a job.",
+					"parameters":  []any{jobParam()},
+					"security":    apiKeySecurity(),
+					"responses": map[string]any{
+						"200": jsonResponse("The job.", "Job"),
+						"401": jsonResponse("The server requires an API key.", "Error"),
+						"404": jsonResponse("There is no such job of the API key.", "Error"),
+					},
+				},
+			},
+			"/v1/jobs/{id}/results": map[string]any{
+				"get": map[string]any{
+					"operationId": "getJobResults",
+					"summary":     "Results of a finished job.",
+					"parameters":  []any{jobParam()},
+					"security":    apiKeySecurity(),
+					"responses": map[string]any{
+						"200": map[string]any{
+							"description": `A line like {"domain": ..., "site": ...} or {"domain": ..., "error": ...} per domain, in order of completion.`,
+							"content":     map[string]any{"application/x-ndjson": map[string]any{}},
+						},
+						"401": jsonResponse("The server requires an API key.", "Error"),
+						"404": jsonResponse("There is no such job of the API key.", "Error"),
+						"409": jsonResponse("The job is still running.", "Error"),
+					},
+				},
+			},
+			"/metrics": map[string]any{
+				"get": map[string]any{
+					"operationId": "getMetrics",
+					"summary":     "Metrics in the Prometheus text format.",
+					"responses": map[string]any{
+						"200": map[string]any{
+							"description": "Metrics.",
+							"content":     map[string]any{"text/plain": map[string]any{"schema": map[string]any{"type": "string"}}},
+						},
+					},
+				},
+			},
+			"/openapi.json": map[string]any{
+				"get": map[string]any{
+					"operationId": "getOpenAPI",
+					"summary":     "This document.",
+					"responses": map[string]any{
+						"200": map[string]any{
+							"description": "OpenAPI document.",
+							"content":     map[string]any{"application/json": map[string]any{}},
+						},
+					},
+				},
+			},
+		},
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"apiKey": map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+				"bearer": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"x-schema-version": asip.SchemaVersion,
+	}
+}
+
+func domainParam() map[string]any {
+	return map[string]any{
+		"name":     "domain",
+		"in":       "path",
+		"required": true,
+		"schema":   map[string]any{"type": "string"},
+		"example":  "example.org",
+	}
+}
+
+func jobParam() map[string]any {
+	return map[string]any{
+		"name":     "id",
+		"in":       "path",
+		"required": true,
+		"schema":   map[string]any{"type": "string"},
+	}
+}
+
+// apiKeySecurity accepts either API key scheme, or none when the server is
+// not configured with keys.
+func apiKeySecurity() []any {
+	return []any{
+		map[string]any{"apiKey": []string{}},
+		map[string]any{"bearer": []string{}},
+		map[string]any{},
+	}
+}
+
+func ifNoneMatchParam() map[string]any {
+	return map[string]any{
+		"name":        "If-None-Match",
+		"in":          "header",
+		"description": "ETag of a response the client has, answered with 304 until the site is fetched anew.",
+		"schema":      map[string]any{"type": "string"},
+	}
+}
+
+func fieldsParam() map[string]any {
+	return map[string]any{
+		"name":        "fields",
+		"in":          "query",
+		"description": "Comma separated fields of Site to answer with only.",
+		"schema":      map[string]any{"type": "string"},
+		"example":     "global_rank,country,linking_total",
+	}
+}
+
+func jsonResponse(description, schema string) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content": map[string]any{
+			"application/json": map[string]any{
+				"schema": map[string]any{"$ref": schemaPrefix + schema},
+			},
+		},
+	}
+}
+
+func (s *Server) openAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPIDoc())
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimit caps requests of a consumer, zero fields mean no limit.
+type RateLimit struct {
+	PerMinute int `json:"per_minute,omitempty"` // sustained rate, allowing bursts of as many
+	Daily     int `json:"daily,omitempty"`      // per UTC day
+}
+
+// WithRateLimits caps requests of consumers by labels of their API keys,
+// answering 429 with Retry-After beyond the limits, so a single one can't
+// use the whole budget of alexa.com. The limit of "" applies to requests
+// of a server without keys.
+func WithRateLimits(limits map[string]RateLimit) Option {
+	return func(s *Server) {
+		s.quotas = &quotas{limits: limits, now: time.Now, usage: make(map[string]*usage)}
+	}
+}
+
+// quotas account requests of consumers.
+type quotas struct {
+	limits map[string]RateLimit
+	now    func() time.Time
+
+	mu    sync.Mutex
+	usage map[string]*usage
+}
+
+type usage struct {
+	tokens float64 // of the PerMinute bucket
+	filled time.Time
+	day    time.Time
+	count  int // requests of the day
+}
+
+// allow accounts a request of the consumer unless it exceeds a limit, then
+// it tells how long to wait.
+func (q *quotas) allow(consumer string) (retryAfter time.Duration, ok bool) {
+	l, limited := q.limits[consumer]
+	if !limited {
+		return 0, true
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := q.now()
+	u, seen := q.usage[consumer]
+	if !seen {
+		u = &usage{tokens: float64(l.PerMinute), filled: now}
+		q.usage[consumer] = u
+	}
+
+	if day := now.UTC().Truncate(24 * time.Hour); !u.day.Equal(day) {
+		u.day, u.count = day, 0
+	}
+	if l.Daily > 0 && u.count >= l.Daily {
+		return u.day.Add(24 * time.Hour).Sub(now), false
+	}
+
+	if l.PerMinute > 0 {
+		perSecond := float64(l.PerMinute) / 60
+		u.tokens = math.Min(float64(l.PerMinute), u.tokens+now.Sub(u.filled).Seconds()*perSecond)
+		u.filled = now
+		if u.tokens < 1 {
+			return time.Duration((1 - u.tokens) / perSecond * float64(time.Second)), false
+		}
+		u.tokens--
+	}
+
+	u.count++
+	return 0, true
+}
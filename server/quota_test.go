@@ -0,0 +1,56 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestQuotas(t *testing.T) {
+	now := time.Date(2023, 1, 1, 23, 59, 0, 0, time.UTC)
+	q := &quotas{
+		limits: map[string]RateLimit{"brand": {PerMinute: 2, Daily: 3}},
+		now:    func() time.Time { return now },
+		usage:  make(map[string]*usage),
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, ok := q.allow("brand"); !ok {
+			t.Fatalf("want request %d within the burst allowed", i)
+		}
+	}
+	if wait, ok := q.allow("brand"); ok || wait != 30*time.Second {
+		t.Fatalf("want to wait 30s, got %v %v", ok, wait)
+	}
+
+	now = now.Add(30 * time.Second)
+	if _, ok := q.allow("brand"); !ok {
+		t.Fatal("want a request allowed once a token is back")
+	}
+	now = now.Add(time.Second)
+	if wait, ok := q.allow("brand"); ok || wait != 29*time.Second {
+		t.Fatalf("want the daily quota exceeded until midnight, got %v %v", ok, wait)
+	}
+
+	now = now.Add(30 * time.Second)
+	if _, ok := q.allow("brand"); !ok {
+		t.Fatal("want the quota renewed the next day")
+	}
+	if _, ok := q.allow("search"); !ok {
+		t.Fatal("want consumers without limits allowed")
+	}
+}
+
+func TestRateLimitedResponse(t *testing.T) {
+	s := New(testLookup, WithRateLimits(map[string]RateLimit{"": {Daily: 1}}))
+	if rec := get(t, s, "/sites/example.org"); rec.Code != http.StatusOK {
+		t.Fatalf("want status 200, got %d", rec.Code)
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sites/example.org", nil))
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("want status 429 with Retry-After, got %d %v", rec.Code, rec.Header())
+	}
+}
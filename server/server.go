@@ -0,0 +1,151 @@
+// Package server exposes lookups over a REST API.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/history"
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/metrics"
+)
+
+// LookupFunc looks up a domain, e.g. Conf.SiteInfo.
+type LookupFunc func(ctx context.Context, domain string) (*asip.Site, error)
+
+// Server serves lookups over HTTP.
+type Server struct {
+	lookup  LookupFunc
+	history *history.Store
+	fields  []string
+	keys    map[string]string
+	quotas  *quotas
+	jobs    *jobs
+	ttl     time.Duration
+	mux     *http.ServeMux
+	handler http.Handler
+
+	batchConcurrency int
+}
+
+// Option customises a Server.
+type Option func(*Server)
+
+// WithHistory serves series of the store to Grafana under /grafana as a
+// JSON datasource.
+func WithHistory(h *history.Store) Option {
+	return func(s *Server) {
+		s.history = h
+	}
+}
+
+// WithFields answers with only the fields, as resolved by asip.ParseFields,
+// unless others are asked with ?fields=.
+func WithFields(fields ...string) Option {
+	return func(s *Server) {
+		s.fields = fields
+	}
+}
+
+// New bootstraps a Server answering with lookup.
+func New(lookup LookupFunc, opts ...Option) *Server {
+	s := &Server{lookup: lookup, batchConcurrency: DefaultBatchConcurrency, mux: http.NewServeMux()}
+	for _, o := range opts {
+		o(s)
+	}
+
+	s.mux.HandleFunc("GET /sites/{domain}", s.site)
+	s.mux.HandleFunc("POST /v1/siteinfo:batch", s.batch)
+	if s.jobs != nil {
+		s.mux.HandleFunc("POST /v1/jobs", s.submitJob)
+		s.mux.HandleFunc("GET /v1/jobs/{id}", s.jobStatus)
+		s.mux.HandleFunc("GET /v1/jobs/{id}/results", s.jobResults)
+	}
+	s.mux.HandleFunc("GET /openapi.json", s.openAPI)
+	s.mux.Handle("GET /metrics", metrics.Default)
+	if s.history != nil {
+		s.mux.HandleFunc("GET /grafana/{$}", s.grafanaHealth)
+		s.mux.HandleFunc("POST /grafana/search", s.grafanaSearch)
+		s.mux.HandleFunc("POST /grafana/query", s.grafanaQuery)
+		s.mux.HandleFunc("POST /grafana/annotations", s.grafanaAnnotations)
+	}
+	s.handler = s.authenticate(s.mux)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+func (s *Server) site(w http.ResponseWriter, r *http.Request) {
+	fields, ok := s.requestedFields(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := asip.ContextWithPriority(r.Context(), asip.PriorityInteractive)
+	site, err := s.lookup(ctx, r.PathValue("domain"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if s.cacheHeaders(w, r, site, fields) {
+		return
+	}
+	if fields != nil {
+		writeJSON(w, http.StatusOK, asip.SelectFields(site, fields))
+		return
+	}
+	writeJSON(w, http.StatusOK, site)
+}
+
+// requestedFields returns fields asked with ?fields=, or the default ones,
+// answering 400 if they are unknown.
+func (s *Server) requestedFields(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	list := r.URL.Query().Get("fields")
+	if list == "" {
+		return s.fields, true
+	}
+
+	fields, err := asip.ParseFields(list)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{err.Error()})
+		return nil, false
+	}
+	return fields, true
+}
+
+// errorBody is returned along with a non-2xx status.
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+// status maps a lookup error to a response status.
+func status(err error) int {
+	var se *asip.StatusError
+	switch {
+	case errors.Is(err, asip.ErrNoEnoughData):
+		return http.StatusNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.As(err, &se):
+		if se.Code == http.StatusNotFound {
+			return http.StatusNotFound
+		}
+		return http.StatusBadGateway
+	}
+	return http.StatusBadGateway
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, status(err), errorBody{err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
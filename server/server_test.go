@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+func testLookup(ctx context.Context, domain string) (*asip.Site, error) {
+	if domain != "example.org" {
+		return nil, asip.ErrNoEnoughData
+	}
+	return &asip.Site{Domain: domain, GlobalRank: 42}, nil
+}
+
+func get(t *testing.T, h http.Handler, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestSite(t *testing.T) {
+	s := New(testLookup)
+
+	rec := get(t, s, "/sites/example.org")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want status 200, got %d", rec.Code)
+	}
+	var site asip.Site
+	if err := json.NewDecoder(rec.Body).Decode(&site); err != nil {
+		t.Fatal(err)
+	}
+	if site.GlobalRank != 42 {
+		t.Fatalf("want global rank 42, got %d", site.GlobalRank)
+	}
+
+	rec = get(t, s, "/sites/unranked.example")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("want status 404, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"error"`) {
+		t.Fatalf("want an error body, got %s", rec.Body)
+	}
+}
+
+func TestSiteFields(t *testing.T) {
+	rec := get(t, New(testLookup), "/sites/example.org?fields=global_rank")
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != `{"GlobalRank":42}` {
+		t.Fatalf("want the global rank only, got %d %s", rec.Code, rec.Body)
+	}
+
+	rec = get(t, New(testLookup, WithFields("Domain")), "/sites/example.org")
+	if strings.TrimSpace(rec.Body.String()) != `{"Domain":"example.org"}` {
+		t.Fatalf("want the domain only, got %s", rec.Body)
+	}
+
+	if rec = get(t, New(testLookup), "/sites/example.org?fields=popularity"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("want status 400, got %d", rec.Code)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	rec := get(t, New(testLookup), "/openapi.json")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("want status 200, got %d", rec.Code)
+	}
+
+	var doc struct {
+		OpenAPI    string
+		Paths      map[string]any
+		Components struct {
+			Schemas map[string]any
+		}
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Paths["/sites/{domain}"] == nil {
+		t.Fatalf("no /sites/{domain} in %v", doc.Paths)
+	}
+	for _, name := range []string{"Site", "Visitor", "Meta", "Error"} {
+		if doc.Components.Schemas[name] == nil {
+			t.Fatalf("no %s schema", name)
+		}
+	}
+	if strings.Contains(rec.Body.String(), "#/$defs/") {
+		t.Fatal("references are not rebased onto components")
+	}
+}
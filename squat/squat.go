@@ -0,0 +1,182 @@
+// Package squat generates typosquatting variants of a domain and checks
+// which of them are actually ranked.
+package squat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+// Kinds of variants.
+const (
+	BitFlip       = "bitflip"
+	Homoglyph     = "homoglyph"
+	TLDSwap       = "tld"
+	Omission      = "omission"
+	Repetition    = "repetition"
+	Transposition = "transposition"
+)
+
+// DefaultTLDs are suffixes tried by TLD swapping.
+var DefaultTLDs = []string{"com", "net", "org", "info", "biz", "co", "io", "ru", "cn", "de", "uk", "xyz", "top", "online", "site"}
+
+var homoglyphs = map[string][]string{
+	"a":  {"4"},
+	"b":  {"d", "lb"},
+	"d":  {"b", "cl"},
+	"e":  {"3"},
+	"g":  {"q", "9"},
+	"i":  {"1", "l"},
+	"l":  {"1", "i"},
+	"m":  {"rn", "nn"},
+	"n":  {"m"},
+	"o":  {"0"},
+	"q":  {"g"},
+	"s":  {"5"},
+	"u":  {"v"},
+	"v":  {"u"},
+	"w":  {"vv"},
+	"z":  {"2"},
+	"0":  {"o"},
+	"1":  {"l", "i"},
+	"rn": {"m"},
+	"vv": {"w"},
+	"cl": {"d"},
+}
+
+// Variant is a look-alike of a domain.
+type Variant struct {
+	Domain string
+	Kind   string
+}
+
+// Generate returns variants of domain, the leftmost label is mutated and
+// the rest of the name is swapped for DefaultTLDs.
+func Generate(domain string) []Variant {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	label, suffix := domain, ""
+	if i := strings.IndexByte(domain, '.'); i >= 0 {
+		label, suffix = domain[:i], domain[i:]
+	}
+
+	seen := map[string]bool{domain: true}
+	var vs []Variant
+	add := func(d, kind string) {
+		if seen[d] || !validLabel(strings.SplitN(d, ".", 2)[0]) {
+			return
+		}
+		seen[d] = true
+		vs = append(vs, Variant{d, kind})
+	}
+
+	for i := 0; i < len(label); i++ {
+		for b := uint(0); b < 8; b++ {
+			c := label[i] ^ 1<<b
+			if validChar(c) {
+				add(label[:i]+string(c)+label[i+1:]+suffix, BitFlip)
+			}
+		}
+	}
+
+	var from []string
+	for k := range homoglyphs {
+		from = append(from, k)
+	}
+	sort.Strings(from)
+	for _, k := range from {
+		for i := strings.Index(label, k); i >= 0; {
+			for _, g := range homoglyphs[k] {
+				add(label[:i]+g+label[i+len(k):]+suffix, Homoglyph)
+			}
+			j := strings.Index(label[i+1:], k)
+			if j < 0 {
+				break
+			}
+			i += j + 1
+		}
+	}
+
+	for i := range label {
+		add(label[:i]+label[i+1:]+suffix, Omission)
+		add(label[:i]+label[i:i+1]+label[i:]+suffix, Repetition)
+		if i+1 < len(label) {
+			add(label[:i]+label[i+1:i+2]+label[i:i+1]+label[i+2:]+suffix, Transposition)
+		}
+	}
+
+	for _, tld := range DefaultTLDs {
+		add(label+"."+tld, TLDSwap)
+	}
+
+	return vs
+}
+
+func validChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-'
+}
+
+func validLabel(l string) bool {
+	return l != "" && l[0] != '-' && l[len(l)-1] != '-'
+}
+
+// Ranker looks up ranks of a domain, *asip.Conf satisfies it.
+type Ranker interface {
+	Rank(ctx context.Context, domain string) (global, local uint, country string, err error)
+}
+
+// Finding is a checked variant.
+type Finding struct {
+	Variant
+	Ranked     bool
+	GlobalRank uint
+	Err        error
+}
+
+// Check looks up ranks of variants one by one.
+func Check(ctx context.Context, r Ranker, vs []Variant) []Finding {
+	fs := make([]Finding, 0, len(vs))
+	for _, v := range vs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		global, _, _, err := r.Rank(ctx, v.Domain)
+		f := Finding{Variant: v, GlobalRank: global}
+		switch {
+		case err == nil:
+			f.Ranked = true
+		case !unranked(err):
+			f.Err = err
+		}
+		fs = append(fs, f)
+	}
+	return fs
+}
+
+// unranked reports whether err only means there is no ranking data.
+func unranked(err error) bool {
+	var se *asip.StatusError
+	if errors.As(err, &se) {
+		return se.Code == http.StatusNotFound
+	}
+	return errors.Is(err, asip.ErrNoEnoughData)
+}
+
+// Ranked returns the ranked findings, most popular first.
+func Ranked(fs []Finding) []Finding {
+	var rs []Finding
+	for _, f := range fs {
+		if f.Ranked {
+			rs = append(rs, f)
+		}
+	}
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].GlobalRank < rs[j].GlobalRank
+	})
+	return rs
+}
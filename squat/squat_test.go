@@ -0,0 +1,75 @@
+package squat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	asip "github.com/ilyaglow/alexa-siteinfo-parser/v2"
+)
+
+func TestGenerate(t *testing.T) {
+	kinds := make(map[string]string)
+	for _, v := range Generate("google.com") {
+		if v.Domain == "google.com" {
+			t.Fatal("original domain is among variants")
+		}
+		if _, ok := kinds[v.Domain]; ok {
+			t.Fatalf("duplicate variant %s", v.Domain)
+		}
+		kinds[v.Domain] = v.Kind
+	}
+
+	for d, kind := range map[string]string{
+		"foogle.com":  BitFlip,
+		"g0ogle.com":  Homoglyph,
+		"gogle.com":   Omission,
+		"gooogle.com": Repetition,
+		"ogogle.com":  Transposition,
+		"google.ru":   TLDSwap,
+	} {
+		if kinds[d] != kind {
+			t.Fatalf("want %s as %s, got %q", d, kind, kinds[d])
+		}
+	}
+}
+
+type fakeRanker map[string]uint
+
+func (f fakeRanker) Rank(_ context.Context, domain string) (uint, uint, string, error) {
+	if domain == "broken.com" {
+		return 0, 0, "", errors.New("connection reset")
+	}
+	r, ok := f[domain]
+	if !ok {
+		return 0, 0, "", fmt.Errorf("rank %s: %w", domain, asip.ErrNoEnoughData)
+	}
+	return r, 0, "", nil
+}
+
+func TestCheck(t *testing.T) {
+	vs := []Variant{
+		{"gogle.com", Omission},
+		{"broken.com", BitFlip},
+		{"google.ru", TLDSwap},
+		{"goggle.com", BitFlip},
+	}
+	fs := Check(context.Background(), fakeRanker{"google.ru": 30, "goggle.com": 20000}, vs)
+
+	if fs[0].Ranked || fs[0].Err != nil {
+		t.Fatalf("want gogle.com unranked without error, got %v", fs[0])
+	}
+	if fs[1].Err == nil {
+		t.Fatal("want lookup error for broken.com")
+	}
+
+	want := []Finding{
+		{Variant: vs[2], Ranked: true, GlobalRank: 30},
+		{Variant: vs[3], Ranked: true, GlobalRank: 20000},
+	}
+	if got := Ranked(fs); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,139 @@
+package asip
+
+import (
+	"sort"
+)
+
+// summaryTop limits lists of a Summary.
+const summaryTop = 10
+
+// Summary describes a batch of sites as a whole.
+type Summary struct {
+	Sites      int
+	Ranked     int
+	MedianRank uint
+	Countries  []Count // main countries of the sites
+	Categories []Count // most common categories
+	Upstreams  []Count // upstream sites shared by at least two sites
+}
+
+// Count is a number of sites sharing a value.
+type Count struct {
+	Value string
+	N     int
+}
+
+// Summarize computes distributions over sites, nil sites of failed lookups
+// are skipped.
+func Summarize(sites []*Site) Summary {
+	var (
+		sum        Summary
+		ranks      []uint
+		countries  = make(map[string]int)
+		categories = make(map[string]int)
+		upstreams  = make(map[string]int)
+	)
+	for _, s := range sites {
+		if s == nil {
+			continue
+		}
+		sum.Sites++
+
+		if s.GlobalRank != 0 {
+			ranks = append(ranks, s.GlobalRank)
+		}
+		if s.MainCountry != "" {
+			countries[s.MainCountry]++
+		}
+		for _, c := range unique(s.Categories) {
+			categories[c]++
+		}
+
+		var us []string
+		for _, u := range s.Upstreams {
+			us = append(us, u.Site)
+		}
+		for _, u := range unique(us) {
+			upstreams[u]++
+		}
+	}
+
+	sum.Ranked = len(ranks)
+	sum.MedianRank = median(ranks)
+	sum.Countries = top(countries, 1)
+	sum.Categories = top(categories, 1)
+	sum.Upstreams = top(upstreams, 2)
+	return sum
+}
+
+// Percentiles sets BatchPercentile of every ranked site to a share of
+// ranked sites in the batch it outranks or ties with, so the best one gets
+// 100. Unranked sites get 0.
+func Percentiles(sites []*Site) {
+	var ranks []uint
+	for _, s := range sites {
+		if s != nil && s.GlobalRank != 0 {
+			ranks = append(ranks, s.GlobalRank)
+		}
+	}
+	sort.Slice(ranks, func(i, j int) bool { return ranks[i] < ranks[j] })
+
+	for _, s := range sites {
+		if s == nil {
+			continue
+		}
+		s.BatchPercentile = 0
+		if s.GlobalRank == 0 {
+			continue
+		}
+		better := sort.Search(len(ranks), func(i int) bool { return ranks[i] >= s.GlobalRank })
+		s.BatchPercentile = float64(len(ranks)-better) * 100 / float64(len(ranks))
+	}
+}
+
+func median(ns []uint) uint {
+	if len(ns) == 0 {
+		return 0
+	}
+	sort.Slice(ns, func(i, j int) bool { return ns[i] < ns[j] })
+
+	m := len(ns) / 2
+	if len(ns)%2 == 0 {
+		return (ns[m-1] + ns[m]) / 2
+	}
+	return ns[m]
+}
+
+// top returns up to summaryTop most common values occurring at least
+// atLeast times.
+func top(counts map[string]int, atLeast int) []Count {
+	var cs []Count
+	for v, n := range counts {
+		if n >= atLeast {
+			cs = append(cs, Count{v, n})
+		}
+	}
+	sort.Slice(cs, func(i, j int) bool {
+		if cs[i].N != cs[j].N {
+			return cs[i].N > cs[j].N
+		}
+		return cs[i].Value < cs[j].Value
+	})
+
+	if len(cs) > summaryTop {
+		cs = cs[:summaryTop]
+	}
+	return cs
+}
+
+func unique(ss []string) []string {
+	seen := make(map[string]bool, len(ss))
+	var u []string
+	for _, s := range ss {
+		if !seen[s] {
+			seen[s] = true
+			u = append(u, s)
+		}
+	}
+	return u
+}
@@ -0,0 +1,65 @@
+package asip
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	sites := []*Site{
+		{
+			GlobalRank:  506,
+			MainCountry: "Russia",
+			Categories:  []string{"Finance", "Banking"},
+			Upstreams:   []Upstream{{Site: "yandex.ru"}, {Site: "google.com"}},
+		},
+		{
+			GlobalRank:  100,
+			MainCountry: "Russia",
+			Categories:  []string{"Banking"},
+			Upstreams:   []Upstream{{Site: "yandex.ru"}},
+		},
+		nil,
+		{
+			GlobalRank:  2000,
+			MainCountry: "Germany",
+			Upstreams:   []Upstream{{Site: "google.de"}},
+		},
+		{},
+	}
+
+	want := Summary{
+		Sites:      4,
+		Ranked:     3,
+		MedianRank: 506,
+		Countries:  []Count{{"Russia", 2}, {"Germany", 1}},
+		Categories: []Count{{"Banking", 2}, {"Finance", 1}},
+		Upstreams:  []Count{{"yandex.ru", 2}},
+	}
+	if got := Summarize(sites); !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	sites := []*Site{
+		{GlobalRank: 300},
+		{GlobalRank: 100},
+		nil,
+		{},
+		{GlobalRank: 300},
+		{GlobalRank: 5000},
+	}
+	Percentiles(sites)
+
+	var got []float64
+	for _, s := range sites {
+		if s != nil {
+			got = append(got, s.BatchPercentile)
+		}
+	}
+	want := []float64{75, 100, 0, 75, 25}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,101 @@
+package asip
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ilyaglow/alexa-siteinfo-parser/v2/parse"
+)
+
+// Timeouts are budgets of the stages of a lookup, zero ones are unlimited.
+type Timeouts struct {
+	Connect time.Duration // until the response headers arrive, retries included
+	Read    time.Duration // reading the body
+	Parse   time.Duration // extracting the Site from the page
+}
+
+// WithTimeouts bounds the stages of every lookup separately, e.g. to give
+// up early on a huge hostile page while waiting long for a slow server.
+func WithTimeouts(t Timeouts) Option {
+	return func(conf *Conf) {
+		conf.page.timeouts = t
+	}
+}
+
+// TimeoutError is returned when a stage of a lookup runs out of its budget,
+// it matches context.DeadlineExceeded.
+type TimeoutError struct {
+	Stage   string // connect, read or parse
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("asip: %s timed out after %s", e.Stage, e.Timeout)
+}
+
+// Unwrap makes errors.Is match context.DeadlineExceeded.
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// stages cancel a context once the current stage runs out of its budget.
+type stages struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	timer  *time.Timer
+}
+
+func newStages(ctx context.Context) *stages {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &stages{ctx: ctx, cancel: cancel}
+}
+
+// start begins the named stage limited to d, ending the previous one.
+func (s *stages) start(name string, d time.Duration) {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if d > 0 {
+		s.timer = time.AfterFunc(d, func() {
+			s.cancel(&TimeoutError{name, d})
+		})
+	}
+}
+
+// stop releases the context.
+func (s *stages) stop() {
+	s.start("", 0)
+	s.cancel(nil)
+}
+
+// err replaces err with a TimeoutError of the stage that ran out.
+func (s *stages) err(err error) error {
+	if te, ok := context.Cause(s.ctx).(*TimeoutError); ok && err != nil {
+		return te
+	}
+	return err
+}
+
+// parseWithin parses r giving up once ctx is done, even in the middle of a
+// section; the parse itself stops at the next section.
+func parseWithin(ctx context.Context, r io.Reader, opts []parse.Option) (*Site, error) {
+	type result struct {
+		s   *Site
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		s, err := parse.ParseContext(ctx, r, opts...)
+		done <- result{s, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.s, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}